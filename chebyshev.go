@@ -0,0 +1,198 @@
+package numerics
+
+import (
+	"math"
+)
+
+const (
+	chebRootsGridFactor = 4
+	chebRootsMaxIter    = 100
+)
+
+// Chebyshev denotes a truncated Chebyshev series approximating a function on the
+// interval [a, b]
+type Chebyshev struct {
+	a, b   float64
+	coeffs []float64
+}
+
+// ChebFit approximates the function f on the interval [a, b] by a Chebyshev series
+// of n terms, evaluating f at the n Chebyshev nodes. Based on Numerical Recipes in C,
+// Second Edition, Section 5.8
+func ChebFit(f func(x float64) float64, a, b float64, n int) *Chebyshev {
+
+	if n < 1 {
+		panic("number of terms must be positive")
+	}
+
+	// Evaluate the function at the Chebyshev nodes
+	bma, bpa := 0.5*(b-a), 0.5*(b+a)
+	fx := make([]float64, n)
+	for k := 0; k < n; k++ {
+		y := math.Cos(math.Pi * (float64(k) + 0.5) / float64(n))
+		fx[k] = f(y*bma + bpa)
+	}
+
+	obj := Chebyshev{
+		a:      a,
+		b:      b,
+		coeffs: make([]float64, n),
+	}
+
+	fac := 2. / float64(n)
+	for j := 0; j < n; j++ {
+		sum := 0.
+		for k := 0; k < n; k++ {
+			sum += fx[k] * math.Cos(math.Pi*float64(j)*(float64(k)+0.5)/float64(n))
+		}
+		obj.coeffs[j] = fac * sum
+	}
+
+	return &obj
+}
+
+// Coefficients returns the coefficients of the series (with the first term being
+// counted with half its value)
+func (c *Chebyshev) Coefficients() []float64 {
+	return c.coeffs
+}
+
+// Interval returns the interval [a, b] on which the series is defined
+func (c *Chebyshev) Interval() (float64, float64) {
+	return c.a, c.b
+}
+
+// Eval evaluates the series at x via Clenshaw's recurrence
+func (c *Chebyshev) Eval(x float64) float64 {
+
+	y := (2.*x - c.a - c.b) / (c.b - c.a)
+	y2 := 2. * y
+
+	d, dd := 0., 0.
+	for j := len(c.coeffs) - 1; j >= 1; j-- {
+		d, dd = y2*d-dd+c.coeffs[j], d
+	}
+
+	return y*d - dd + 0.5*c.coeffs[0]
+}
+
+// Derivative returns the Chebyshev series of the derivative of the approximated
+// function
+func (c *Chebyshev) Derivative() *Chebyshev {
+
+	n := len(c.coeffs)
+	obj := Chebyshev{
+		a:      c.a,
+		b:      c.b,
+		coeffs: make([]float64, n),
+	}
+	if n < 2 {
+		return &obj
+	}
+
+	cder := obj.coeffs
+	cder[n-2] = 2. * float64(n-1) * c.coeffs[n-1]
+	for j := n - 2; j > 0; j-- {
+		cder[j-1] = cder[j+1] + 2.*float64(j)*c.coeffs[j]
+	}
+
+	scale := 2. / (c.b - c.a)
+	for j := range cder {
+		cder[j] *= scale
+	}
+
+	return &obj
+}
+
+// Integral returns the Chebyshev series of the indefinite integral of the approximated
+// function, chosen to vanish at the lower boundary a. The series has the same number of
+// coefficients as the original one (truncating the highest order term), except for a
+// constant series, whose (linear) integral requires two coefficients
+func (c *Chebyshev) Integral() *Chebyshev {
+
+	n := len(c.coeffs)
+	if n == 1 {
+		n = 2
+	}
+	obj := Chebyshev{
+		a:      c.a,
+		b:      c.b,
+		coeffs: make([]float64, n),
+	}
+
+	con := 0.25 * (c.b - c.a)
+	cint := obj.coeffs
+	for j := 1; j < n; j++ {
+		next := 0.
+		if j+1 < len(c.coeffs) {
+			next = c.coeffs[j+1]
+		}
+		cint[j] = con * (c.coeffs[j-1] - next) / float64(j)
+	}
+
+	// Choose the constant of integration such that the integral vanishes at a
+	sum, fac := 0., 1.
+	for j := 1; j < n; j++ {
+		sum += fac * cint[j]
+		fac = -fac
+	}
+	cint[0] = 2. * sum
+
+	return &obj
+}
+
+// Roots returns all roots of the series within [a, b] in ascending order, located by
+// scanning for sign changes on a grid and refining them via bisection. Roots of even
+// multiplicity (i.e. without a sign change) may not be detected
+func (c *Chebyshev) Roots() []float64 {
+
+	nGrid := chebRootsGridFactor*len(c.coeffs) + 1
+	step := (c.b - c.a) / float64(nGrid-1)
+
+	var roots []float64
+	xPrev, fPrev := c.a, c.Eval(c.a)
+	if fPrev == 0 {
+		roots = append(roots, xPrev)
+	}
+	for i := 1; i < nGrid; i++ {
+		x := c.a + float64(i)*step
+		if i == nGrid-1 {
+			x = c.b
+		}
+		fx := c.Eval(x)
+
+		if fx == 0 {
+			roots = append(roots, x)
+		} else if fPrev != 0 && Sign(fx) != Sign(fPrev) {
+			roots = append(roots, c.bisect(xPrev, x, fPrev))
+		}
+		xPrev, fPrev = x, fx
+	}
+
+	return roots
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// bisect refines a bracketed root of the series to (close to) machine precision
+func (c *Chebyshev) bisect(lo, hi, fLo float64) float64 {
+
+	for i := 0; i < chebRootsMaxIter; i++ {
+		mid := 0.5 * (lo + hi)
+		if mid == lo || mid == hi {
+			break
+		}
+
+		fMid := c.Eval(mid)
+		if fMid == 0 {
+			return mid
+		}
+		if Sign(fMid) == Sign(fLo) {
+			lo, fLo = mid, fMid
+		} else {
+			hi = mid
+		}
+	}
+
+	return 0.5 * (lo + hi)
+}
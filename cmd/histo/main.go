@@ -0,0 +1,304 @@
+// histo reads numbers (or durations) from stdin and / or files and prints their histogram
+// along with summary statistics, e.g.
+//
+//	seq 1 1000 | awk '{print sqrt($1)}' | histo -bins 20
+//	grep -o 'took [0-9.]*ms' app.log | cut -d' ' -f2 | histo -duration
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fako1024/numerics/hist"
+)
+
+// errUsage denotes an invalid combination of flags (reported along with the usage)
+var errUsage = errors.New("invalid usage")
+
+type config struct {
+	nBins      int
+	xMin, xMax string
+	logScale   bool
+	edges      string
+	durations  bool
+	width      int
+}
+
+func main() {
+
+	var cfg config
+	flag.IntVar(&cfg.nBins, "bins", 0, "number of bins (0: automatic choice via Freedman-Diaconis rule)")
+	flag.StringVar(&cfg.xMin, "min", "", "lower boundary of the x axis (default: minimum of the data)")
+	flag.StringVar(&cfg.xMax, "max", "", "upper boundary of the x axis (default: maximum of the data)")
+	flag.BoolVar(&cfg.logScale, "log", false, "use logarithmic binning, i.e. bins equidistant in log10(x) (requires positive values)")
+	flag.StringVar(&cfg.edges, "edges", "", "comma-separated list of ascending bin edges (overrides -bins, -min, -max and -log)")
+	flag.BoolVar(&cfg.durations, "duration", false, "parse input as durations (e.g. 1.5ms) instead of numbers")
+	flag.IntVar(&cfg.width, "width", 50, "maximum width of the histogram bars (in characters)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [file ...]\n\nReads whitespace-separated values from the files (or stdin) and prints their histogram.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var err error
+	if cfg.durations {
+		err = run(os.Stdout, flag.Args(), cfg, parseDuration)
+	} else {
+		err = run(os.Stdout, flag.Args(), cfg, parseFloat)
+	}
+	if errors.Is(err, errUsage) {
+		fmt.Fprintln(flag.CommandLine.Output(), err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseFloat(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	return time.ParseDuration(s)
+}
+
+func run[T hist.Number](w io.Writer, files []string, cfg config, parse func(string) (T, error)) error {
+
+	if err := checkFlags(cfg, parse); err != nil {
+		return err
+	}
+
+	values, err := readValues(files, parse)
+	if err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("no values read")
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i] < values[j]
+	})
+
+	edges, err := binning(values, cfg, parse)
+	if err != nil {
+		return err
+	}
+
+	binEdges := make([]T, len(edges))
+	for i, edge := range edges {
+		binEdges[i] = T(edge)
+		if i > 0 && !(binEdges[i] > binEdges[i-1]) {
+			return fmt.Errorf("bin edges not representable by value type, reduce number of bins")
+		}
+	}
+	h := hist.NewH1Edges(binEdges)
+	for _, v := range values {
+		h.Fill(v)
+	}
+	if err := h.Print(w, hist.WithMaxBarWidth(cfg.width), hist.WithAbsoluteScale(), hist.WithANSIColors()); err != nil {
+		return err
+	}
+
+	printSummary(w, values)
+
+	return nil
+}
+
+func readValues[T hist.Number](files []string, parse func(string) (T, error)) ([]T, error) {
+
+	var values []T
+	read := func(r io.Reader, name string) error {
+		scanner := bufio.NewScanner(r)
+		scanner.Split(bufio.ScanWords)
+		for scanner.Scan() {
+			v, err := parse(scanner.Text())
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			values = append(values, v)
+		}
+		return scanner.Err()
+	}
+
+	if len(files) == 0 {
+		return values, read(os.Stdin, "stdin")
+	}
+
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		err = read(f, file)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// checkFlags validates the combination of flags (independent of the values read)
+func checkFlags[T hist.Number](cfg config, parse func(string) (T, error)) error {
+
+	if cfg.nBins < 0 {
+		return fmt.Errorf("%w: number of bins must not be negative, have %d", errUsage, cfg.nBins)
+	}
+	if cfg.width <= 0 {
+		return fmt.Errorf("%w: width must be positive, have %d", errUsage, cfg.width)
+	}
+	if cfg.edges != "" {
+		return nil
+	}
+
+	var bounds [2]float64
+	for i, s := range []string{cfg.xMin, cfg.xMax} {
+		if s == "" {
+			continue
+		}
+		v, err := parse(s)
+		if err != nil {
+			return fmt.Errorf("%w: invalid axis boundary: %w", errUsage, err)
+		}
+		bounds[i] = float64(v)
+		if cfg.logScale && bounds[i] <= 0 {
+			return fmt.Errorf("%w: logarithmic binning requires positive axis boundaries, have %s", errUsage, s)
+		}
+	}
+	if cfg.xMin != "" && cfg.xMax != "" && bounds[0] >= bounds[1] {
+		return fmt.Errorf("%w: -min (%s) must be below -max (%s)", errUsage, cfg.xMin, cfg.xMax)
+	}
+
+	return nil
+}
+
+// binning determines the bin edges from the (sorted) values and the configuration
+func binning[T hist.Number](values []T, cfg config, parse func(string) (T, error)) ([]float64, error) {
+
+	if cfg.edges != "" {
+		fields := strings.Split(cfg.edges, ",")
+		edges := make([]float64, len(fields))
+		for i, field := range fields {
+			v, err := parse(strings.TrimSpace(field))
+			if err != nil {
+				return nil, fmt.Errorf("invalid bin edge: %w", err)
+			}
+			edges[i] = float64(v)
+			if i > 0 && !(edges[i] > edges[i-1]) {
+				return nil, fmt.Errorf("bin edges must be strictly ascending")
+			}
+		}
+		if len(edges) < 2 {
+			return nil, fmt.Errorf("at least two bin edges are required")
+		}
+
+		return edges, nil
+	}
+
+	// Transform the values for logarithmic binning, i.e. equidistant bins in log10(x)
+	xs := make([]float64, len(values))
+	for i, v := range values {
+		xs[i] = float64(v)
+		if cfg.logScale {
+			if xs[i] <= 0 {
+				return nil, fmt.Errorf("logarithmic binning requires positive values, got %v", v)
+			}
+			xs[i] = math.Log10(xs[i])
+		}
+	}
+
+	xMin, xMax := xs[0], xs[len(xs)-1]
+	for _, bound := range []struct {
+		s string
+		x *float64
+	}{{cfg.xMin, &xMin}, {cfg.xMax, &xMax}} {
+		if bound.s == "" {
+			continue
+		}
+		v, err := parse(bound.s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid axis boundary: %w", err)
+		}
+		*bound.x = float64(v)
+		if cfg.logScale {
+			*bound.x = math.Log10(*bound.x)
+		}
+	}
+	if xMax <= xMin {
+
+		// A single explicit boundary must still leave a range w.r.t. the data
+		if cfg.xMin != "" || cfg.xMax != "" {
+			return nil, fmt.Errorf("%w: axis boundary outside of the range of the data [%v, %v]", errUsage, values[0], values[len(values)-1])
+		}
+		xMax = xMin + 1.
+	}
+
+	n := cfg.nBins
+	if n <= 0 {
+		n = autoBins(xs, xMin, xMax)
+	}
+
+	edges := make([]float64, n+1)
+	for i := range edges {
+		edges[i] = xMin + float64(i)*(xMax-xMin)/float64(n)
+		if cfg.logScale {
+			edges[i] = math.Pow(10., edges[i])
+		}
+	}
+
+	// Avoid the extreme values ending up in the flow bins due to rounding
+	if cfg.logScale && cfg.xMin == "" {
+		edges[0] = math.Min(edges[0], float64(values[0]))
+	}
+	if cfg.logScale && cfg.xMax == "" {
+		edges[n] = math.Max(edges[n], float64(values[len(values)-1]))
+	}
+
+	return edges, nil
+}
+
+// autoBins determines the number of bins via the Freedman-Diaconis rule, falling back
+// to Sturges' rule for degenerate distributions
+func autoBins(xs []float64, xMin, xMax float64) int {
+
+	n := len(xs)
+	iqr := xs[(3*n)/4] - xs[n/4]
+	if iqr > 0 {
+		width := 2. * iqr / math.Cbrt(float64(n))
+		return min(max(int(math.Ceil((xMax-xMin)/width)), 1), 200)
+	}
+
+	return int(math.Ceil(math.Log2(float64(n)))) + 1
+}
+
+func printSummary[T hist.Number](w io.Writer, values []T) {
+
+	n := len(values)
+	sum, sumSq := 0., 0.
+	for _, v := range values {
+		sum += float64(v)
+		sumSq += float64(v) * float64(v)
+	}
+	mean := sum / float64(n)
+	stdDev := math.Sqrt(math.Max(0., sumSq/float64(n)-mean*mean))
+
+	quantile := func(q float64) T {
+		return values[min(int(q*float64(n)), n-1)]
+	}
+
+	fmt.Fprintf(w, "\nCount: %d\nSum: %v\nMin: %v\nMax: %v\nMean: %v\nStdDev: %v\nMedian: %v\nP90: %v\nP99: %v\n",
+		n, T(sum), values[0], values[n-1], T(mean), T(stdDev), quantile(0.5), quantile(0.9), quantile(0.99))
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeInput(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write input file: %s", err)
+	}
+
+	return path
+}
+
+func TestRun(t *testing.T) {
+
+	file := writeInput(t, "1 2 2 3\n3 3 4 10\n")
+
+	buf := new(bytes.Buffer)
+	if err := run(buf, []string{file}, config{nBins: 3, xMin: "0", xMax: "12", width: 20}, parseFloat); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, expected := range []string{"Count: 8", "Sum: 28", "Min: 1", "Max: 10", "Mean: 3.5", "Median: 3"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatalf("Missing %q in output:\n%s", expected, buf.String())
+		}
+	}
+
+	// Degenerate data (without explicit boundaries), logarithmic binning and explicit edges
+	for _, cfg := range []config{
+		{width: 20},
+		{logScale: true, width: 20},
+		{logScale: true, xMin: "0.5", width: 20},
+		{edges: "0, 5, 20", width: 20},
+	} {
+		input := file
+		if cfg == (config{width: 20}) {
+			input = writeInput(t, "7 7 7")
+		}
+		buf.Reset()
+		if err := run(buf, []string{input}, cfg, parseFloat); err != nil || !strings.Contains(buf.String(), "Count: ") {
+			t.Fatalf("Unexpected result for %+v: %v\n%s", cfg, err, buf.String())
+		}
+	}
+
+	durations := writeInput(t, "1ms 1.5ms 2ms 300us")
+	buf.Reset()
+	if err := run(buf, []string{durations}, config{width: 20}, parseDuration); err != nil || !strings.Contains(buf.String(), "Max: "+(2*time.Millisecond).String()) {
+		t.Fatalf("Unexpected result for durations: %v\n%s", err, buf.String())
+	}
+}
+
+func TestFlagValidation(t *testing.T) {
+
+	file := writeInput(t, "1 2 2 3\n3 3 4 10\n")
+
+	for _, cs := range []struct {
+		name  string
+		cfg   config
+		usage bool
+	}{
+		{name: "EqualBoundaries", cfg: config{xMin: "5", xMax: "5", width: 20}, usage: true},
+		{name: "InvertedBoundaries", cfg: config{xMin: "5", xMax: "1", width: 20}, usage: true},
+		{name: "LogZeroMin", cfg: config{logScale: true, xMin: "0", width: 20}, usage: true},
+		{name: "LogNegativeMax", cfg: config{logScale: true, xMax: "-1", width: 20}, usage: true},
+		{name: "InvalidBoundary", cfg: config{xMin: "abc", width: 20}, usage: true},
+		{name: "NegativeBins", cfg: config{nBins: -1, width: 20}, usage: true},
+		{name: "ZeroWidth", cfg: config{}, usage: true},
+		{name: "BoundaryAboveData", cfg: config{xMin: "20", width: 20}, usage: true},
+		{name: "UnorderedEdges", cfg: config{edges: "0, 5, 2", width: 20}},
+		{name: "SingleEdge", cfg: config{edges: "1", width: 20}},
+	} {
+		t.Run(cs.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := run(buf, []string{file}, cs.cfg, parseFloat)
+			if err == nil || errors.Is(err, errUsage) != cs.usage {
+				t.Fatalf("Unexpected error for %+v: %v", cs.cfg, err)
+			}
+			if buf.Len() != 0 {
+				t.Fatalf("Unexpected output for invalid flags: %s", buf.String())
+			}
+		})
+	}
+
+	// Non-positive values cannot be binned logarithmically
+	if err := run(new(bytes.Buffer), []string{writeInput(t, "-1 2 3")}, config{logScale: true, width: 20}, parseFloat); err == nil {
+		t.Fatalf("Unexpected success for logarithmic binning of negative values")
+	}
+}
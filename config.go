@@ -0,0 +1,118 @@
+package numerics
+
+import (
+	"fmt"
+	"math"
+)
+
+// NaNPolicy defines how failures (e.g. arguments outside of the domain of a function or
+// a lack of convergence) are reported
+type NaNPolicy int
+
+const (
+
+	// ReturnNaN reports failures by returning NaN (without an error)
+	ReturnNaN NaNPolicy = iota
+
+	// ReturnError reports failures by returning NaN along with a descriptive error
+	ReturnError
+)
+
+// Config defines the precision parameters used by iterative numerical methods, replacing
+// hard-coded constants and allowing for (e.g. platform-dependent) tuning of tolerances
+type Config struct {
+
+	// Epsilon denotes the relative tolerance at which an iteration is considered converged
+	Epsilon float64
+
+	// MaxIterations denotes the maximum number of iterations to perform
+	MaxIterations int
+
+	// NaNPolicy defines how failures are reported
+	NaNPolicy NaNPolicy
+}
+
+// DefaultConfig denotes the configuration used by all functions that do not take a Config
+// explicitly. It may be adapted globally, but must not be modified concurrently with its use
+var DefaultConfig = Config{
+	Epsilon:       3e-14,
+	MaxIterations: 200,
+	NaNPolicy:     ReturnNaN,
+}
+
+// BetaIncompleteRegular returns the value of the regularized incomplete beta
+// function Iₓ(a, b), see BetaIncompleteRegular()
+func (c Config) BetaIncompleteRegular(x, a, b float64) (float64, error) {
+
+	// Based on Numerical Recipes in C, section 6.4. This uses the
+	// continued fraction definition of I:
+	//
+	//  (xᵃ*(1-x)ᵇ)/(a*B(a,b)) * (1/(1+(d₁/(1+(d₂/(1+...))))))
+	//
+	// where B(a,b) is the beta function and
+	//
+	//  d_{2m+1} = -(a+m)(a+b+m)x/((a+2m)(a+2m+1))
+	//  d_{2m}   = m(b-m)x/((a+2m-1)(a+2m))
+	if x < 0 || x > 1 {
+		return c.fail(fmt.Errorf("%w: x=%v not in [0, 1]", ErrDomain, x))
+	}
+	bt := 0.0
+	if 0 < x && x < 1 {
+
+		// Compute the coefficient before the continued
+		// fraction.
+		bt = math.Exp(Lgamma(a+b) - Lgamma(a) - Lgamma(b) +
+			a*math.Log(x) + b*math.Log(1-x))
+	}
+
+	var res, cf float64
+	if x < (a+1)/(a+b+2) {
+		// Compute continued fraction directly.
+		cf = betacf(x, a, b, c.Epsilon, c.MaxIterations)
+		res = bt * cf / a
+	} else {
+		// Compute continued fraction after symmetry transform.
+		cf = betacf(1-x, b, a, c.Epsilon, c.MaxIterations)
+		res = 1 - bt*cf/b
+	}
+
+	if math.IsNaN(res) {
+		if math.IsNaN(cf) {
+			return c.fail(fmt.Errorf("%w: continued fraction (a=%v, b=%v) after %d iterations", ErrNoConvergence, a, b, c.MaxIterations))
+		}
+		return c.fail(fmt.Errorf("%w: a=%v, b=%v", ErrDomain, a, b))
+	}
+
+	return res, nil
+}
+
+// BetaIncomplete returns the value of the (non-regularized) incomplete beta function,
+// see BetaIncomplete()
+func (c Config) BetaIncomplete(x, a, b float64) (float64, error) {
+	res, err := c.BetaIncompleteRegular(x, a, b)
+	if err != nil {
+		return res, err
+	}
+
+	// Report results whose magnitude was lost due to over- / underflow of B(a, b)
+	beta := Beta(a, b)
+	if c.NaNPolicy == ReturnError && res != 0 && (beta == 0 || math.IsInf(beta, 0)) {
+		return res * beta, fmt.Errorf("%w: B(a, b) over- / underflows (a=%v, b=%v)", ErrPrecisionLoss, a, b)
+	}
+
+	return res * beta, nil
+}
+
+// withErrors returns a copy of the configuration reporting failures via errors
+func (c Config) withErrors() Config {
+	c.NaNPolicy = ReturnError
+	return c
+}
+
+// fail reports a failure according to the NaN policy
+func (c Config) fail(err error) (float64, error) {
+	if c.NaNPolicy == ReturnError {
+		return math.NaN(), err
+	}
+	return math.NaN(), nil
+}
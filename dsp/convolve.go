@@ -0,0 +1,159 @@
+package dsp
+
+import (
+	"github.com/fako1024/numerics/fft"
+)
+
+// directThreshold denotes the kernel length up to which the Auto method uses
+// direct summation
+const directThreshold = 32
+
+// Convolution denotes the parameters of a convolution / correlation
+type Convolution struct {
+	mode     Mode
+	boundary Boundary
+	method   Method
+}
+
+// Convolve computes the discrete convolution of the signal x with a kernel using
+// the provided options (by default yielding the Full result with zero boundaries)
+func Convolve(x, kernel []float64, options ...func(*Convolution)) []float64 {
+
+	obj := &Convolution{
+		mode:     Full,
+		boundary: Zero,
+		method:   Auto,
+	}
+
+	// Execute functional options (if any), see options.go for implementation
+	for _, option := range options {
+		option(obj)
+	}
+
+	return obj.convolve(x, kernel)
+}
+
+// CrossCorrelate computes the discrete cross-correlation of the signals x and y using
+// the provided options. For the Full result, element k corresponds to the lag k-(len(y)-1),
+// i.e. the sum over x[i+lag]*y[i]
+func CrossCorrelate(x, y []float64, options ...func(*Convolution)) []float64 {
+
+	reversed := make([]float64, len(y))
+	for i := range y {
+		reversed[len(y)-1-i] = y[i]
+	}
+
+	return Convolve(x, reversed, options...)
+}
+
+// EstimateLag returns the lag (in samples) by which the signal x is shifted with
+// respect to the signal y, determined via the maximum of their cross-correlation
+func EstimateLag(x, y []float64) int {
+
+	corr := CrossCorrelate(x, y)
+	if len(corr) == 0 {
+		return 0
+	}
+
+	maxIdx := 0
+	for i := range corr {
+		if corr[i] > corr[maxIdx] {
+			maxIdx = i
+		}
+	}
+
+	return maxIdx - (len(y) - 1)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (c *Convolution) convolve(x, kernel []float64) []float64 {
+
+	n, m := len(x), len(kernel)
+	if n == 0 || m == 0 {
+		return []float64{}
+	}
+
+	// Valid results do not depend on any boundary values
+	if c.mode == Valid {
+		if m > n {
+			return []float64{}
+		}
+		return c.compute(x, kernel)
+	}
+
+	// Extend the signal by m-1 elements on each side, yielding the full result
+	padded := make([]float64, n+2*(m-1))
+	for i := range padded {
+		padded[i] = c.boundary.extend(x, i-(m-1))
+	}
+	res := c.compute(padded, kernel)
+
+	if c.mode == Same {
+		offset := (m - 1) / 2
+		return res[offset : offset+n]
+	}
+
+	return res
+}
+
+// compute calculates the valid part of the convolution of x and the kernel
+func (c *Convolution) compute(x, kernel []float64) []float64 {
+
+	method := c.method
+	if method == Auto {
+		method = FFT
+		if len(kernel) <= directThreshold {
+			method = Direct
+		}
+	}
+
+	if method == Direct {
+		return convolveDirect(x, kernel)
+	}
+	return convolveFFT(x, kernel)
+}
+
+func convolveDirect(x, kernel []float64) []float64 {
+
+	n, m := len(x), len(kernel)
+	res := make([]float64, n-m+1)
+	for i := range res {
+		sum := 0.
+		for j := 0; j < m; j++ {
+			sum += x[i+m-1-j] * kernel[j]
+		}
+		res[i] = sum
+	}
+
+	return res
+}
+
+func convolveFFT(x, kernel []float64) []float64 {
+
+	n, m := len(x), len(kernel)
+
+	// Determine transform size large enough to avoid circular wrap-around
+	size := 2
+	for size < n+m-1 {
+		size <<= 1
+	}
+	plan := fft.NewRealPlan(size)
+
+	xPadded, kPadded := make([]float64, size), make([]float64, size)
+	copy(xPadded, x)
+	copy(kPadded, kernel)
+
+	xSpec, kSpec := make([]complex128, size/2+1), make([]complex128, size/2+1)
+	plan.Forward(xSpec, xPadded)
+	plan.Forward(kSpec, kPadded)
+	for i := range xSpec {
+		xSpec[i] *= kSpec[i]
+	}
+	plan.Inverse(xPadded, xSpec)
+
+	res := make([]float64, n-m+1)
+	copy(res, xPadded[m-1:n])
+
+	return res
+}
@@ -0,0 +1,83 @@
+package dsp
+
+// Mode denotes the extent of the output of a convolution / correlation
+type Mode int
+
+const (
+	// Full returns the complete result of length n+m-1
+	Full Mode = iota
+
+	// Same returns a result of the same length as the input signal, centered with
+	// respect to the full result
+	Same
+
+	// Valid returns only those n-m+1 elements computed without any boundary values
+	Valid
+)
+
+// Boundary denotes the method to extend a signal beyond its boundaries
+type Boundary int
+
+const (
+	// Zero extends the signal with zeros
+	Zero Boundary = iota
+
+	// Nearest extends the signal by repeating its first / last element
+	Nearest
+
+	// Reflect extends the signal by mirroring it about its edges (d c b a | a b c d | d c b a)
+	Reflect
+
+	// Periodic extends the signal by wrapping it around (a b c d | a b c d | a b c d)
+	Periodic
+)
+
+// Method denotes the algorithm used to compute a convolution / correlation
+type Method int
+
+const (
+	// Auto chooses a method based on the size of the input signal and kernel
+	Auto Method = iota
+
+	// Direct computes the result by direct summation, requiring O(n*m) operations
+	Direct
+
+	// FFT computes the result via fast Fourier transforms, requiring O((n+m) log(n+m))
+	// operations
+	FFT
+)
+
+// extend returns the value of the signal at (potentially out-of-range) index i
+// according to the boundary method
+func (b Boundary) extend(x []float64, i int) float64 {
+
+	n := len(x)
+	if i >= 0 && i < n {
+		return x[i]
+	}
+
+	switch b {
+	case Nearest:
+		if i < 0 {
+			return x[0]
+		}
+		return x[n-1]
+	case Reflect:
+		i %= 2 * n
+		if i < 0 {
+			i += 2 * n
+		}
+		if i >= n {
+			i = 2*n - 1 - i
+		}
+		return x[i]
+	case Periodic:
+		i %= n
+		if i < 0 {
+			i += n
+		}
+		return x[i]
+	}
+
+	return 0.
+}
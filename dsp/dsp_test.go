@@ -0,0 +1,164 @@
+package dsp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+const testEpsilon = 1e-9
+
+func TestConvolveTable(t *testing.T) {
+
+	type testCaseConvolve struct {
+		x, kernel []float64
+		options   []func(*Convolution)
+		expected  []float64
+	}
+
+	testCases := map[string]testCaseConvolve{
+		"Full": {
+			x:        []float64{1, 2, 3},
+			kernel:   []float64{0, 1, 0.5},
+			expected: []float64{0, 1, 2.5, 4, 1.5},
+		},
+		"Same": {
+			x:        []float64{1, 2, 3},
+			kernel:   []float64{0, 1, 0.5},
+			options:  []func(*Convolution){WithMode(Same)},
+			expected: []float64{1, 2.5, 4},
+		},
+		"Valid": {
+			x:        []float64{1, 2, 3},
+			kernel:   []float64{0, 1, 0.5},
+			options:  []func(*Convolution){WithMode(Valid)},
+			expected: []float64{2.5},
+		},
+		"SameNearest": {
+			x:        []float64{1, 2, 3},
+			kernel:   []float64{1, 1, 1},
+			options:  []func(*Convolution){WithMode(Same), WithBoundary(Nearest)},
+			expected: []float64{4, 6, 8},
+		},
+		"SameReflect": {
+			x:        []float64{1, 2, 3},
+			kernel:   []float64{1, 1, 1},
+			options:  []func(*Convolution){WithMode(Same), WithBoundary(Reflect)},
+			expected: []float64{4, 6, 8},
+		},
+		"SamePeriodic": {
+			x:        []float64{1, 2, 3},
+			kernel:   []float64{1, 1, 1},
+			options:  []func(*Convolution){WithMode(Same), WithBoundary(Periodic)},
+			expected: []float64{6, 6, 6},
+		},
+		"FFT": {
+			x:        []float64{1, 2, 3},
+			kernel:   []float64{0, 1, 0.5},
+			options:  []func(*Convolution){WithMethod(FFT)},
+			expected: []float64{0, 1, 2.5, 4, 1.5},
+		},
+	}
+
+	for testName, cs := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			compare(t, cs.expected, Convolve(cs.x, cs.kernel, cs.options...))
+		})
+	}
+}
+
+func TestConvolveMethods(t *testing.T) {
+	x, kernel := random(1000), random(77)
+
+	for _, mode := range []Mode{Full, Same, Valid} {
+		for _, boundary := range []Boundary{Zero, Nearest, Reflect, Periodic} {
+			compare(t,
+				Convolve(x, kernel, WithMode(mode), WithBoundary(boundary), WithMethod(Direct)),
+				Convolve(x, kernel, WithMode(mode), WithBoundary(boundary), WithMethod(FFT)),
+			)
+		}
+	}
+}
+
+func TestEstimateLag(t *testing.T) {
+	y := random(200)
+	x := make([]float64, len(y))
+	copy(x[13:], y)
+
+	if lag := EstimateLag(x, y); lag != 13 {
+		t.Fatalf("Unexpected lag estimate: want 13, have %d", lag)
+	}
+}
+
+func compare(t *testing.T, expected, have []float64) {
+	t.Helper()
+
+	if len(expected) != len(have) {
+		t.Fatalf("Unexpected result length: want %d, have %d", len(expected), len(have))
+	}
+	for i := range expected {
+		if math.Abs(expected[i]-have[i]) > testEpsilon {
+			t.Fatalf("Unexpected result at index %d: want %v, have %v", i, expected[i], have[i])
+		}
+	}
+}
+
+func random(n int) []float64 {
+	res := make([]float64, n)
+	for i := range res {
+		res[i] = rand.Float64() - 0.5
+	}
+	return res
+}
+
+func TestSavitzkyGolayCoefficients(t *testing.T) {
+	compare(t, []float64{-3. / 35., 12. / 35., 17. / 35., 12. / 35., -3. / 35.}, NewSavitzkyGolay(5, 2).Coefficients())
+	compare(t, []float64{-0.2, -0.1, 0, 0.1, 0.2}, NewSavitzkyGolay(5, 2, WithDerivative(1)).Coefficients())
+}
+
+func TestSavitzkyGolayPolynomial(t *testing.T) {
+
+	x, dx := make([]float64, 50), make([]float64, 50)
+	for i := range x {
+		ti := float64(i) * 0.1
+		x[i] = 2.*ti*ti*ti - ti + 3.
+		dx[i] = 6.*ti*ti - 1.
+	}
+
+	// A cubic polynomial must be preserved exactly (including the edges)
+	compare(t, x, SavitzkyGolayFilter(x, 7, 3))
+	compare(t, dx, SavitzkyGolayFilter(x, 7, 3, WithDerivative(1), WithSpacing(0.1)))
+}
+
+func TestFindPeaks(t *testing.T) {
+
+	xs, ys := make([]float64, 201), make([]float64, 201)
+	for i := range xs {
+		xs[i] = float64(i) * 0.05
+		ys[i] = 3.*math.Exp(-0.5*math.Pow((xs[i]-3.02)/0.2, 2)) + math.Exp(-0.5*math.Pow((xs[i]-7.)/0.3, 2))
+	}
+
+	peaks := FindPeaks(xs, ys)
+	if len(peaks) != 2 {
+		t.Fatalf("Unexpected number of peaks: want 2, have %d", len(peaks))
+	}
+	if math.Abs(peaks[0].X-3.02) > 1e-3 || math.Abs(peaks[0].Height-3.) > 1e-2 {
+		t.Fatalf("Unexpected first peak: have x=%.4f, height=%.4f", peaks[0].X, peaks[0].Height)
+	}
+	if fwhm := 2. * math.Sqrt(2.*math.Ln2) * 0.3; math.Abs(peaks[1].Width-fwhm) > 1e-2 {
+		t.Fatalf("Unexpected width of second peak: want %.4f, have %.4f", fwhm, peaks[1].Width)
+	}
+	if math.Abs(peaks[1].Prominence-1.) > 1e-2 {
+		t.Fatalf("Unexpected prominence of second peak: have %.4f", peaks[1].Prominence)
+	}
+
+	if peaks = FindPeaks(xs, ys, WithMinProminence(2.)); len(peaks) != 1 {
+		t.Fatalf("Unexpected number of peaks after prominence filter: want 1, have %d", len(peaks))
+	}
+	if peaks = FindPeaks(xs, ys, WithMinDistance(5.)); len(peaks) != 1 || peaks[0].Index != 60 {
+		t.Fatalf("Unexpected peaks after distance filter: %v", peaks)
+	}
+	if peaks = FindPeaks(nil, []float64{0, 1, 1, 1, 0}); len(peaks) != 1 || peaks[0].Index != 2 {
+		t.Fatalf("Unexpected plateau peak: %v", peaks)
+	}
+}
@@ -0,0 +1,84 @@
+package dsp
+
+// WithMode sets the extent of the output (Full, Same or Valid)
+func WithMode(mode Mode) func(*Convolution) {
+	return func(c *Convolution) {
+		c.mode = mode
+	}
+}
+
+// WithBoundary sets the method used to extend the signal beyond its boundaries
+func WithBoundary(boundary Boundary) func(*Convolution) {
+	return func(c *Convolution) {
+		c.boundary = boundary
+	}
+}
+
+// WithMethod sets a specific method used to compute the result (Direct or FFT)
+func WithMethod(method Method) func(*Convolution) {
+	return func(c *Convolution) {
+		c.method = method
+	}
+}
+
+// WithDerivative sets the order of the derivative computed by a Savitzky-Golay
+// filter (0 denoting plain smoothing)
+func WithDerivative(derivative int) func(*SavitzkyGolay) {
+	return func(s *SavitzkyGolay) {
+		s.derivative = derivative
+	}
+}
+
+// WithSpacing sets the sample spacing used to scale derivatives computed by a
+// Savitzky-Golay filter
+func WithSpacing(delta float64) func(*SavitzkyGolay) {
+	return func(s *SavitzkyGolay) {
+		s.delta = delta
+	}
+}
+
+// WithEdges sets the method used to extend the signal beyond its boundaries for a
+// Savitzky-Golay filter (instead of fitting the polynomial to the first / last window)
+func WithEdges(boundary Boundary) func(*SavitzkyGolay) {
+	return func(s *SavitzkyGolay) {
+		s.interpolateEdges = false
+		s.boundary = boundary
+	}
+}
+
+// WithMinHeight sets the minimum (interpolated) height of a peak
+func WithMinHeight(height float64) func(*PeakFinder) {
+	return func(p *PeakFinder) {
+		p.minHeight = height
+	}
+}
+
+// WithMinProminence sets the minimum prominence of a peak
+func WithMinProminence(prominence float64) func(*PeakFinder) {
+	return func(p *PeakFinder) {
+		p.minProminence = prominence
+	}
+}
+
+// WithMinWidth sets the minimum width of a peak (in units of the positions)
+func WithMinWidth(width float64) func(*PeakFinder) {
+	return func(p *PeakFinder) {
+		p.minWidth = width
+	}
+}
+
+// WithMinDistance sets the minimum distance between peaks (in units of the positions),
+// removing smaller peaks in the vicinity of higher ones
+func WithMinDistance(distance float64) func(*PeakFinder) {
+	return func(p *PeakFinder) {
+		p.minDistance = distance
+	}
+}
+
+// WithRelHeight sets the height (relative to the prominence) at which the width of a
+// peak is evaluated, e.g. 0.5 for the full width at half prominence
+func WithRelHeight(relHeight float64) func(*PeakFinder) {
+	return func(p *PeakFinder) {
+		p.relHeight = relHeight
+	}
+}
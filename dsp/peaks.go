@@ -0,0 +1,213 @@
+package dsp
+
+import (
+	"math"
+	"sort"
+)
+
+// Peak denotes a local maximum found in sampled data
+type Peak struct {
+
+	// Index denotes the sample index of the peak (the center of a plateau)
+	Index int
+
+	// X and Height denote the position and height of the peak, interpolated via a
+	// parabola through the peak sample and its neighbors
+	X, Height float64
+
+	// Prominence denotes the height of the peak relative to the higher of its two
+	// surrounding minima (bases)
+	Prominence float64
+
+	// Width denotes the width of the peak at the configured relative prominence height
+	// (by default half of the prominence), with Left / Right denoting the interpolated
+	// positions of the respective crossings
+	Width, Left, Right float64
+}
+
+// PeakFinder denotes the parameters for locating peaks
+type PeakFinder struct {
+	minHeight     float64
+	minProminence float64
+	minWidth      float64
+	minDistance   float64
+	relHeight     float64
+}
+
+// FindPeaks locates all local maxima of the sampled data ys at positions xs (which
+// may be nil, in which case the sample indices are used) that fulfil the provided
+// options. The peaks are returned in ascending order of their position
+func FindPeaks(xs, ys []float64, options ...func(*PeakFinder)) []Peak {
+
+	obj := &PeakFinder{
+		minHeight:     math.Inf(-1),
+		minProminence: 0.,
+		minWidth:      0.,
+		minDistance:   0.,
+		relHeight:     0.5,
+	}
+
+	// Execute functional options (if any), see options.go for implementation
+	for _, option := range options {
+		option(obj)
+	}
+
+	if xs == nil {
+		xs = make([]float64, len(ys))
+		for i := range xs {
+			xs[i] = float64(i)
+		}
+	}
+	if len(xs) != len(ys) {
+		panic("number of positions does not match number of samples")
+	}
+
+	return obj.find(xs, ys)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (p *PeakFinder) find(xs, ys []float64) []Peak {
+
+	var peaks []Peak
+	for _, idx := range localMaxima(ys) {
+		peak := Peak{Index: idx}
+		peak.X, peak.Height = interpolatePeak(xs, ys, idx)
+
+		leftBase, rightBase := prominenceBases(ys, idx)
+		peak.Prominence = ys[idx] - math.Max(ys[leftBase], ys[rightBase])
+
+		ref := ys[idx] - p.relHeight*peak.Prominence
+		peak.Left = crossing(xs, ys, idx, leftBase, ref)
+		peak.Right = crossing(xs, ys, idx, rightBase, ref)
+		peak.Width = peak.Right - peak.Left
+
+		if peak.Height < p.minHeight || peak.Prominence < p.minProminence || peak.Width < p.minWidth {
+			continue
+		}
+		peaks = append(peaks, peak)
+	}
+
+	if p.minDistance > 0 {
+		peaks = filterDistance(peaks, p.minDistance)
+	}
+
+	return peaks
+}
+
+// localMaxima returns the indices of all local maxima, using the center of flat
+// plateaus
+func localMaxima(ys []float64) []int {
+
+	var res []int
+	for i := 1; i < len(ys)-1; i++ {
+		if ys[i] <= ys[i-1] {
+			continue
+		}
+
+		// Skip to the end of a potential plateau
+		j := i
+		for j < len(ys)-1 && ys[j+1] == ys[i] {
+			j++
+		}
+		if j < len(ys)-1 && ys[j+1] < ys[i] {
+			res = append(res, (i+j)/2)
+		}
+		i = j
+	}
+
+	return res
+}
+
+// interpolatePeak determines the position and height of the parabola through the
+// peak sample and its neighbors
+func interpolatePeak(xs, ys []float64, idx int) (float64, float64) {
+
+	y0, y1, y2 := ys[idx-1], ys[idx], ys[idx+1]
+	denom := y0 - 2*y1 + y2
+	if denom >= 0 {
+		return xs[idx], y1
+	}
+
+	offset := 0.5 * (y0 - y2) / denom
+	height := y1 - 0.25*(y0-y2)*offset
+	if offset >= 0 {
+		return xs[idx] + offset*(xs[idx+1]-xs[idx]), height
+	}
+
+	return xs[idx] + offset*(xs[idx]-xs[idx-1]), height
+}
+
+// prominenceBases returns the indices of the minima on either side of the peak up
+// to the next higher sample (or the boundary of the data)
+func prominenceBases(ys []float64, idx int) (int, int) {
+
+	left := idx
+	for i := idx - 1; i >= 0 && ys[i] <= ys[idx]; i-- {
+		if ys[i] < ys[left] {
+			left = i
+		}
+	}
+
+	right := idx
+	for i := idx + 1; i < len(ys) && ys[i] <= ys[idx]; i++ {
+		if ys[i] < ys[right] {
+			right = i
+		}
+	}
+
+	return left, right
+}
+
+// crossing returns the interpolated position at which the data drops to the reference
+// height when walking from the peak towards its base
+func crossing(xs, ys []float64, idx, base int, ref float64) float64 {
+
+	step := 1
+	if base < idx {
+		step = -1
+	}
+
+	i := idx
+	for i != base && ys[i] > ref {
+		i += step
+	}
+	if ys[i] >= ref || i == idx {
+		return xs[i]
+	}
+
+	prev := i - step
+	return xs[i] + (ref-ys[i])*(xs[prev]-xs[i])/(ys[prev]-ys[i])
+}
+
+// filterDistance removes all peaks closer than the minimum distance to a higher peak
+func filterDistance(peaks []Peak, minDistance float64) []Peak {
+
+	order := make([]int, len(peaks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return peaks[order[i]].Height > peaks[order[j]].Height
+	})
+
+	keep := make([]bool, len(peaks))
+	for _, i := range order {
+		keep[i] = true
+		for j := range peaks {
+			if j != i && keep[j] && math.Abs(peaks[j].X-peaks[i].X) < minDistance {
+				keep[i] = false
+				break
+			}
+		}
+	}
+
+	res := peaks[:0]
+	for i := range peaks {
+		if keep[i] {
+			res = append(res, peaks[i])
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,167 @@
+package dsp
+
+import (
+	"math"
+
+	"github.com/fako1024/numerics/linalg"
+)
+
+// SavitzkyGolay denotes a Savitzky-Golay filter, smoothing (or differentiating) a
+// signal by fitting a polynomial of a given order to a moving window via least squares.
+// In contrast to a moving average, this preserves the height and width of peaks
+type SavitzkyGolay struct {
+	window, order, derivative int
+	delta                     float64
+
+	interpolateEdges bool
+	boundary         Boundary
+
+	// Least squares projection onto polynomial coefficients (order+1 rows, window columns)
+	projection [][]float64
+	kernel     []float64
+}
+
+// NewSavitzkyGolay instantiates a new Savitzky-Golay filter for an (odd) window length
+// and a polynomial order lower than the window length. By default, the filter smooths
+// the signal and fits the polynomial to the first / last window to handle the edges
+func NewSavitzkyGolay(window, order int, options ...func(*SavitzkyGolay)) *SavitzkyGolay {
+
+	if window < 1 || window%2 != 1 {
+		panic("window length must be a positive odd number")
+	}
+	if order < 0 || order >= window {
+		panic("polynomial order must be non-negative and less than the window length")
+	}
+
+	obj := &SavitzkyGolay{
+		window:           window,
+		order:            order,
+		delta:            1.,
+		interpolateEdges: true,
+	}
+
+	// Execute functional options (if any), see options.go for implementation
+	for _, option := range options {
+		option(obj)
+	}
+
+	if obj.derivative < 0 || obj.derivative > order {
+		panic("derivative must be non-negative and not exceed the polynomial order")
+	}
+
+	obj.projection = leastSquaresProjection(window, order)
+
+	// The kernel is the (reversed) projection row of the requested derivative, scaled
+	// by d! / delta^d
+	obj.kernel = make([]float64, window)
+	for j := 0; j < window; j++ {
+		obj.kernel[window-1-j] = obj.derivativeAt(j, 0)
+	}
+
+	return obj
+}
+
+// Coefficients returns the filter coefficients c_j, such that the result for sample i
+// is the sum over c_j*x[i+j-window/2]
+func (s *SavitzkyGolay) Coefficients() []float64 {
+	res := make([]float64, s.window)
+	for i := range res {
+		res[i] = s.kernel[s.window-1-i]
+	}
+	return res
+}
+
+// Apply filters the signal x, returning a newly allocated slice of the same length
+func (s *SavitzkyGolay) Apply(x []float64) []float64 {
+
+	if !s.interpolateEdges || len(x) < s.window {
+		boundary := s.boundary
+		if s.interpolateEdges {
+			boundary = Nearest
+		}
+		return Convolve(x, s.kernel, WithMode(Same), WithBoundary(boundary))
+	}
+
+	res := Convolve(x, s.kernel, WithMode(Same))
+
+	// Evaluate the polynomials fitted to the first / last window at the edges
+	m, n := s.window/2, len(x)
+	for i := 0; i < m; i++ {
+		res[i] = s.evaluate(x[:s.window], i-m)
+		res[n-1-i] = s.evaluate(x[n-s.window:], m-i)
+	}
+
+	return res
+}
+
+// SavitzkyGolayFilter is a convenience function to apply a Savitzky-Golay filter to
+// the signal x in a single call
+func SavitzkyGolayFilter(x []float64, window, order int, options ...func(*SavitzkyGolay)) []float64 {
+	return NewSavitzkyGolay(window, order, options...).Apply(x)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// evaluate returns the value (or derivative) of the polynomial fitted to the window
+// at offset t (relative to the window center)
+func (s *SavitzkyGolay) evaluate(window []float64, t int) float64 {
+	res := 0.
+	for j := range window {
+		res += s.derivativeAt(j, t) * window[j]
+	}
+	return res
+}
+
+// derivativeAt returns the contribution of the window sample j to the requested
+// derivative of the fitted polynomial at offset t
+func (s *SavitzkyGolay) derivativeAt(j, t int) float64 {
+
+	res := 0.
+	for k := s.derivative; k <= s.order; k++ {
+
+		// d^d/dt^d t^k = k!/(k-d)! t^(k-d)
+		fac := 1.
+		for l := k - s.derivative + 1; l <= k; l++ {
+			fac *= float64(l)
+		}
+		res += s.projection[k][j] * fac * math.Pow(float64(t), float64(k-s.derivative))
+	}
+
+	return res / math.Pow(s.delta, float64(s.derivative))
+}
+
+// leastSquaresProjection computes (AᵀA)⁻¹Aᵀ for the Vandermonde matrix A of the
+// (centered) window positions
+func leastSquaresProjection(window, order int) [][]float64 {
+
+	m := window / 2
+	ata := linalg.NewDense(order+1, order+1, nil)
+	for k := 0; k <= order; k++ {
+		for l := 0; l <= order; l++ {
+			sum := 0.
+			for j := -m; j <= m; j++ {
+				sum += math.Pow(float64(j), float64(k+l))
+			}
+			ata.Set(k, l, sum)
+		}
+	}
+
+	// AᵀA is always regular since the window length exceeds the polynomial order
+	lu, err := linalg.NewLU(ata)
+	if err != nil {
+		panic(err)
+	}
+	inv := lu.Inverse()
+
+	res := make([][]float64, order+1)
+	for k := range res {
+		res[k] = make([]float64, window)
+		for j := -m; j <= m; j++ {
+			for l := 0; l <= order; l++ {
+				res[k][j+m] += inv.At(k, l) * math.Pow(float64(j), float64(l))
+			}
+		}
+	}
+
+	return res
+}
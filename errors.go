@@ -0,0 +1,70 @@
+package numerics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// The following errors are shared across all packages of this module, allowing callers
+// to branch on the type of failure via errors.Is() (the returned errors are typically
+// wrapped to provide additional context)
+var (
+	// ErrDomain denotes that an argument lies outside of the domain of a function
+	ErrDomain = errors.New("argument outside of domain")
+
+	// ErrNoConvergence denotes that an iterative method did not converge within the
+	// maximum number of iterations
+	ErrNoConvergence = errors.New("no convergence")
+
+	// ErrPrecisionLoss denotes that a result cannot be represented with sufficient
+	// precision (e.g. due to overflow or underflow)
+	ErrPrecisionLoss = errors.New("loss of precision")
+
+	// ErrIncompatibleBinning denotes that two histograms cannot be combined due to
+	// different binning
+	ErrIncompatibleBinning = errors.New("incompatible binning")
+)
+
+// BetaIncompleteRegularErr returns the value of the regularized incomplete beta function
+// Iₓ(a, b) or an error (wrapping ErrDomain or ErrNoConvergence) if it cannot be computed
+func BetaIncompleteRegularErr(x, a, b float64) (float64, error) {
+	return DefaultConfig.withErrors().BetaIncompleteRegular(x, a, b)
+}
+
+// BetaIncompleteErr returns the value of the (non-regularized) incomplete beta function or
+// an error (wrapping ErrDomain, ErrNoConvergence or ErrPrecisionLoss) if it cannot be computed
+func BetaIncompleteErr(x, a, b float64) (float64, error) {
+	return DefaultConfig.withErrors().BetaIncomplete(x, a, b)
+}
+
+// GammaIncompleteRegularErr returns the value of the regularized incomplete gamma function
+// P(a, x) or an error (wrapping ErrDomain or ErrNoConvergence) if it cannot be computed
+func GammaIncompleteRegularErr(a, x float64) (float64, error) {
+	return DefaultConfig.withErrors().GammaIncompleteRegular(a, x)
+}
+
+// GammaIncompleteRegularCompErr returns the value of the complementary regularized
+// incomplete gamma function Q(a, x) or an error (wrapping ErrDomain or ErrNoConvergence)
+// if it cannot be computed
+func GammaIncompleteRegularCompErr(a, x float64) (float64, error) {
+	return DefaultConfig.withErrors().GammaIncompleteRegularComp(a, x)
+}
+
+// BinomialErr returns the value of the probability distribution for a Bernoulli experiment
+// or an error (wrapping ErrDomain or ErrPrecisionLoss) if it cannot be computed
+func BinomialErr(x, k, n float64) (float64, error) {
+	if x < 0 || x > 1 {
+		return DefaultConfig.withErrors().fail(fmt.Errorf("%w: x=%v not in [0, 1]", ErrDomain, x))
+	}
+
+	res := Binomial(x, k, n)
+	if math.IsNaN(res) {
+		return DefaultConfig.withErrors().fail(fmt.Errorf("%w: k=%v, n=%v", ErrDomain, k, n))
+	}
+	if math.IsInf(res, 0) {
+		return res, fmt.Errorf("%w: result overflows (x=%v, k=%v, n=%v)", ErrPrecisionLoss, x, k, n)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,122 @@
+package fft
+
+import (
+	"math"
+	"math/bits"
+	"math/cmplx"
+)
+
+// Plan denotes a precomputed radix-2 transform of a fixed length, allowing for
+// repeated transforms without per-call allocation
+type Plan struct {
+	n int
+
+	twiddles []complex128
+	reversed []int
+}
+
+// NewPlan instantiates a new transform plan for complex input of length n (which
+// must be a power of two)
+func NewPlan(n int) *Plan {
+
+	if n < 1 || n&(n-1) != 0 {
+		panic("transform length must be a power of two")
+	}
+
+	obj := Plan{
+		n:        n,
+		twiddles: make([]complex128, n/2),
+		reversed: make([]int, n),
+	}
+
+	// Precompute twiddle factors exp(-2πik/n)
+	for k := 0; k < n/2; k++ {
+		obj.twiddles[k] = cmplx.Rect(1., -2.*math.Pi*float64(k)/float64(n))
+	}
+
+	// Precompute bit reversal permutation
+	shift := bits.UintSize - bits.Len(uint(n)) + 1
+	for i := 0; i < n; i++ {
+		obj.reversed[i] = int(bits.Reverse(uint(i)) >> shift)
+	}
+
+	return &obj
+}
+
+// Len returns the transform length of the plan
+func (p *Plan) Len() int {
+	return p.n
+}
+
+// Forward performs the forward transform of src and stores the result in dst (which
+// may be identical to src for an in-place transform)
+func (p *Plan) Forward(dst, src []complex128) {
+	p.transform(dst, src, false)
+}
+
+// Inverse performs the inverse transform of src and stores the result in dst (which
+// may be identical to src for an in-place transform). The result is normalized by
+// 1/n, i.e. Inverse(Forward(x)) == x
+func (p *Plan) Inverse(dst, src []complex128) {
+	p.transform(dst, src, true)
+
+	scale := complex(1./float64(p.n), 0)
+	for i := range dst {
+		dst[i] *= scale
+	}
+}
+
+// FFT performs a forward transform of x (whose length must be a power of two),
+// returning a newly allocated slice
+func FFT(x []complex128) []complex128 {
+	res := make([]complex128, len(x))
+	NewPlan(len(x)).Forward(res, x)
+	return res
+}
+
+// IFFT performs an inverse transform of x (whose length must be a power of two),
+// returning a newly allocated slice
+func IFFT(x []complex128) []complex128 {
+	res := make([]complex128, len(x))
+	NewPlan(len(x)).Inverse(res, x)
+	return res
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// transform performs the actual iterative Cooley-Tukey butterfly computation
+func (p *Plan) transform(dst, src []complex128, inverse bool) {
+
+	if len(dst) != p.n || len(src) != p.n {
+		panic("input / output length does not match transform length")
+	}
+
+	// Perform bit reversal permutation (swapping in place if dst and src coincide)
+	if &dst[0] == &src[0] {
+		for i, j := range p.reversed {
+			if i < j {
+				dst[i], dst[j] = dst[j], dst[i]
+			}
+		}
+	} else {
+		for i, j := range p.reversed {
+			dst[j] = src[i]
+		}
+	}
+
+	// Butterfly stages, using the conjugate twiddle factors for the inverse transform
+	for size := 2; size <= p.n; size <<= 1 {
+		half, stride := size/2, p.n/size
+		for start := 0; start < p.n; start += size {
+			for k := 0; k < half; k++ {
+				w := p.twiddles[k*stride]
+				if inverse {
+					w = cmplx.Conj(w)
+				}
+
+				a, b := dst[start+k], w*dst[start+k+half]
+				dst[start+k], dst[start+k+half] = a+b, a-b
+			}
+		}
+	}
+}
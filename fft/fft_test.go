@@ -0,0 +1,112 @@
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+const testEpsilon = 1e-9
+
+func TestForwardInverse(t *testing.T) {
+
+	for _, n := range []int{1, 2, 4, 8, 64, 1024} {
+		x := randomComplex(n)
+
+		spectrum := FFT(x)
+		expected := dft(x)
+		for i := range spectrum {
+			if cmplx.Abs(spectrum[i]-expected[i]) > testEpsilon*float64(n) {
+				t.Fatalf("Unexpected spectrum for n=%d at index %d: want %v, have %v", n, i, expected[i], spectrum[i])
+			}
+		}
+
+		restored := IFFT(spectrum)
+		for i := range restored {
+			if cmplx.Abs(restored[i]-x[i]) > testEpsilon {
+				t.Fatalf("Unexpected inverse for n=%d at index %d: want %v, have %v", n, i, x[i], restored[i])
+			}
+		}
+	}
+}
+
+func TestInPlace(t *testing.T) {
+	x := randomComplex(16)
+	expected := FFT(x)
+
+	plan := NewPlan(16)
+	plan.Forward(x, x)
+	for i := range x {
+		if cmplx.Abs(x[i]-expected[i]) > testEpsilon {
+			t.Fatalf("Unexpected in-place spectrum at index %d: want %v, have %v", i, expected[i], x[i])
+		}
+	}
+}
+
+func TestReal(t *testing.T) {
+
+	for _, n := range []int{2, 4, 16, 256} {
+		x := make([]float64, n)
+		xc := make([]complex128, n)
+		for i := range x {
+			x[i] = rand.Float64() - 0.5
+			xc[i] = complex(x[i], 0)
+		}
+
+		spectrum := RFFT(x)
+		expected := dft(xc)
+		if len(spectrum) != n/2+1 {
+			t.Fatalf("Unexpected packed spectrum length for n=%d: want %d, have %d", n, n/2+1, len(spectrum))
+		}
+		for i := range spectrum {
+			if cmplx.Abs(spectrum[i]-expected[i]) > testEpsilon*float64(n) {
+				t.Fatalf("Unexpected real spectrum for n=%d at index %d: want %v, have %v", n, i, expected[i], spectrum[i])
+			}
+		}
+
+		restored := IRFFT(spectrum, n)
+		for i := range restored {
+			if math.Abs(restored[i]-x[i]) > testEpsilon {
+				t.Fatalf("Unexpected real inverse for n=%d at index %d: want %v, have %v", n, i, x[i], restored[i])
+			}
+		}
+	}
+}
+
+func TestInvalidLength(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("Expected panic for non power of two transform length")
+		}
+	}()
+	_ = NewPlan(12)
+}
+
+func BenchmarkPlan(b *testing.B) {
+	x := randomComplex(4096)
+	plan := NewPlan(len(x))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		plan.Forward(x, x)
+	}
+}
+
+func dft(x []complex128) []complex128 {
+	res := make([]complex128, len(x))
+	for k := range res {
+		for j := range x {
+			res[k] += x[j] * cmplx.Rect(1., -2.*math.Pi*float64(j*k)/float64(len(x)))
+		}
+	}
+	return res
+}
+
+func randomComplex(n int) []complex128 {
+	res := make([]complex128, n)
+	for i := range res {
+		res[i] = complex(rand.Float64()-0.5, rand.Float64()-0.5)
+	}
+	return res
+}
@@ -0,0 +1,110 @@
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// RealPlan denotes a precomputed transform of real input of a fixed length, computed
+// via a complex transform of half the length. The spectrum is stored in packed form,
+// i.e. only the n/2+1 non-redundant coefficients are kept. Since it holds an internal
+// work buffer, a RealPlan must not be used concurrently
+type RealPlan struct {
+	n int
+
+	plan     *Plan
+	twiddles []complex128
+	buf      []complex128
+}
+
+// NewRealPlan instantiates a new transform plan for real input of length n (which
+// must be a power of two and at least 2)
+func NewRealPlan(n int) *RealPlan {
+
+	if n < 2 || n&(n-1) != 0 {
+		panic("transform length must be a power of two and at least 2")
+	}
+
+	obj := RealPlan{
+		n:        n,
+		plan:     NewPlan(n / 2),
+		twiddles: make([]complex128, n/2+1),
+		buf:      make([]complex128, n/2),
+	}
+
+	for k := 0; k <= n/2; k++ {
+		obj.twiddles[k] = cmplx.Rect(1., -2.*math.Pi*float64(k)/float64(n))
+	}
+
+	return &obj
+}
+
+// Len returns the (real) transform length of the plan
+func (p *RealPlan) Len() int {
+	return p.n
+}
+
+// Forward performs the forward transform of the real input src (of length n) and
+// stores the packed spectrum in dst (of length n/2+1)
+func (p *RealPlan) Forward(dst []complex128, src []float64) {
+
+	m := p.n / 2
+	if len(src) != p.n || len(dst) != m+1 {
+		panic("input / output length does not match transform length")
+	}
+
+	// Pack even / odd samples into real / imaginary parts and transform
+	for k := 0; k < m; k++ {
+		p.buf[k] = complex(src[2*k], src[2*k+1])
+	}
+	p.plan.Forward(p.buf, p.buf)
+
+	// Separate the spectra of the even / odd samples and combine them
+	for k := 0; k <= m; k++ {
+		zk, zmk := p.buf[k%m], cmplx.Conj(p.buf[(m-k)%m])
+		even := (zk + zmk) / 2
+		odd := complex(0, -0.5) * (zk - zmk)
+		dst[k] = even + p.twiddles[k]*odd
+	}
+}
+
+// Inverse performs the inverse transform of the packed spectrum src (of length n/2+1)
+// and stores the real result in dst (of length n). The result is normalized by 1/n,
+// i.e. Inverse(Forward(x)) == x
+func (p *RealPlan) Inverse(dst []float64, src []complex128) {
+
+	m := p.n / 2
+	if len(dst) != p.n || len(src) != m+1 {
+		panic("input / output length does not match transform length")
+	}
+
+	// Reconstruct the spectra of the even / odd samples and recombine them
+	for k := 0; k < m; k++ {
+		xk, xmk := src[k], cmplx.Conj(src[m-k])
+		even := (xk + xmk) / 2
+		odd := (xk - xmk) / 2 * cmplx.Conj(p.twiddles[k])
+		p.buf[k] = even + complex(0, 1)*odd
+	}
+	p.plan.Inverse(p.buf, p.buf)
+
+	// Unpack real / imaginary parts into even / odd samples
+	for k := 0; k < m; k++ {
+		dst[2*k], dst[2*k+1] = real(p.buf[k]), imag(p.buf[k])
+	}
+}
+
+// RFFT performs a forward transform of the real input x (whose length must be a
+// power of two), returning the newly allocated packed spectrum of length n/2+1
+func RFFT(x []float64) []complex128 {
+	res := make([]complex128, len(x)/2+1)
+	NewRealPlan(len(x)).Forward(res, x)
+	return res
+}
+
+// IRFFT performs an inverse transform of the packed spectrum x to real output of
+// length n (which must be a power of two), returning a newly allocated slice
+func IRFFT(x []complex128, n int) []float64 {
+	res := make([]float64, n)
+	NewRealPlan(n).Inverse(res, x)
+	return res
+}
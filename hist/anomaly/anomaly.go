@@ -0,0 +1,222 @@
+// Package anomaly provides histogram-based anomaly / outlier detection, scoring incoming
+// values or windows of values against an (optionally decaying) baseline histogram
+package anomaly
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fako1024/numerics"
+	"github.com/fako1024/numerics/hist"
+)
+
+// Method denotes a scoring method
+type Method int
+
+const (
+
+	// TailProbability scores a value by the two-sided tail probability of its bin within
+	// the baseline (a window by the asymptotic Kolmogorov-Smirnov p-value), small scores
+	// denoting anomalies
+	TailProbability Method = iota
+
+	// ZScore scores a value by its distance from the baseline mean in units of the
+	// baseline standard deviation (a window by the distance of its mean in units of the
+	// standard error), large scores denoting anomalies
+	ZScore
+
+	// Distance scores a window by the Kolmogorov-Smirnov distance between its distribution
+	// and the baseline (a single value by the distance of its degenerate distribution),
+	// large scores denoting anomalies
+	Distance
+)
+
+// String returns a human-readable representation of the method
+func (m Method) String() string {
+	switch m {
+	case TailProbability:
+		return "tail probability"
+	case ZScore:
+		return "z-score"
+	case Distance:
+		return "distance"
+	}
+	return fmt.Sprintf("Method(%d)", int(m))
+}
+
+// defaultThreshold returns the default threshold of the method
+func (m Method) defaultThreshold() float64 {
+	switch m {
+	case ZScore:
+		return 3.
+	case Distance:
+		return 0.2
+	}
+	return 0.01
+}
+
+// Result denotes the outcome of scoring a value or window against the baseline
+type Result struct {
+	Method    Method
+	N         int
+	Mean      float64
+	Score     float64
+	Threshold float64
+	Anomalous bool
+}
+
+// Detector denotes a histogram-based anomaly detector (not safe for concurrent use)
+type Detector[T hist.Number] struct {
+	baseline *hist.H1[T]
+
+	settings
+}
+
+// NewDetector instantiates a new anomaly detector based on an (initially empty) baseline
+// histogram with the provided binning
+func NewDetector[T hist.Number](n int, xMin, xMax T, options ...Option) *Detector[T] {
+	d := &Detector[T]{
+		baseline: hist.NewH1(n, xMin, xMax),
+		settings: settings{
+			method:    TailProbability,
+			decay:     1.,
+			minWeight: 100.,
+		},
+	}
+
+	// Execute functional options (if any), see options.go for implementation
+	for _, option := range options {
+		option(&d.settings)
+	}
+	if d.threshold == 0 {
+		d.threshold = d.method.defaultThreshold()
+	}
+
+	return d
+}
+
+// Baseline returns the baseline histogram
+func (d *Detector[T]) Baseline() *hist.H1[T] {
+	return d.baseline
+}
+
+// Update adds values to the baseline (applying the decay, if any)
+func (d *Detector[T]) Update(vals ...T) {
+	for _, val := range vals {
+		if d.decay != 1. {
+			d.baseline.Scale(d.decay)
+		}
+		d.baseline.Fill(val)
+	}
+}
+
+// Score scores a single value against the baseline
+func (d *Detector[T]) Score(val T) Result {
+	return d.ScoreWindow([]T{val})
+}
+
+// ScoreWindow scores a window of values against the baseline
+func (d *Detector[T]) ScoreWindow(vals []T) Result {
+
+	res := Result{
+		Method:    d.method,
+		N:         len(vals),
+		Threshold: d.threshold,
+	}
+	if len(vals) == 0 {
+		res.Score = math.NaN()
+		return res
+	}
+	for _, val := range vals {
+		res.Mean += float64(val)
+	}
+	res.Mean /= float64(len(vals))
+
+	switch d.method {
+	case ZScore:
+		mean, std := d.moments()
+		res.Score = math.Abs(res.Mean-mean) / (std / math.Sqrt(float64(len(vals))))
+		res.Anomalous = res.Score > d.threshold
+	case Distance:
+		res.Score = d.distance(vals)
+		res.Anomalous = res.Score > d.threshold
+	default:
+		if len(vals) == 1 {
+			res.Score = d.tailProbability(vals[0])
+		} else {
+			dist := d.distance(vals)
+			nEff := float64(len(vals)) * d.baseline.Sum() / (float64(len(vals)) + d.baseline.Sum())
+			res.Score = numerics.KolmogorovQ(math.Sqrt(nEff) * dist)
+		}
+		res.Anomalous = res.Score < d.threshold
+	}
+
+	// Do not classify anything as anomalous as long as the baseline is insufficient
+	if d.baseline.Sum() < d.minWeight {
+		res.Anomalous = false
+	}
+
+	return res
+}
+
+// Observe scores a single value against the baseline and subsequently adds it to the
+// baseline (unless it is anomalous and anomalies are excluded)
+func (d *Detector[T]) Observe(val T) Result {
+	res := d.Score(val)
+	if !res.Anomalous || !d.excludeAnomalies {
+		d.Update(val)
+	}
+
+	return res
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// tailProbability determines the two-sided tail probability of the bin of a value
+func (d *Detector[T]) tailProbability(val T) float64 {
+	bin := d.baseline.FindBin(val)
+
+	var lower, upper float64
+	for i := 0; i <= d.baseline.NBins()+1; i++ {
+		if i <= bin {
+			lower += d.baseline.BinContent(i)
+		}
+		if i >= bin {
+			upper += d.baseline.BinContent(i)
+		}
+	}
+
+	return math.Min(1., 2.*math.Min(lower, upper)/d.baseline.Sum())
+}
+
+// moments determines the mean and standard deviation of the regular bins of the baseline
+func (d *Detector[T]) moments() (float64, float64) {
+	var sumW, sumX, sumX2 float64
+	for i := 1; i <= d.baseline.NBins(); i++ {
+		w, x := d.baseline.BinContent(i), d.baseline.BinCenter(i)
+		sumW += w
+		sumX += w * x
+		sumX2 += w * x * x
+	}
+
+	mean := sumX / sumW
+	return mean, math.Sqrt(math.Max(0., sumX2/sumW-mean*mean))
+}
+
+// distance determines the Kolmogorov-Smirnov distance between the binned distribution of
+// the values and the baseline
+func (d *Detector[T]) distance(vals []T) float64 {
+	window := hist.NewH1(d.baseline.NBins(), d.baseline.XMin(), d.baseline.XMax())
+	for _, val := range vals {
+		window.Fill(val)
+	}
+
+	var cdfBaseline, cdfWindow, dist float64
+	for i := 0; i <= d.baseline.NBins()+1; i++ {
+		cdfBaseline += d.baseline.BinContent(i) / d.baseline.Sum()
+		cdfWindow += window.BinContent(i) / window.Sum()
+		dist = math.Max(dist, math.Abs(cdfBaseline-cdfWindow))
+	}
+
+	return dist
+}
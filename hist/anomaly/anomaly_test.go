@@ -0,0 +1,68 @@
+package anomaly
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestDetector(t *testing.T) {
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+	baseline := make([]float64, 10000)
+	for i := range baseline {
+		baseline[i] = 10. + rnd.NormFloat64()
+	}
+
+	for _, method := range []Method{TailProbability, ZScore, Distance} {
+		d := NewDetector(100, 0., 20., WithMethod(method))
+		if res := d.Score(20.); res.Anomalous {
+			t.Fatalf("Unexpected anomaly for insufficient baseline (%s): %+v", method, res)
+		}
+		d.Update(baseline...)
+
+		if method != Distance {
+			if res := d.Score(10.1); res.Anomalous {
+				t.Fatalf("Unexpected anomaly (%s): %+v", method, res)
+			}
+			if res := d.Score(16.); !res.Anomalous {
+				t.Fatalf("Expected anomaly (%s): %+v", method, res)
+			}
+		}
+
+		window := make([]float64, 200)
+		for i := range window {
+			window[i] = 10. + rnd.NormFloat64()
+		}
+		if res := d.ScoreWindow(window); res.Anomalous {
+			t.Fatalf("Unexpected anomaly for window (%s): %+v", method, res)
+		}
+		for i := range window {
+			window[i] += 1.
+		}
+		if res := d.ScoreWindow(window); !res.Anomalous {
+			t.Fatalf("Expected anomaly for shifted window (%s): %+v", method, res)
+		}
+	}
+}
+
+func TestDetectorObserve(t *testing.T) {
+
+	rnd := rand.New(rand.NewPCG(3, 4))
+	d := NewDetector(50, 0, 100, WithMethod(ZScore), WithThreshold(4.), WithDecay(0.999), WithMinWeight(50.), WithExcludeAnomalies())
+	for i := 0; i < 2000; i++ {
+		if res := d.Observe(int(50. + 5.*rnd.NormFloat64())); res.Anomalous {
+			t.Fatalf("Unexpected anomaly: %+v", res)
+		}
+	}
+
+	sum := d.Baseline().Sum()
+	if sum > 1000. {
+		t.Fatalf("Unexpected sum of weights for decaying baseline: %v", sum)
+	}
+	if res := d.Observe(95); !res.Anomalous || res.Threshold != 4. {
+		t.Fatalf("Expected anomaly: %+v", res)
+	}
+	if d.Baseline().Sum() != sum {
+		t.Fatalf("Anomaly was unexpectedly added to baseline")
+	}
+}
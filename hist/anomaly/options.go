@@ -0,0 +1,51 @@
+package anomaly
+
+// Option denotes a functional option for a Detector
+type Option func(*settings)
+
+type settings struct {
+	method           Method
+	threshold        float64
+	decay            float64
+	minWeight        float64
+	excludeAnomalies bool
+}
+
+// WithMethod sets the scoring method (default: TailProbability)
+func WithMethod(method Method) Option {
+	return func(s *settings) {
+		s.method = method
+	}
+}
+
+// WithThreshold sets the threshold used to classify a score as anomalous (overriding the
+// default threshold of the scoring method)
+func WithThreshold(threshold float64) Option {
+	return func(s *settings) {
+		s.threshold = threshold
+	}
+}
+
+// WithDecay sets a factor in (0, 1] by which the baseline is scaled before each update,
+// i.e. exponentially forgetting older values (default: 1, i.e. no decay)
+func WithDecay(decay float64) Option {
+	return func(s *settings) {
+		s.decay = decay
+	}
+}
+
+// WithMinWeight sets the minimum sum of weights of the baseline required before any
+// value / window is classified as anomalous (default: 100)
+func WithMinWeight(minWeight float64) Option {
+	return func(s *settings) {
+		s.minWeight = minWeight
+	}
+}
+
+// WithExcludeAnomalies prevents values classified as anomalous from being added to the
+// baseline by Observe
+func WithExcludeAnomalies() Option {
+	return func(s *settings) {
+		s.excludeAnomalies = true
+	}
+}
@@ -0,0 +1,252 @@
+package hist
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fako1024/numerics"
+)
+
+// DivideOption denotes a functional option for Divide
+type DivideOption func(*divideSettings)
+
+type divideSettings struct {
+	binomial bool
+}
+
+// WithBinomialErrors propagates the uncertainties assuming that the numerator is a subset
+// of the denominator (e.g. for efficiency / acceptance calculations)
+func WithBinomialErrors() DivideOption {
+	return func(s *divideSettings) {
+		s.binomial = true
+	}
+}
+
+// Divide sets the histogram to the bin-wise ratio of two histograms, propagating the
+// uncertainties from their bin variances (bins with zero denominator yielding zero) and
+// enabling tracking of the bin variances (see EnableSumw2). The binning of all histograms
+// must be identical
+func (h *H1[T]) Divide(num, den *H1[T], options ...DivideOption) error {
+	if err := h.checkBinning(num); err != nil {
+		return err
+	}
+	if err := h.checkBinning(den); err != nil {
+		return err
+	}
+	h.EnableSumw2()
+
+	var opts divideSettings
+
+	// Execute functional options (if any)
+	for _, option := range options {
+		option(&opts)
+	}
+
+	for i := range h.binContent {
+		a, b := num.binContent[i], den.binContent[i]
+		varA, varB := num.effectiveVariance(i), den.effectiveVariance(i)
+		if b == 0 {
+			h.binContent[i], h.binVariance[i] = 0, 0
+			continue
+		}
+
+		r := a / b
+		h.binContent[i] = r
+		if opts.binomial {
+			h.binVariance[i] = math.Abs(((1.-2.*r)*varA + r*r*varB) / (b * b))
+		} else {
+			h.binVariance[i] = (varA*b*b + varB*a*a) / (b * b * b * b)
+		}
+	}
+	h.nEntries = num.nEntries
+	h.updateSumOfWeights()
+
+	return nil
+}
+
+// Subtract subtracts the contents of another histogram (e.g. a background), adding the
+// variances of both histograms (enabling tracking of the bin variances, see EnableSumw2)
+// and the number of entries. The binning of the histograms must be identical
+func (h *H1[T]) Subtract(other *H1[T]) error {
+	if err := h.checkBinning(other); err != nil {
+		return err
+	}
+	h.EnableSumw2()
+
+	for i := range h.binContent {
+		h.binVariance[i] = h.effectiveVariance(i) + other.effectiveVariance(i)
+		h.binContent[i] -= other.binContent[i]
+	}
+	h.nEntries += other.nEntries
+	h.sumOfWeights -= other.sumOfWeights
+	h.spline.invalidate()
+
+	return nil
+}
+
+// Multiply multiplies the histogram bin-wise by another histogram (e.g. a weighting),
+// propagating the uncertainties from their bin variances (enabling tracking of the bin
+// variances, see EnableSumw2) while the number of entries remains unchanged. The binning of
+// the histograms must be identical
+func (h *H1[T]) Multiply(other *H1[T]) error {
+	if err := h.checkBinning(other); err != nil {
+		return err
+	}
+	h.EnableSumw2()
+
+	for i := range h.binContent {
+		a, b := h.binContent[i], other.binContent[i]
+		h.binVariance[i] = h.effectiveVariance(i)*b*b + other.effectiveVariance(i)*a*a
+		h.binContent[i] = a * b
+	}
+	h.updateSumOfWeights()
+
+	return nil
+}
+
+// IntegralOption denotes a functional option for Integral / Normalize
+type IntegralOption func(*integralSettings)
+
+type integralSettings struct {
+	flowBins bool
+	counts   bool
+}
+
+// WithCounts determines the integral as the plain sum of the bin contents, i.e. without
+// weighting them by their bin widths (e.g. to normalize the contents to probabilities)
+func WithCounts() IntegralOption {
+	return func(s *integralSettings) {
+		s.counts = true
+	}
+}
+
+// WithFlowBins includes the contents of the under- / overflow bins in the integral. Since
+// these bins lack a finite width, this is only supported in combination with WithCounts
+// (the integral being NaN otherwise)
+func WithFlowBins() IntegralOption {
+	return func(s *integralSettings) {
+		s.flowBins = true
+	}
+}
+
+// newIntegralSettings applies the provided options to the default settings of Integral
+func newIntegralSettings(options []IntegralOption) integralSettings {
+	var opts integralSettings
+
+	// Execute functional options (if any)
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return opts
+}
+
+// Integral returns the integral of the histogram, i.e. the sum of the bin contents weighted
+// by their respective bin widths (or, if WithCounts is used, the sum of the bin contents)
+func (h *H1[T]) Integral(options ...IntegralOption) float64 {
+
+	opts := newIntegralSettings(options)
+	if opts.flowBins && !opts.counts {
+		return math.NaN()
+	}
+
+	var res float64
+	for i := 1; i <= h.nBins; i++ {
+		if opts.counts {
+			res += h.binContent[i]
+		} else {
+			res += h.binContent[i] * h.BinWidth(i)
+		}
+	}
+	if opts.flowBins {
+		res += h.binContent[0] + h.binContent[h.nBins+1]
+	}
+
+	return res
+}
+
+// Normalize scales the histogram such that its integral (see Integral) equals the
+// provided value, returning an error wrapping numerics.ErrDomain if the histogram has a
+// vanishing integral or the flow bins are included without WithCounts
+func (h *H1[T]) Normalize(to float64, options ...IntegralOption) error {
+	if opts := newIntegralSettings(options); opts.flowBins && !opts.counts {
+		return fmt.Errorf("%w: flow bins lacking a finite width can only be included with WithCounts", numerics.ErrDomain)
+	}
+
+	integral := h.Integral(options...)
+	if integral == 0 || math.IsNaN(integral) || math.IsInf(integral, 0) {
+		return fmt.Errorf("%w: cannot normalize histogram with integral %v", numerics.ErrDomain, integral)
+	}
+
+	h.Scale(to / integral)
+
+	return nil
+}
+
+// Diff returns the increment of the histogram since a previous snapshot of it (e.g. obtained
+// via Clone), i.e. a histogram holding the per-bin differences of contents and variances as
+// well as the difference of the number of entries, allowing to ship increments instead of
+// cumulative histograms. If any bin content decreased (e.g. since the histogram was reset
+// in the meantime), a copy of the full histogram is returned instead. Returns an error
+// wrapping numerics.ErrIncompatibleBinning if the binning of the histograms differs
+func (h *H1[T]) Diff(prev *H1[T]) (*H1[T], error) {
+	if err := h.checkBinning(prev); err != nil {
+		return nil, err
+	}
+
+	res := h.Clone()
+	if h.nEntries < prev.nEntries {
+		return res, nil
+	}
+	for i := range h.binContent {
+		if h.binContent[i] < prev.binContent[i] {
+			return res, nil
+		}
+	}
+
+	for i := range res.binContent {
+		res.binContent[i] -= prev.binContent[i]
+		res.binVariance[i] = math.Max(0., res.binVariance[i]-prev.binVariance[i])
+	}
+	res.nEntries -= prev.nEntries
+	res.updateSumOfWeights()
+
+	return res, nil
+}
+
+// Density returns a copy of the histogram with the content of each regular bin divided by
+// its width (and the variances by the squared width), i.e. the appropriate representation of
+// a distribution with non-uniform bins. The under- / overflow bins are retained as is
+func (h *H1[T]) Density() *H1[T] {
+	res := h.Clone()
+	for i := 1; i <= res.nBins; i++ {
+		invWidth := 1. / res.BinWidth(i)
+		res.binContent[i] *= invWidth
+		res.binVariance[i] *= invWidth * invWidth
+	}
+	res.updateSumOfWeights()
+
+	return res
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// effectiveVariance returns the variance of a bin, assuming Poisson statistics (i.e. unit
+// weights) if no variance is recorded
+func (h *H1[T]) effectiveVariance(bin int) float64 {
+	if h.binVariance[bin] == 0 {
+		return math.Abs(h.binContent[bin])
+	}
+
+	return h.binVariance[bin]
+}
+
+// updateSumOfWeights recomputes the overall sum of weights from the bin contents (and
+// invalidates the cached spline, if any)
+func (h *H1[T]) updateSumOfWeights() {
+	h.spline.invalidate()
+	h.sumOfWeights = 0
+	for _, v := range h.binContent {
+		h.sumOfWeights += v
+	}
+}
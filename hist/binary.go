@@ -0,0 +1,250 @@
+package hist
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+)
+
+// ErrInvalidData denotes that serialized histogram data cannot be decoded
+var ErrInvalidData = errors.New("invalid serialized histogram data")
+
+// encodingVersion denotes the version of the binary serialization format
+const encodingVersion = 1
+
+// Value kinds of the binary serialization format, preventing decoding into a histogram
+// of an incompatible type
+const (
+	kindFloat byte = iota + 1
+	kindInt
+	kindUint
+)
+
+// Flags of the binary serialization format
+const (
+	flagEquidistant byte = 1 << iota
+	flagVariance
+	flagSumw2
+)
+
+// Encode writes the histogram in a compact binary format (prefixed by its length, allowing
+// to write several histograms to the same io.Writer)
+func (h *H1[T]) Encode(w io.Writer) error {
+	data, err := h.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(binary.AppendUvarint(nil, uint64(len(data)))); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+
+	return err
+}
+
+// Decode reads a histogram written by Encode, replacing the current state of the histogram
+func (h *H1[T]) Decode(r io.Reader) error {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = byteReader{r}
+	}
+
+	size, err := binary.ReadUvarint(br)
+	if err != nil {
+		if err == io.EOF {
+			return err
+		}
+		return ErrInvalidData
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, int64(size)))
+	if err != nil || uint64(len(data)) != size {
+		return ErrInvalidData
+	}
+
+	return h.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler (and thereby gob encoding). Bin edges
+// are only stored explicitly for histograms with non-equidistant bins and variances only
+// if any of them is non-zero
+func (h *H1[T]) MarshalBinary() ([]byte, error) {
+
+	var flags byte
+	if h.isEquidistant() {
+		flags |= flagEquidistant
+	}
+	if h.sumw2 {
+		flags |= flagSumw2
+	}
+	for _, v := range h.binVariance {
+		if v != 0 {
+			flags |= flagVariance
+			break
+		}
+	}
+
+	data := make([]byte, 0, 3+2*binary.MaxVarintLen64+8*(3*h.nBins+6))
+	data = append(data, encodingVersion, valueKind[T](), flags)
+	data = binary.AppendUvarint(data, uint64(h.nBins))
+	data = binary.AppendUvarint(data, uint64(h.nEntries))
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(h.sumOfWeights))
+
+	if flags&flagEquidistant != 0 {
+		data = appendValue(data, h.XMin())
+		data = appendValue(data, h.XMax())
+	} else {
+		for _, edge := range h.bins {
+			data = appendValue(data, edge)
+		}
+	}
+
+	for _, v := range h.binContent {
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(v))
+	}
+	if flags&flagVariance != 0 {
+		for _, v := range h.binVariance {
+			data = binary.LittleEndian.AppendUint64(data, math.Float64bits(v))
+		}
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler (and thereby gob decoding)
+func (h *H1[T]) UnmarshalBinary(data []byte) error {
+
+	if len(data) < 3 || data[0] != encodingVersion || data[1] != valueKind[T]() {
+		return ErrInvalidData
+	}
+	flags, data := data[2], data[3:]
+
+	var header [2]uint64
+	for i := range header {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return ErrInvalidData
+		}
+		header[i], data = v, data[n:]
+	}
+	if header[0] < 1 || header[0] > uint64(len(data)) || len(data) < 8 {
+		return ErrInvalidData
+	}
+	nBins := int(header[0])
+	sumOfWeights := math.Float64frombits(binary.LittleEndian.Uint64(data))
+	data = data[8:]
+
+	nEdges := nBins + 1
+	if flags&flagEquidistant != 0 {
+		nEdges = 2
+	}
+	edges := make([]T, nEdges)
+	for i := range edges {
+		v, n := readValue[T](data)
+		if n <= 0 {
+			return ErrInvalidData
+		}
+		edges[i], data = v, data[n:]
+		if i > 0 && !(edges[i] > edges[i-1]) {
+			return ErrInvalidData
+		}
+	}
+
+	nValues := nBins + 2
+	if flags&flagVariance != 0 {
+		nValues *= 2
+	}
+	if len(data) != 8*nValues {
+		return ErrInvalidData
+	}
+
+	var res *H1[T]
+	if flags&flagEquidistant != 0 {
+		res = NewH1(nBins, edges[0], edges[1])
+	} else {
+		res = NewH1Edges(edges)
+	}
+	res.nEntries = int(header[1])
+	res.sumOfWeights = sumOfWeights
+	res.sumw2 = flags&flagSumw2 != 0
+	for i := range res.binContent {
+		res.binContent[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+	}
+	if flags&flagVariance != 0 {
+		data = data[8*len(res.binContent):]
+		for i := range res.binVariance {
+			res.binVariance[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+		}
+	}
+	*h = *res
+
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// isEquidistant determines if the bin edges can be reconstructed by NewH1 from the
+// boundaries of the x axis
+func (h *H1[T]) isEquidistant() bool {
+	xMin, xMax := h.XMin(), h.XMax()
+	step := (xMax - xMin) / T(h.nBins)
+	for i, edge := range h.bins {
+		if edge != xMin+T(i)*step {
+			return false
+		}
+	}
+
+	return true
+}
+
+// valueKind classifies T by its underlying kind (such that named types are encoded like
+// the respective predeclared type)
+func valueKind[T Number]() byte {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Float32, reflect.Float64:
+		return kindFloat
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return kindInt
+	}
+	return kindUint
+}
+
+func appendValue[T Number](data []byte, v T) []byte {
+	switch valueKind[T]() {
+	case kindFloat:
+		return binary.LittleEndian.AppendUint64(data, math.Float64bits(float64(v)))
+	case kindInt:
+		return binary.AppendVarint(data, int64(v))
+	}
+	return binary.AppendUvarint(data, uint64(v))
+}
+
+func readValue[T Number](data []byte) (T, int) {
+	switch valueKind[T]() {
+	case kindFloat:
+		if len(data) < 8 {
+			return 0, 0
+		}
+		return T(math.Float64frombits(binary.LittleEndian.Uint64(data))), 8
+	case kindInt:
+		v, n := binary.Varint(data)
+		return T(v), n
+	}
+	v, n := binary.Uvarint(data)
+	return T(v), n
+}
+
+// byteReader provides an io.ByteReader for an arbitrary io.Reader (without read-ahead,
+// allowing to decode subsequent histograms from the same io.Reader)
+type byteReader struct {
+	io.Reader
+}
+
+func (b byteReader) ReadByte() (byte, error) {
+	var buf [1]byte
+	_, err := io.ReadFull(b.Reader, buf[:])
+	return buf[0], err
+}
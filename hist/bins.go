@@ -0,0 +1,31 @@
+//go:build go1.23
+
+package hist
+
+import "iter"
+
+// Bin denotes a single regular bin of a histogram, as provided by Bins()
+type Bin[T Number] struct {
+	Index             int
+	LowEdge, HighEdge T
+	Content, Variance float64
+}
+
+// Bins returns an iterator over all regular bins of the histogram in ascending order, e.g.
+//
+//	for bin := range h.Bins() {
+//		fmt.Println(bin.LowEdge, bin.HighEdge, bin.Content)
+//	}
+func (h *H1[T]) Bins() iter.Seq[Bin[T]] {
+	return func(yield func(Bin[T]) bool) {
+		h.Visit(func(bin int, lowEdge, highEdge T, content, variance float64) bool {
+			return yield(Bin[T]{
+				Index:    bin,
+				LowEdge:  lowEdge,
+				HighEdge: highEdge,
+				Content:  content,
+				Variance: variance,
+			})
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package hist
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fako1024/numerics"
+)
+
+// ChiSquareTest performs a χ² homogeneity test comparing the distributions represented by
+// two histograms with identical binning, returning χ², the number of degrees of freedom and
+// the p-value. Only the regular bins are considered and bins that are empty in both
+// histograms do not contribute (reducing the number of degrees of freedom). If either
+// histogram is weighted (i.e. records bin variances differing from the contents), the
+// test for weighted histograms by N. Gagunashvili (Comput. Phys. Commun. 181 (2010)
+// 2039-2046, arXiv:physics/0605123) is applied
+func ChiSquareTest(h1, h2 Hist1D) (chi2 float64, ndf int, pValue float64, err error) {
+
+	if err := compatibleBinning(h1, h2); err != nil {
+		return math.NaN(), 0, math.NaN(), err
+	}
+
+	var sum1, sum2 float64
+	for i := 1; i <= h1.NBins(); i++ {
+		sum1 += h1.BinContent(i)
+		sum2 += h2.BinContent(i)
+	}
+	if sum1 <= 0 || sum2 <= 0 {
+		return math.NaN(), 0, math.NaN(), fmt.Errorf("%w: histogram without (positive) contents", numerics.ErrDomain)
+	}
+
+	weighted := isWeighted(h1) || isWeighted(h2)
+	for i := 1; i <= h1.NBins(); i++ {
+		y1, y2 := h1.BinContent(i), h2.BinContent(i)
+
+		// Unweighted: Σ (N₂n₁ᵢ - N₁n₂ᵢ)² / (N₁N₂(n₁ᵢ + n₂ᵢ))
+		// Weighted:   Σ (W₂w₁ᵢ - W₁w₂ᵢ)² / (W₂²s₁ᵢ² + W₁²s₂ᵢ²)
+		var denom float64
+		if weighted {
+			denom = sum2*sum2*binVariance(h1, i) + sum1*sum1*binVariance(h2, i)
+		} else {
+			denom = sum1 * sum2 * (y1 + y2)
+		}
+		if denom == 0 {
+			continue
+		}
+
+		diff := sum2*y1 - sum1*y2
+		chi2 += diff * diff / denom
+		ndf++
+	}
+
+	// One degree of freedom is lost due to the (relative) normalization
+	ndf--
+	if ndf < 1 {
+		return math.NaN(), 0, math.NaN(), fmt.Errorf("%w: insufficient number of non-empty bins", numerics.ErrDomain)
+	}
+
+	return chi2, ndf, chiSquareProb(chi2, ndf), nil
+}
+
+// ChiSquareTestFunc performs a χ² goodness-of-fit test of a histogram against a function
+// providing the expected bin content at the center of each bin, returning χ², the number of
+// degrees of freedom (reduced by the number of parameters estimated from the data) and the
+// p-value. Only the regular bins are considered. For unweighted histograms, the expected
+// contents define the bin variances (Pearson's χ², properly accounting for empty bins),
+// otherwise the recorded bin variances are used (skipping bins without variance)
+func ChiSquareTestFunc(h Hist1D, expected func(x float64) float64, nParams int) (chi2 float64, ndf int, pValue float64, err error) {
+
+	weighted := isWeighted(h)
+	for i := 1; i <= h.NBins(); i++ {
+		y, yExp := h.BinContent(i), expected(h.BinCenter(i))
+
+		variance := yExp
+		if weighted {
+			variance = binVariance(h, i)
+		}
+		if variance <= 0 {
+			if !weighted && y != 0 {
+				return math.NaN(), 0, math.NaN(), fmt.Errorf("%w: non-positive expectation %v for non-empty bin %d", numerics.ErrDomain, yExp, i)
+			}
+			continue
+		}
+
+		chi2 += (y - yExp) * (y - yExp) / variance
+		ndf++
+	}
+
+	ndf -= nParams
+	if ndf < 1 {
+		return math.NaN(), 0, math.NaN(), fmt.Errorf("%w: insufficient number of bins for %d parameters", numerics.ErrDomain, nParams)
+	}
+
+	return chi2, ndf, chiSquareProb(chi2, ndf), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// chiSquareProb returns the p-value of the χ² distribution, i.e. the probability of
+// observing a value of at least chi2 for ndf degrees of freedom
+func chiSquareProb(chi2 float64, ndf int) float64 {
+	return numerics.GammaIncompleteRegularComp(float64(ndf)/2., chi2/2.)
+}
+
+// isWeighted determines if any bin of a histogram records a variance differing from its
+// content (i.e. if it was filled with non-unit weights)
+func isWeighted(h Hist1D) bool {
+	for i := 0; i <= h.NBins()+1; i++ {
+		if v := h.BinVariance(i); v != 0 && v != h.BinContent(i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// binVariance returns the variance of a bin, assuming Poisson statistics (i.e. unit
+// weights) if no variance is recorded
+func binVariance(h Hist1D, bin int) float64 {
+	if v := h.BinVariance(bin); v != 0 {
+		return v
+	}
+
+	return math.Abs(h.BinContent(bin))
+}
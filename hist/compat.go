@@ -4,14 +4,30 @@ package hist
 type H1D = H1[float64]
 
 // NewH1D instantiates a new one-dimensional histogram based on float64 values
-func NewH1D(n int, xMin, xMax float64) *H1D {
-	return NewH1(n, xMin, xMax)
+func NewH1D(n int, xMin, xMax float64, options ...H1Option) *H1D {
+	return NewH1(n, xMin, xMax, options...)
 }
 
 // H1I denotes a one-dimensional histogram based on integer values
 type H1I = H1[int]
 
 // NewH1I instantiates a new one-dimensional histogram based on integer values
-func NewH1I(n int, xMin, xMax int) *H1I {
-	return NewH1(n, xMin, xMax)
+func NewH1I(n int, xMin, xMax int, options ...H1Option) *H1I {
+	return NewH1(n, xMin, xMax, options...)
+}
+
+// H2D denotes a two-dimensional histogram based on float64 values
+type H2D = H2[float64]
+
+// NewH2D instantiates a new two-dimensional histogram based on float64 values
+func NewH2D(nx int, xMin, xMax float64, ny int, yMin, yMax float64) *H2D {
+	return NewH2(nx, xMin, xMax, ny, yMin, yMax)
+}
+
+// H2I denotes a two-dimensional histogram based on integer values
+type H2I = H2[int]
+
+// NewH2I instantiates a new two-dimensional histogram based on integer values
+func NewH2I(nx int, xMin, xMax int, ny int, yMin, yMax int) *H2I {
+	return NewH2(nx, xMin, xMax, ny, yMin, yMax)
 }
@@ -0,0 +1,152 @@
+package hist
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var csvHeader = []string{"binLow", "binHigh", "content", "variance"}
+
+// ToCSV writes the histogram as CSV to any io.Writer, with one row per bin (including the
+// under- / overflow bins, denoted by infinite outer edges) preceded by comment lines
+// containing the metadata (number of entries, sum of weights, sumw2 tracking)
+func (h *H1[T]) ToCSV(w io.Writer) error {
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# entries: %d\n# sum: %s\n# sumw2: %t\n", h.nEntries, fmtData(h.sumOfWeights), h.sumw2)
+
+	cw := csv.NewWriter(bw)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for i := 0; i < h.nBins+2; i++ {
+		low, high := "-Inf", "+Inf"
+		if i > 0 {
+			low = fmtCSVValue(h.bins[i-1])
+		}
+		if i <= h.nBins {
+			high = fmtCSVValue(h.bins[i])
+		}
+		if err := cw.Write([]string{low, high, fmtData(h.binContent[i]), fmtData(h.binVariance[i])}); err != nil {
+			return err
+		}
+	}
+	if cw.Flush(); cw.Error() != nil {
+		return cw.Error()
+	}
+
+	return bw.Flush()
+}
+
+// FromCSV reads a histogram written by ToCSV, replacing the current state of the histogram
+func (h *H1[T]) FromCSV(r io.Reader) error {
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	// Parse the metadata from the leading comment lines
+	var (
+		nEntries     int
+		sumOfWeights float64
+		sumw2        bool
+		metadata     = make(map[string]string)
+		body         = string(data)
+	)
+	for strings.HasPrefix(body, "#") {
+		line, rest, _ := strings.Cut(body, "\n")
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "#"), ":")
+		if !ok {
+			return fmt.Errorf("%w: invalid metadata line %q", ErrInvalidData, line)
+		}
+		metadata[strings.TrimSpace(key)] = strings.TrimSpace(strings.TrimSuffix(value, "\r"))
+		body = rest
+	}
+	if nEntries, err = strconv.Atoi(metadata["entries"]); err != nil {
+		return fmt.Errorf("%w: invalid number of entries: %w", ErrInvalidData, err)
+	}
+	if sumOfWeights, err = strconv.ParseFloat(metadata["sum"], 64); err != nil {
+		return fmt.Errorf("%w: invalid sum of weights: %w", ErrInvalidData, err)
+	}
+	if sumw2, err = strconv.ParseBool(metadata["sumw2"]); err != nil {
+		return fmt.Errorf("%w: invalid sumw2 flag: %w", ErrInvalidData, err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidData, err)
+	}
+	if len(records) < 4 || strings.Join(records[0], ",") != strings.Join(csvHeader, ",") {
+		return fmt.Errorf("%w: missing header or bins", ErrInvalidData)
+	}
+	records = records[1:]
+
+	// Reconstruct the bin edges from the regular bins (and the contents / variances from all bins)
+	edges := make([]T, 0, len(records)-1)
+	contents := make([]float64, len(records))
+	variances := make([]float64, len(records))
+	for i, record := range records {
+		if i > 0 {
+			edge, err := parseCSVValue[T](record[0])
+			if err != nil {
+				return fmt.Errorf("%w: invalid bin edge in row %d: %w", ErrInvalidData, i+1, err)
+			}
+			if len(edges) > 0 && !(edge > edges[len(edges)-1]) {
+				return fmt.Errorf("%w: bin edges not ascending in row %d", ErrInvalidData, i+1)
+			}
+			edges = append(edges, edge)
+		}
+		if contents[i], err = strconv.ParseFloat(record[2], 64); err != nil {
+			return fmt.Errorf("%w: invalid bin content in row %d: %w", ErrInvalidData, i+1, err)
+		}
+		if variances[i], err = strconv.ParseFloat(record[3], 64); err != nil {
+			return fmt.Errorf("%w: invalid bin variance in row %d: %w", ErrInvalidData, i+1, err)
+		}
+	}
+
+	res := NewH1Edges(edges)
+	res.nEntries = nEntries
+	res.sumOfWeights = sumOfWeights
+	res.sumw2 = sumw2
+	res.binContent = contents
+	res.binVariance = variances
+	*h = *res
+
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// fmtCSVValue formats a bin edge losslessly (using the raw number of nanoseconds for
+// durations)
+func fmtCSVValue[T Number](v T) string {
+	switch valueKind[T]() {
+	case kindFloat:
+		return fmtData(float64(v))
+	case kindInt:
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+func parseCSVValue[T Number](s string) (T, error) {
+	switch valueKind[T]() {
+	case kindFloat:
+		v, err := strconv.ParseFloat(s, 64)
+		if err == nil && math.IsInf(v, 0) {
+			return 0, fmt.Errorf("infinite bin edge %s", s)
+		}
+		return T(v), err
+	case kindInt:
+		v, err := strconv.ParseInt(s, 10, 64)
+		return T(v), err
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	return T(v), err
+}
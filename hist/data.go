@@ -0,0 +1,59 @@
+package hist
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ErrUnknownFormat denotes that a data format is not supported
+var ErrUnknownFormat = errors.New("unknown data format")
+
+// Format denotes a flat-text data format for WriteData()
+type Format int
+
+const (
+	// FormatGnuplot denotes whitespace-separated columns with a commented header line,
+	// e.g. for `plot "data" using (($1+$2)/2):3:4 with yerrorbars`
+	FormatGnuplot Format = iota
+
+	// FormatTSV denotes tab-separated columns with a plain header line
+	FormatTSV
+)
+
+// WriteData writes the regular bins of the histogram as flat-text rows of the form
+// `binLow binHigh content error` to any io.Writer, allowing downstream tooling to
+// process / plot the histogram without parsing the output of Print()
+func (h *H1[T]) WriteData(w io.Writer, format Format) error {
+
+	var header, sep string
+	switch format {
+	case FormatGnuplot:
+		header, sep = "# binLow binHigh content error", " "
+	case FormatTSV:
+		header, sep = "binLow\tbinHigh\tcontent\terror", "\t"
+	default:
+		return fmt.Errorf("%w: %d", ErrUnknownFormat, format)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, header)
+	for i := 1; i <= h.nBins; i++ {
+		fmt.Fprintln(bw,
+			fmtData(float64(h.bins[i-1]))+sep+
+				fmtData(float64(h.bins[i]))+sep+
+				fmtData(h.binContent[i])+sep+
+				fmtData(h.BinError(i)))
+	}
+
+	return bw.Flush()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// fmtData formats a value for data export (using the shortest lossless representation)
+func fmtData(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,55 @@
+package hist
+
+import (
+	"math"
+	"time"
+)
+
+// EnableDecay enables exponential decay of the bin contents with the provided half-life:
+// Before each Fill, the contents (and sum of weights) are scaled according to the time
+// elapsed since the previous one, such that the histogram reflects recent behavior
+// without losing continuity (as opposed to periodic resets). The number of entries is
+// not affected
+func (h *H1[T]) EnableDecay(halfLife time.Duration) {
+	if halfLife <= 0 {
+		panic("half-life must be positive")
+	}
+	if h.now == nil {
+		h.now = time.Now
+	}
+	h.halfLife = halfLife
+	h.lastDecay = h.now()
+}
+
+// HalfLife returns the half-life of the decay of the bin contents (zero if disabled)
+func (h *H1[T]) HalfLife() time.Duration {
+	return h.halfLife
+}
+
+// Decay explicitly decays the bin contents by a time interval, using the half-life set
+// via EnableDecay (e.g. to age the histogram in the absence of new entries). Decays
+// applied this way are independent of (and add to) the ones applied on each Fill
+func (h *H1[T]) Decay(dt time.Duration) {
+	if h.halfLife <= 0 {
+		panic("decay requires a positive half-life, see EnableDecay()")
+	}
+	if dt <= 0 {
+		return
+	}
+
+	h.Scale(math.Exp2(-float64(dt) / float64(h.halfLife)))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// decayOnFill decays the bin contents according to the time elapsed since the last fill
+// (if enabled)
+func (h *H1[T]) decayOnFill() {
+	if h.halfLife <= 0 {
+		return
+	}
+
+	now := h.now()
+	h.Decay(now.Sub(h.lastDecay))
+	h.lastDecay = now
+}
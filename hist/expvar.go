@@ -0,0 +1,46 @@
+package hist
+
+import (
+	"expvar"
+)
+
+// Var denotes a histogram published via expvar (e.g. on /debug/vars). All fills are
+// performed under a lock, hence the published JSON representation is always consistent
+type Var[T Number] struct {
+	*SafeH1[T]
+}
+
+// Publish publishes the histogram under the given name via expvar, returning a wrapper
+// that must be used for all subsequent fills. Like expvar.Publish, it panics if the
+// name is already registered
+func Publish[T Number](name string, h *H1[T]) *Var[T] {
+	obj := &Var[T]{
+		SafeH1: NewSafeH1(h),
+	}
+	expvar.Publish(name, obj)
+
+	return obj
+}
+
+// String implements expvar.Var, returning the JSON representation of the histogram
+func (v *Var[T]) String() string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	data, err := v.h.MarshalJSON()
+	if err != nil {
+		return "null"
+	}
+
+	return string(data)
+}
+
+// PublishFunc publishes a histogram via expvar that is obtained from the provided function
+// whenever the variable is read (e.g. the aggregate of a Window or a snapshot of a
+// ShardedH1), refreshing the published data on demand. Like expvar.Publish, it panics if
+// the name is already registered
+func PublishFunc[T Number](name string, snapshot func() *H1[T]) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return snapshot()
+	}))
+}
@@ -0,0 +1,78 @@
+package hist
+
+import (
+	"math"
+)
+
+// EnableAutoExtend enables automatic extension of the x axis: Instead of being assigned
+// to the under- / overflow bin, a value outside of the current range doubles the range
+// (repeatedly, if required) towards the value, merging pairs of adjacent bins such that
+// the number of bins remains constant. Only supported for equidistant bins (panics
+// otherwise)
+func (h *H1[T]) EnableAutoExtend() {
+	if !h.equidistant {
+		panic("automatic extension of the axis requires equidistant bins")
+	}
+	h.autoExtend = true
+}
+
+// AutoExtend returns if automatic extension of the x axis is enabled
+func (h *H1[T]) AutoExtend() bool {
+	return h.autoExtend
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// fillBin returns the bin to fill for a value, extending the axis if required
+func (h *H1[T]) fillBin(val T) int {
+	if h.autoExtend && (val < h.bins[0] || val > h.bins[h.nBins]) {
+		h.extend(val)
+	}
+
+	return h.findBin(val)
+}
+
+// extend doubles the range of the x axis until it contains the value (unless the range
+// cannot be represented by the underlying type, leaving the value to the flow bins)
+func (h *H1[T]) extend(val T) {
+	if math.IsInf(float64(val), 0) {
+		return
+	}
+
+	for val < h.bins[0] || val > h.bins[h.nBins] {
+		xMin, xMax := h.XMin(), h.XMax()
+		length := xMax - xMin
+
+		// Double the range towards the value, with the old bins occupying the upper or lower
+		// half, respectively (in units of the old bin width)
+		offset := 0
+		if val < xMin {
+			xMin -= length
+			offset = h.nBins
+			if xMin >= h.bins[0] || math.IsInf(float64(xMin), 0) {
+				return
+			}
+		} else {
+			xMax += length
+			if xMax <= h.bins[h.nBins] || math.IsInf(float64(xMax), 0) {
+				return
+			}
+		}
+
+		content, variance := make([]float64, h.nBins+2), make([]float64, h.nBins+2)
+		content[0], content[h.nBins+1] = h.binContent[0], h.binContent[h.nBins+1]
+		variance[0], variance[h.nBins+1] = h.binVariance[0], h.binVariance[h.nBins+1]
+		for k := 1; k <= h.nBins; k++ {
+			j := (offset + k + 1) / 2
+			content[j] += h.binContent[k]
+			variance[j] += h.binVariance[k]
+		}
+		h.binContent, h.binVariance = content, variance
+
+		step := (xMax - xMin) / T(h.nBins)
+		for i := range h.bins {
+			h.bins[i] = xMin + T(i)*step
+		}
+		h.setEquidistant()
+	}
+}
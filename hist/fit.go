@@ -0,0 +1,238 @@
+package hist
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/fako1024/numerics"
+	"github.com/fako1024/numerics/linalg"
+)
+
+const (
+	fitMaxIterations = 500
+	fitTolerance     = 1e-10
+)
+
+// FitResult denotes the result of fitting a model function to a histogram
+type FitResult struct {
+
+	// Params denotes the best-fit parameters of the model
+	Params []float64
+
+	// Errors denotes the (1σ) uncertainties of the parameters
+	Errors []float64
+
+	// Covariance denotes the covariance matrix of the parameters
+	Covariance *linalg.Dense
+
+	// Chi2 denotes the χ² of the fit and NDF the number of degrees of freedom
+	Chi2 float64
+	NDF  int
+}
+
+// Chi2NDF returns the reduced χ² (i.e. χ² per degree of freedom) of the fit
+func (r FitResult) Chi2NDF() float64 {
+	return r.Chi2 / float64(r.NDF)
+}
+
+// Fit performs a weighted least-squares fit of a model function (providing the expected bin
+// content at the center of a bin for a set of parameters) to the regular bins of the
+// histogram via the Levenberg-Marquardt algorithm, starting from the provided initial
+// parameters. Bins are weighted by their inverse variance (assuming Poisson statistics if
+// no variance is recorded), skipping empty bins
+func (h *H1[T]) Fit(model func(x float64, params []float64) float64, init []float64) (FitResult, error) {
+
+	// Collect all bins contributing to the fit
+	var xs, ys, weights []float64
+	for i := 1; i <= h.nBins; i++ {
+		if variance := h.effectiveVariance(i); variance > 0 {
+			xs = append(xs, h.BinCenter(i))
+			ys = append(ys, h.binContent[i])
+			weights = append(weights, 1./variance)
+		}
+	}
+
+	nParams := len(init)
+	if nParams == 0 || len(xs) <= nParams {
+		return FitResult{}, fmt.Errorf("%w: %d non-empty bins insufficient for %d parameters", numerics.ErrDomain, len(xs), nParams)
+	}
+
+	chi2 := func(params []float64) float64 {
+		var res float64
+		for i, x := range xs {
+			r := ys[i] - model(x, params)
+			res += r * r * weights[i]
+		}
+		return res
+	}
+
+	params := slices.Clone(init)
+	current := chi2(params)
+	if math.IsNaN(current) || math.IsInf(current, 0) {
+		return FitResult{}, fmt.Errorf("%w: model not finite for initial parameters", numerics.ErrDomain)
+	}
+
+	lambda := 1e-3
+	for iter := 0; ; iter++ {
+		if iter == fitMaxIterations {
+			return FitResult{}, fmt.Errorf("%w: fit after %d iterations", numerics.ErrNoConvergence, fitMaxIterations)
+		}
+
+		alpha, beta := fitNormalEquations(model, params, xs, ys, weights)
+
+		// Increase the damping until a step reduces the χ² (or the step vanishes)
+		improved := false
+		for lambda < 1e10 {
+			damped := alpha.Clone()
+			for j := 0; j < nParams; j++ {
+				damped.Set(j, j, alpha.At(j, j)*(1.+lambda))
+			}
+			step, err := linalg.Solve(damped, beta)
+			if err != nil {
+				lambda *= 10.
+				continue
+			}
+
+			trial := make([]float64, nParams)
+			for j := range trial {
+				trial[j] = params[j] + step[j]
+			}
+			if next := chi2(trial); next <= current {
+				converged := current-next <= fitTolerance*math.Max(current, 1.)
+				params, current = trial, next
+				lambda = math.Max(lambda/10., 1e-12)
+				improved = true
+				if converged {
+					return fitResult(model, params, current, xs, ys, weights)
+				}
+				break
+			}
+			lambda *= 10.
+		}
+
+		// No further improvement possible, i.e. the minimum was reached
+		if !improved {
+			return fitResult(model, params, current, xs, ys, weights)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// fitResult determines the parameter uncertainties from the inverse of the (undamped)
+// curvature matrix at the minimum
+func fitResult(model func(float64, []float64) float64, params []float64, chi2 float64, xs, ys, weights []float64) (FitResult, error) {
+
+	alpha, _ := fitNormalEquations(model, params, xs, ys, weights)
+	lu, err := linalg.NewLU(alpha)
+	if err != nil {
+		return FitResult{}, fmt.Errorf("%w: parameters not constrained by data: %w", numerics.ErrDomain, err)
+	}
+
+	res := FitResult{
+		Params:     params,
+		Errors:     make([]float64, len(params)),
+		Covariance: lu.Inverse(),
+		Chi2:       chi2,
+		NDF:        len(xs) - len(params),
+	}
+	for j := range res.Errors {
+		res.Errors[j] = math.Sqrt(math.Abs(res.Covariance.At(j, j)))
+	}
+
+	return res, nil
+}
+
+// fitNormalEquations computes the curvature matrix α = JᵀWJ and the vector β = JᵀW(y - f)
+// for the current parameters, using numerical (central difference) derivatives
+func fitNormalEquations(model func(float64, []float64) float64, params, xs, ys, weights []float64) (*linalg.Dense, []float64) {
+
+	nParams := len(params)
+	alpha := linalg.NewDense(nParams, nParams, nil)
+	beta := make([]float64, nParams)
+
+	grad := make([]float64, nParams)
+	shifted := slices.Clone(params)
+	for i, x := range xs {
+		for j := range params {
+			step := 1e-6 * math.Max(math.Abs(params[j]), 1e-3)
+			shifted[j] = params[j] + step
+			up := model(x, shifted)
+			shifted[j] = params[j] - step
+			down := model(x, shifted)
+			shifted[j] = params[j]
+			grad[j] = (up - down) / (2. * step)
+		}
+
+		r := ys[i] - model(x, params)
+		for j := 0; j < nParams; j++ {
+			beta[j] += weights[i] * grad[j] * r
+			for k := 0; k <= j; k++ {
+				alpha.Set(j, k, alpha.At(j, k)+weights[i]*grad[j]*grad[k])
+			}
+		}
+	}
+	for j := 0; j < nParams; j++ {
+		for k := j + 1; k < nParams; k++ {
+			alpha.Set(j, k, alpha.At(k, j))
+		}
+	}
+
+	return alpha, beta
+}
+
+// fwhmPerSigma denotes the ratio between the full width at half maximum and the standard
+// deviation of a Gaussian, i.e. 2√(2 ln 2)
+var fwhmPerSigma = 2. * math.Sqrt(2.*math.Ln2)
+
+// GaussianFit denotes the result of fitting a Gaussian to a histogram
+type GaussianFit struct {
+	FitResult
+
+	// Amplitude, Mean and Sigma denote the parameters of the Gaussian A·exp(-(x-μ)²/(2σ²))
+	// and AmplitudeErr, MeanErr and SigmaErr their uncertainties
+	Amplitude, AmplitudeErr float64
+	Mean, MeanErr           float64
+	Sigma, SigmaErr         float64
+}
+
+// FWHM returns the full width at half maximum of the fitted Gaussian
+func (g GaussianFit) FWHM() float64 {
+	return fwhmPerSigma * g.Sigma
+}
+
+// Resolution returns the relative resolution σ / μ of the fitted Gaussian
+func (g GaussianFit) Resolution() float64 {
+	return g.Sigma / g.Mean
+}
+
+// FitGaussian fits a Gaussian A·exp(-(x-μ)²/(2σ²)) to the histogram (see Fit), with the
+// initial parameters derived from the mode, maximum bin content and standard deviation
+// of the histogram
+func (h *H1[T]) FitGaussian() (GaussianFit, error) {
+
+	sigma := h.StdDev()
+	if !(sigma > 0) {
+		sigma = h.BinWidth(h.MaximumBin())
+	}
+
+	res, err := h.Fit(func(x float64, p []float64) float64 {
+		dx := (x - p[1]) / p[2]
+		return p[0] * math.Exp(-0.5*dx*dx)
+	}, []float64{h.MaximumWeight(), h.Mode(), sigma})
+	if err != nil {
+		return GaussianFit{}, err
+	}
+
+	// The sign of σ is irrelevant (and not constrained by the fit)
+	return GaussianFit{
+		FitResult:    res,
+		Amplitude:    res.Params[0],
+		AmplitudeErr: res.Errors[0],
+		Mean:         res.Params[1],
+		MeanErr:      res.Errors[1],
+		Sigma:        math.Abs(res.Params[2]),
+		SigmaErr:     res.Errors[2],
+	}, nil
+}
@@ -0,0 +1,51 @@
+package hist
+
+// H1Option denotes a functional option for the construction of a one-dimensional histogram
+type H1Option func(*h1Settings)
+
+type h1Settings struct {
+	withoutFlowBins bool
+	onOutOfRange    func(val float64)
+}
+
+// WithoutFlowBins disables the under- / overflow bins: Values outside of the x axis (or NaN)
+// are rejected instead of being silently accumulated, i.e. they do not contribute to the
+// histogram at all. TryFill returns an error for such values, all other fill methods
+// silently drop them (unless a handler is provided via WithOutOfRangeHandler)
+func WithoutFlowBins() H1Option {
+	return func(s *h1Settings) {
+		s.withoutFlowBins = true
+	}
+}
+
+// WithOutOfRangeHandler disables the under- / overflow bins (see WithoutFlowBins), calling
+// the provided function for each rejected value
+func WithOutOfRangeHandler(fn func(val float64)) H1Option {
+	return func(s *h1Settings) {
+		s.withoutFlowBins = true
+		s.onOutOfRange = fn
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (h *H1[T]) applyOptions(options []H1Option) {
+
+	// Execute functional options (if any)
+	for _, option := range options {
+		option(&h.settings)
+	}
+}
+
+// rejectBin determines if a bin must not be filled since the flow bins are disabled,
+// calling the handler for the rejected value (if any)
+func (h *H1[T]) rejectBin(bin int, val T) bool {
+	if !h.settings.withoutFlowBins || (bin > 0 && bin <= h.nBins) {
+		return false
+	}
+	if h.settings.onOutOfRange != nil {
+		h.settings.onOutOfRange(float64(val))
+	}
+
+	return true
+}
@@ -3,14 +3,19 @@ package hist
 import (
 	"fmt"
 	"io"
+	"math"
+	"slices"
 	"strconv"
-	"text/tabwriter"
 	"time"
+
+	"github.com/fako1024/numerics"
 )
 
-// Number provides a type constraint on the supported generics (anything number-like)
+// Number provides a type constraint on the supported generics (anything number-like),
+// including named types based on them (e.g. time.Duration or a fixed-point amount
+// defined as type Cents int64)
 type Number interface {
-	int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | float32 | float64 | time.Duration | uintptr
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64 | ~uintptr
 }
 
 // H1 denotes a one-dimensional histogram
@@ -19,48 +24,211 @@ type H1[T Number] struct {
 	nBins    int
 
 	sumOfWeights float64
+	sumw2        bool
 
 	binContent  []float64
 	binVariance []float64
 	bins        []T
+
+	// Equidistant bins allow for a closed-form bin lookup (based on the inverse bin width)
+	equidistant bool
+	invWidth    float64
+	autoExtend  bool
+
+	// Construction settings (see H1Option)
+	settings h1Settings
+
+	// Exponential decay of the bin contents (if enabled, i.e. for a positive half-life)
+	halfLife  time.Duration
+	lastDecay time.Time
+	now       func() time.Time
+
+	// Cached cubic spline (see InterpolateSpline)
+	spline *splineCache
 }
 
 // NewH1 instantiates a new one-dimensional histogram
-func NewH1[T Number](n int, xMin, xMax T) *H1[T] {
+func NewH1[T Number](n int, xMin, xMax T, options ...H1Option) *H1[T] {
 	obj := H1[T]{
 		nBins: n,
 
 		binContent:  make([]float64, n+2),
 		binVariance: make([]float64, n+2),
 		bins:        make([]T, n+1),
+		spline:      new(splineCache),
 	}
 
 	step := (xMax - xMin) / T(n)
 	for i := 0; i < n+1; i++ {
 		obj.bins[i] = xMin + T(i)*step
 	}
+	obj.setEquidistant()
+	obj.applyOptions(options)
 
 	return &obj
 }
 
-// Print prints out the histogram data to any io.Writer
-func (h *H1[T]) Print(w io.Writer) error {
+// NewH1E instantiates a new one-dimensional histogram (see NewH1), returning an error
+// wrapping numerics.ErrDomain instead of an unusable histogram if the binning is invalid,
+// e.g. when derived from untrusted input
+func NewH1E[T Number](n int, xMin, xMax T, options ...H1Option) (*H1[T], error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: number of bins must be positive, have %d", numerics.ErrDomain, n)
+	}
+	if !(xMin < xMax) || math.IsInf(float64(xMin), 0) || math.IsInf(float64(xMax), 0) {
+		return nil, fmt.Errorf("%w: invalid range of x axis [%v, %v)", numerics.ErrDomain, xMin, xMax)
+	}
+	if (xMax-xMin)/T(n) == 0 {
+		return nil, fmt.Errorf("%w: range of x axis [%v, %v) too small for %d bins", numerics.ErrDomain, xMin, xMax, n)
+	}
 
-	tabw := tabwriter.NewWriter(w, 2, 2, 2, byte(' '), 0)
+	return NewH1(n, xMin, xMax, options...), nil
+}
 
-	fmt.Fprintf(w, "Mode: %v\n", h.Mode())
+// NewH1Edges instantiates a new one-dimensional histogram with arbitrary (i.e. not
+// necessarily equidistant) bins, defined by their n+1 ascending edges
+func NewH1Edges[T Number](edges []T, options ...H1Option) *H1[T] {
+	if len(edges) < 2 {
+		panic("must specify at least two bin edges")
+	}
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i] > edges[i-1]) {
+			panic("bin edges must be strictly ascending")
+		}
+	}
 
-	for i := 0; i < len(h.bins)-1; i++ {
-		fmt.Fprintf(tabw, "%s-%s\t%.3g%%\t%s\n",
-			fmt.Sprintf("%.4v", h.bins[i]),
-			fmt.Sprintf("%.4v", h.bins[i+1]),
-			h.BinContent(i+1)*100.0/h.sumOfWeights,
-			bar(h.BinContent(i+1)*100.0/h.sumOfWeights)+"\t"+yfmt(h.BinContent(i+1)),
-		)
+	n := len(edges) - 1
+	obj := H1[T]{
+		nBins: n,
+
+		binContent:  make([]float64, n+2),
+		binVariance: make([]float64, n+2),
+		bins:        make([]T, n+1),
+		spline:      new(splineCache),
 	}
+	copy(obj.bins, edges)
+	if obj.isEquidistant() {
+		obj.setEquidistant()
+	}
+	obj.applyOptions(options)
 
-	return tabw.Flush()
+	return &obj
+}
+
+// NewH1EqualFreq instantiates a new one-dimensional histogram with (up to) n bins whose edges
+// are chosen from the quantiles of a sample, such that each bin receives roughly the same
+// number of its entries (which is useful for heavily skewed data). Bins that would coincide
+// due to repeated values are merged. The sample is not modified and not filled into the
+// histogram (see FillN())
+func NewH1EqualFreq[T Number](sample []T, n int) *H1[T] {
+	if len(sample) == 0 {
+		panic("must specify a non-empty sample")
+	}
+	if n < 1 {
+		panic("must specify at least one bin")
+	}
+
+	sorted := slices.Clone(sample)
+	slices.Sort(sorted)
+
+	edges := make([]T, 0, n+1)
+	edges = append(edges, sorted[0])
+	for i := 1; i < n; i++ {
+		if edge := sorted[i*len(sorted)/n]; edge > edges[len(edges)-1] {
+			edges = append(edges, edge)
+		}
+	}
+
+	// Ensure that the maximum of the sample falls into the last (regular) bin
+	edges = append(edges, nextAbove(sorted[len(sorted)-1]))
+
+	return NewH1Edges(edges)
+}
+
+// Clone returns a deep copy of the histogram
+func (h *H1[T]) Clone() *H1[T] {
+	res := *h
+	res.binContent = slices.Clone(h.binContent)
+	res.binVariance = slices.Clone(h.binVariance)
+	res.bins = slices.Clone(h.bins)
+	res.spline = h.spline.clone()
+
+	return &res
+}
+
+// Print prints out the histogram data to any io.Writer (the rendering can be customized
+// via functional options). If the bin variances are tracked (see EnableSumw2()), the
+// statistical uncertainty of each bin is printed along with its content
+func (h *H1[T]) Print(w io.Writer, options ...PrintOption) error {
+
+	opts := newPrintSettings(options)
+	colored := opts.useColors(w)
+
+	// Acquire reusable buffers to avoid allocations per bin
+	pb := printBuffers.Get().(*printBuffer)
+	defer printBuffers.Put(pb)
+	tabw := opts.initTabWriter(&pb.tabw, w, colored)
+
+	line := append(pb.line[:0], "Mode: "...)
+	if isDuration[T]() {
+		line = appendFmtValue(line, T(h.Mode()))
+	} else {
+		line = strconv.AppendFloat(line, h.Mode(), 'g', -1, 64)
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	maxContent, modeBin := h.MaximumWeight(), h.MaximumBin()
+	for i := 0; i <= h.nBins+1; i++ {
+		isFlow := i == 0 || i == h.nBins+1
+		if isFlow && !opts.flowBins {
+			continue
+		}
+		y := h.BinContent(i)
+		if opts.skipEmpty && y == 0 {
+			continue
+		}
+
+		labelColor, barColor := ansiReset, magnitudeColor(y, maxContent)
+		switch {
+		case isFlow:
+			labelColor, barColor = ansiDim, ansiDim
+		case i == modeBin:
+			labelColor = ansiBold
+		}
+
+		line = appendEscaped(line[:0], labelColor, colored)
+		switch {
+		case i == 0:
+			line = appendFmtValue(append(line, '<'), h.bins[0])
+		case i == h.nBins+1:
+			line = appendFmtValue(append(line, '>'), h.bins[h.nBins])
+		case opts.binLabel != nil:
+			line = append(line, opts.binLabel(float64(h.bins[i-1]), float64(h.bins[i]))...)
+		default:
+			line = appendFmtValue(append(appendFmtValue(line, h.bins[i-1]), '-'), h.bins[i])
+		}
+		line = appendEscaped(line, ansiReset, colored)
+
+		line = append(strconv.AppendFloat(append(line, '\t'), y*100.0/h.sumOfWeights, 'g', 3, 64), "%\t"...)
+
+		line = appendEscaped(line, barColor, colored)
+		line = appendBar(line, math.Min(opts.barLength(y, h.sumOfWeights, maxContent), float64(opts.maxBarWidth)))
+		line = appendEscaped(line, ansiReset, colored)
 
+		line = opts.appendCount(append(line, '\t'), y)
+		if h.sumw2 {
+			line = opts.appendCount(append(line, " ± "...), h.BinError(i))
+		}
+
+		if _, err := tabw.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	pb.line = line
+
+	return tabw.Flush()
 }
 
 // NBins Returns the number of bins in the histogram
@@ -88,6 +256,21 @@ func (h *H1[T]) XMax() T {
 	return h.bins[h.nBins]
 }
 
+// BinLowEdge returns the lower edge of a particular (regular) bin
+func (h *H1[T]) BinLowEdge(bin int) T {
+	return h.bins[bin-1]
+}
+
+// BinUpEdge returns the upper edge of a particular (regular) bin
+func (h *H1[T]) BinUpEdge(bin int) T {
+	return h.bins[bin]
+}
+
+// BinWidth returns the width of a particular (regular) bin
+func (h *H1[T]) BinWidth(bin int) float64 {
+	return float64(h.bins[bin]) - float64(h.bins[bin-1])
+}
+
 // BinContent returns the sum of weights in a particular bin
 func (h *H1[T]) BinContent(bin int) float64 {
 	return h.binContent[bin]
@@ -98,6 +281,40 @@ func (h *H1[T]) BinVariance(bin int) float64 {
 	return h.binVariance[bin]
 }
 
+// Underflow returns the sum of weights and the variance of the underflow bin
+func (h *H1[T]) Underflow() (float64, float64) {
+	return h.binContent[0], h.binVariance[0]
+}
+
+// Overflow returns the sum of weights and the variance of the overflow bin
+func (h *H1[T]) Overflow() (float64, float64) {
+	return h.binContent[h.nBins+1], h.binVariance[h.nBins+1]
+}
+
+// BinError returns the statistical uncertainty of a particular bin, i.e. the square root
+// of its variance (assuming Poisson statistics if no variance is recorded)
+func (h *H1[T]) BinError(bin int) float64 {
+	return math.Sqrt(h.effectiveVariance(bin))
+}
+
+// EnableSumw2 enables tracking of the sum of squared weights per bin during Fill (i.e. the
+// bin variances), initializing the variances of bins without recorded variance from their
+// current contents (assuming unit weights)
+func (h *H1[T]) EnableSumw2() {
+	if h.sumw2 {
+		return
+	}
+	for i := range h.binVariance {
+		h.binVariance[i] = h.effectiveVariance(i)
+	}
+	h.sumw2 = true
+}
+
+// Sumw2 returns if tracking of the sum of squared weights per bin is enabled
+func (h *H1[T]) Sumw2() bool {
+	return h.sumw2
+}
+
 // MaximumBin returns the maximum bin
 func (h *H1[T]) MaximumBin() int {
 	max, maxBin := -1e99, 0
@@ -117,6 +334,30 @@ func (h *H1[T]) MaximumWeight() float64 {
 	return h.BinContent(h.MaximumBin())
 }
 
+// FindFirstBinAbove returns the first (regular) bin whose sum of weights exceeds the
+// threshold, or -1 if there is none
+func (h *H1[T]) FindFirstBinAbove(threshold float64) int {
+	for i := 1; i <= h.nBins; i++ {
+		if h.binContent[i] > threshold {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// FindLastBinAbove returns the last (regular) bin whose sum of weights exceeds the
+// threshold, or -1 if there is none
+func (h *H1[T]) FindLastBinAbove(threshold float64) int {
+	for i := h.nBins; i >= 1; i-- {
+		if h.binContent[i] > threshold {
+			return i
+		}
+	}
+
+	return -1
+}
+
 // BinCenter returns the center x value of a particular bin
 func (h *H1[T]) BinCenter(bin int) float64 {
 	return (float64(h.bins[bin-1]) + float64(h.bins[bin])) / 2.0
@@ -127,6 +368,31 @@ func (h *H1[T]) Mode() float64 {
 	return h.BinCenter(h.MaximumBin())
 }
 
+// InterpolatedMode returns the mode of the histogram with sub-bin resolution, i.e. the
+// vertex of the parabola through the centers of the maximum bin and its neighbors (limited
+// to the extent of the maximum bin). Falls back to the center of the maximum bin if it is
+// located at the boundary of the x axis
+func (h *H1[T]) InterpolatedMode() float64 {
+	bin := h.MaximumBin()
+	if bin <= 1 || bin >= h.nBins {
+		return h.BinCenter(bin)
+	}
+
+	x0, x1, x2 := h.BinCenter(bin-1), h.BinCenter(bin), h.BinCenter(bin+1)
+	y0, y1, y2 := h.binContent[bin-1], h.binContent[bin], h.binContent[bin+1]
+
+	// Parabola p(x) = y0 + d1·(x-x0) + a·(x-x0)·(x-x1), with vertex at p'(x) = 0
+	d1, d2 := (y1-y0)/(x1-x0), (y2-y1)/(x2-x1)
+	a := (d2 - d1) / (x2 - x0)
+	if !(a < 0) {
+		return x1
+	}
+
+	vertex := (x0+x1)/2. - d1/(2.*a)
+
+	return math.Min(math.Max(vertex, float64(h.bins[bin-1])), float64(h.bins[bin]))
+}
+
 // SetBinContent sets the sum of weights in a particular bin
 func (h *H1[T]) SetBinContent(bin int, sumOfWeights float64) {
 
@@ -135,6 +401,12 @@ func (h *H1[T]) SetBinContent(bin int, sumOfWeights float64) {
 	h.sumOfWeights += sumOfWeights - h.binContent[bin]
 
 	h.binContent[bin] = sumOfWeights
+	h.spline.invalidate()
+}
+
+// SetNEntries sets the number of entries in the histogram
+func (h *H1[T]) SetNEntries(nEntries int) {
+	h.nEntries = nEntries
 }
 
 // SetBinVariance sets the variance in a particular bin
@@ -153,58 +425,108 @@ func (h *H1[T]) Fill(val T, weight ...float64) {
 		w = weight[0]
 	}
 
-	// Increment counters
-	h.nEntries++
-	h.sumOfWeights += w
+	h.fill(val, w)
+}
 
-	// Handle underflow case
-	if val < h.bins[0] {
-		h.binContent[0] += w
-		return
+// FillW adds an entry with the provided weight to the histogram (see Fill), avoiding the
+// allocation of the variadic weight argument in hot paths
+func (h *H1[T]) FillW(val T, weight float64) {
+	h.fill(val, weight)
+}
+
+// TryFill adds a weight / entry to the histogram (see Fill), returning an error wrapping
+// numerics.ErrDomain instead of panicking if more than one weight is provided or the weight
+// is not finite, e.g. when filling from untrusted input. If the under- / overflow bins are
+// disabled (see WithoutFlowBins), an error is also returned for values outside of the x axis
+func (h *H1[T]) TryFill(val T, weight ...float64) error {
+	if len(weight) > 1 {
+		return fmt.Errorf("%w: must specify no or exactly one weight, have %d", numerics.ErrDomain, len(weight))
+	}
+	if len(weight) == 1 && (math.IsNaN(weight[0]) || math.IsInf(weight[0], 0)) {
+		return fmt.Errorf("%w: weight must be finite, have %v", numerics.ErrDomain, weight[0])
 	}
 
-	// Handle overflow case
-	if val > h.bins[h.nBins] {
-		h.binContent[h.nBins+1] += w
-		return
+	w := 1.0
+	if len(weight) == 1 {
+		w = weight[0]
 	}
+	if !h.fill(val, w) {
+		return fmt.Errorf("%w: value %v outside of x axis [%v, %v]", numerics.ErrDomain, val, h.XMin(), h.XMax())
+	}
+
+	return nil
+}
 
-	// Handle standard case
-	for i := 0; i < h.nBins-1; i++ {
-		if val >= h.bins[i] && val < h.bins[i+1] {
-			h.binContent[i+1] += w
-			return
+// FillN adds an entry for each of the provided values to the histogram
+func (h *H1[T]) FillN(vals []T) {
+	h.decayOnFill()
+	h.spline.invalidate()
+	n := 0
+	for _, val := range vals {
+		bin := h.fillBin(val)
+		if h.rejectBin(bin, val) {
+			continue
+		}
+		h.binContent[bin]++
+		if h.sumw2 {
+			h.binVariance[bin]++
 		}
+		n++
+	}
+
+	h.nEntries += n
+	h.sumOfWeights += float64(n)
+}
+
+// FillNW adds an entry for each of the provided values with the respective weight to the
+// histogram (both slices must have the same length)
+func (h *H1[T]) FillNW(vals []T, weights []float64) {
+	if len(vals) != len(weights) {
+		panic("must specify exactly one weight per value")
 	}
+	h.decayOnFill()
+	h.spline.invalidate()
 
-	// Last regular bin is inclusive
-	if val >= h.bins[h.nBins-1] && val <= h.bins[h.nBins] {
-		h.binContent[h.nBins] += w
+	for i, val := range vals {
+		bin := h.fillBin(val)
+		if h.rejectBin(bin, val) {
+			continue
+		}
+		h.binContent[bin] += weights[i]
+		if h.sumw2 {
+			h.binVariance[bin] += weights[i] * weights[i]
+		}
+		h.sumOfWeights += weights[i]
+		h.nEntries++
 	}
 }
 
+// Reset zeroes all bin contents / variances, the number of entries and the sum of
+// weights while retaining the binning, allowing to reuse the histogram
+func (h *H1[T]) Reset() {
+	h.nEntries = 0
+	h.sumOfWeights = 0
+	clear(h.binContent)
+	clear(h.binVariance)
+	h.spline.invalidate()
+}
+
 // Scale scales the histogram by a constant factor
 func (h *H1[T]) Scale(scale float64) {
 
 	h.sumOfWeights *= scale
+	h.spline.invalidate()
 
 	for i := 0; i < h.nBins+2; i++ {
 		h.binContent[i] *= scale
-		h.binVariance[i] *= scale
+		h.binVariance[i] *= scale * scale
 	}
 }
 
-// FindBin returns the bin best matching the value x
+// FindBin returns the bin best matching the value x (in constant time for equidistant
+// bins, via binary search over the bin edges otherwise)
 func (h *H1[T]) FindBin(x T) int {
-
-	if x < h.XMin() {
-		return 0
-	}
-	if x > h.XMax() {
-		return h.nBins + 1
-	}
-
-	return 1 + int(T(h.nBins)*(x-h.XMin())/(h.XMax()-h.XMin()))
+	return h.findBin(x)
 }
 
 // Interpolate linearly interpolates between the nearest bin neigbors
@@ -236,8 +558,81 @@ func (h *H1[T]) Interpolate(x float64) float64 {
 }
 
 func yfmt(y float64) string {
+	return string(appendY(nil, y))
+}
+
+func appendY(dst []byte, y float64) []byte {
 	if y > 0 {
-		return strconv.FormatFloat(y, 'f', 2, 64)
+		return strconv.AppendFloat(dst, y, 'f', 2, 64)
+	}
+	return dst
+}
+
+// setEquidistant marks the bins as equidistant, enabling the closed-form bin lookup
+func (h *H1[T]) setEquidistant() {
+	h.equidistant = true
+	h.invWidth = float64(h.nBins) / (float64(h.XMax()) - float64(h.XMin()))
+}
+
+// findBin returns the bin containing a value
+func (h *H1[T]) findBin(val T) int {
+	if !h.equidistant {
+		return findBin(h.bins, val)
+	}
+
+	// Handle underflow / overflow case (the latter including NaN values)
+	if val < h.bins[0] {
+		return 0
+	}
+	if !(val <= h.bins[h.nBins]) {
+		return h.nBins + 1
+	}
+
+	// Estimate the bin in closed form and correct for rounding of the bin edges (the last
+	// regular bin being inclusive)
+	i := min(int(float64(val-h.bins[0])*h.invWidth), h.nBins-1)
+	for i > 0 && val < h.bins[i] {
+		i--
+	}
+	for i < h.nBins-1 && val >= h.bins[i+1] {
+		i++
+	}
+
+	return i + 1
+}
+
+// nextAbove returns the smallest value representable by T that is larger than v
+func nextAbove[T Number](v T) T {
+	if valueKind[T]() != kindFloat {
+		return v + 1
+	}
+	if next := T(math.Nextafter(float64(v), math.Inf(1))); next > v {
+		return next
+	}
+
+	return T(math.Nextafter32(float32(v), float32(math.Inf(1))))
+}
+
+// fill adds a weight / entry to the histogram, returning false if the value was rejected
+// (see WithoutFlowBins)
+func (h *H1[T]) fill(val T, w float64) bool {
+
+	h.decayOnFill()
+	h.spline.invalidate()
+
+	bin := h.fillBin(val)
+	if h.rejectBin(bin, val) {
+		return false
+	}
+
+	// Increment counters
+	h.nEntries++
+	h.sumOfWeights += w
+
+	h.binContent[bin] += w
+	if h.sumw2 {
+		h.binVariance[bin] += w * w
 	}
-	return ""
+
+	return true
 }
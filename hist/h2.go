@@ -0,0 +1,397 @@
+package hist
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"text/tabwriter"
+)
+
+// H2 denotes a two-dimensional histogram
+type H2[T Number] struct {
+	nEntries int
+	nBinsX   int
+	nBinsY   int
+
+	sumOfWeights float64
+	sumw2        bool
+
+	binContent  []float64
+	binVariance []float64
+	binsX       []T
+	binsY       []T
+}
+
+// NewH2 instantiates a new two-dimensional histogram
+func NewH2[T Number](nx int, xMin, xMax T, ny int, yMin, yMax T) *H2[T] {
+	obj := H2[T]{
+		nBinsX: nx,
+		nBinsY: ny,
+
+		binContent:  make([]float64, (nx+2)*(ny+2)),
+		binVariance: make([]float64, (nx+2)*(ny+2)),
+		binsX:       make([]T, nx+1),
+		binsY:       make([]T, ny+1),
+	}
+
+	stepX := (xMax - xMin) / T(nx)
+	for i := 0; i < nx+1; i++ {
+		obj.binsX[i] = xMin + T(i)*stepX
+	}
+	stepY := (yMax - yMin) / T(ny)
+	for i := 0; i < ny+1; i++ {
+		obj.binsY[i] = yMin + T(i)*stepY
+	}
+
+	return &obj
+}
+
+// Print prints out the histogram data (one row per y bin, starting with the highest one)
+// to any io.Writer
+func (h *H2[T]) Print(w io.Writer) error {
+
+	tabw := tabwriter.NewWriter(w, 2, 2, 2, byte(' '), tabwriter.AlignRight)
+
+	for iy := h.nBinsY; iy >= 1; iy-- {
+		fmt.Fprintf(tabw, "%s-%s\t", fmtValue(h.binsY[iy-1]), fmtValue(h.binsY[iy]))
+		for ix := 1; ix <= h.nBinsX; ix++ {
+			fmt.Fprintf(tabw, "%s\t", yfmt(h.BinContent(ix, iy)))
+		}
+		fmt.Fprintln(tabw)
+	}
+
+	fmt.Fprint(tabw, "\t")
+	for ix := 1; ix <= h.nBinsX; ix++ {
+		fmt.Fprintf(tabw, "%s\t", fmtValue(h.binsX[ix-1]))
+	}
+	fmt.Fprintln(tabw)
+
+	return tabw.Flush()
+}
+
+// NBinsX Returns the number of bins on the x axis
+func (h *H2[T]) NBinsX() int {
+	return h.nBinsX
+}
+
+// NBinsY Returns the number of bins on the y axis
+func (h *H2[T]) NBinsY() int {
+	return h.nBinsY
+}
+
+// NEntries returns the number of entries in the histogram
+func (h *H2[T]) NEntries() int {
+	return h.nEntries
+}
+
+// Sum returns the sum of weights in the histogram
+func (h *H2[T]) Sum() float64 {
+	return h.sumOfWeights
+}
+
+// XMin returns the lower boundary of the x axis
+func (h *H2[T]) XMin() T {
+	return h.binsX[0]
+}
+
+// XMax returns the upper boundary of the x axis
+func (h *H2[T]) XMax() T {
+	return h.binsX[h.nBinsX]
+}
+
+// YMin returns the lower boundary of the y axis
+func (h *H2[T]) YMin() T {
+	return h.binsY[0]
+}
+
+// YMax returns the upper boundary of the y axis
+func (h *H2[T]) YMax() T {
+	return h.binsY[h.nBinsY]
+}
+
+// BinCenter returns the center x and y values of a particular bin
+func (h *H2[T]) BinCenter(ix, iy int) (float64, float64) {
+	return (float64(h.binsX[ix-1]) + float64(h.binsX[ix])) / 2.0,
+		(float64(h.binsY[iy-1]) + float64(h.binsY[iy])) / 2.0
+}
+
+// BinContent returns the sum of weights in a particular bin (with bins 0 and n+1
+// denoting the underflow and overflow on either axis)
+func (h *H2[T]) BinContent(ix, iy int) float64 {
+	return h.binContent[h.index(ix, iy)]
+}
+
+// BinVariance returns the variance in a particular bin
+func (h *H2[T]) BinVariance(ix, iy int) float64 {
+	return h.binVariance[h.index(ix, iy)]
+}
+
+// EnableSumw2 enables tracking of the sum of squared weights per bin during Fill (i.e. the
+// bin variances), initializing the variances of bins without recorded variance from their
+// current contents (assuming unit weights)
+func (h *H2[T]) EnableSumw2() {
+	if h.sumw2 {
+		return
+	}
+	for i, v := range h.binVariance {
+		if v == 0 {
+			h.binVariance[i] = math.Abs(h.binContent[i])
+		}
+	}
+	h.sumw2 = true
+}
+
+// MaximumBin returns the maximum (regular) bin
+func (h *H2[T]) MaximumBin() (int, int) {
+	max, maxX, maxY := -1e99, 0, 0
+
+	for iy := 1; iy <= h.nBinsY; iy++ {
+		for ix := 1; ix <= h.nBinsX; ix++ {
+			if content := h.BinContent(ix, iy); content > max {
+				max, maxX, maxY = content, ix, iy
+			}
+		}
+	}
+
+	return maxX, maxY
+}
+
+// SetBinContent sets the sum of weights in a particular bin
+func (h *H2[T]) SetBinContent(ix, iy int, sumOfWeights float64) {
+	idx := h.index(ix, iy)
+
+	// increase overall sum of weights by current value in requested bin and
+	// subtract the old bin content
+	h.sumOfWeights += sumOfWeights - h.binContent[idx]
+
+	h.binContent[idx] = sumOfWeights
+}
+
+// SetNEntries sets the number of entries in the histogram
+func (h *H2[T]) SetNEntries(nEntries int) {
+	h.nEntries = nEntries
+}
+
+// SetBinVariance sets the variance in a particular bin
+func (h *H2[T]) SetBinVariance(ix, iy int, variance float64) {
+	h.binVariance[h.index(ix, iy)] = variance
+}
+
+// Fill adds a weight / entry to the histogram
+func (h *H2[T]) Fill(x, y T, weight ...float64) {
+
+	if len(weight) > 1 {
+		panic("must specify no or exactly one weight")
+	}
+	w := 1.0
+	if len(weight) == 1 {
+		w = weight[0]
+	}
+
+	// Increment counters
+	h.nEntries++
+	h.sumOfWeights += w
+
+	idx := h.index(h.FindBin(x, y))
+	h.binContent[idx] += w
+	if h.sumw2 {
+		h.binVariance[idx] += w * w
+	}
+}
+
+// Reset zeroes all bin contents / variances, the number of entries and the sum of
+// weights while retaining the binning, allowing to reuse the histogram
+func (h *H2[T]) Reset() {
+	h.nEntries = 0
+	h.sumOfWeights = 0
+	clear(h.binContent)
+	clear(h.binVariance)
+}
+
+// Scale scales the histogram by a constant factor
+func (h *H2[T]) Scale(scale float64) {
+
+	h.sumOfWeights *= scale
+
+	for i := range h.binContent {
+		h.binContent[i] *= scale
+		h.binVariance[i] *= scale * scale
+	}
+}
+
+// FindBin returns the bins best matching the values x and y
+func (h *H2[T]) FindBin(x, y T) (int, int) {
+	return findBin(h.binsX, x), findBin(h.binsY, y)
+}
+
+// ProjectionOption denotes a functional option for ProjectionX / ProjectionY
+type ProjectionOption func(*projectionSettings)
+
+type projectionSettings struct {
+	first, last int
+	restricted  bool
+}
+
+// WithBinRange restricts a projection to the bins first to last (inclusive) of the other
+// axis, with 0 and n+1 denoting the underflow / overflow bin, respectively
+func WithBinRange(first, last int) ProjectionOption {
+	return func(s *projectionSettings) {
+		s.first, s.last, s.restricted = first, last, true
+	}
+}
+
+// ProjectionX returns the projection of the histogram onto the x axis (summing over all
+// y bins, including underflow and overflow, unless restricted via WithBinRange). For a
+// restricted projection, the number of entries is estimated from the sum of weights
+func (h *H2[T]) ProjectionX(options ...ProjectionOption) *H1[T] {
+	return h.projection(h.binsX, h.nBinsY, h.index, options)
+}
+
+// ProjectionY returns the projection of the histogram onto the y axis (summing over all
+// x bins, including underflow and overflow, unless restricted via WithBinRange). For a
+// restricted projection, the number of entries is estimated from the sum of weights
+func (h *H2[T]) ProjectionY(options ...ProjectionOption) *H1[T] {
+	return h.projection(h.binsY, h.nBinsX, func(i, j int) int {
+		return h.index(j, i)
+	}, options)
+}
+
+// SliceX returns the distribution of x for a particular bin of y (with 0 and n+1 denoting
+// the underflow / overflow bin, respectively), see ProjectionX
+func (h *H2[T]) SliceX(binY int) *H1[T] {
+	return h.ProjectionX(WithBinRange(binY, binY))
+}
+
+// SliceY returns the distribution of y for a particular bin of x (with 0 and n+1 denoting
+// the underflow / overflow bin, respectively), see ProjectionY
+func (h *H2[T]) SliceY(binX int) *H1[T] {
+	return h.ProjectionY(WithBinRange(binX, binX))
+}
+
+// ProfileX returns the profile of the histogram along the x axis, i.e. the mean (and spread)
+// of y per bin of x, estimated from the centers of the regular y bins
+func (h *H2[T]) ProfileX() *HProf[T] {
+	return h.profile(h.binsX, h.binsY, h.index)
+}
+
+// ProfileY returns the profile of the histogram along the y axis, i.e. the mean (and spread)
+// of x per bin of y, estimated from the centers of the regular x bins
+func (h *H2[T]) ProfileY() *HProf[T] {
+	return h.profile(h.binsY, h.binsX, func(i, j int) int {
+		return h.index(j, i)
+	})
+}
+
+// Covariance returns the covariance of x and y, estimated from the bin centers of the
+// regular bins (i.e. excluding under- / overflow), or NaN if these are empty
+func (h *H2[T]) Covariance() float64 {
+	_, _, cov := h.covariance()
+	return cov
+}
+
+// CorrelationFactor returns the (Pearson) correlation coefficient of x and y, estimated from
+// the bin centers of the regular bins (NaN if these are empty or either variable does not
+// vary)
+func (h *H2[T]) CorrelationFactor() float64 {
+	varX, varY, cov := h.covariance()
+	return cov / math.Sqrt(varX*varY)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// index returns the position of a bin in the flattened bin slices
+func (h *H2[T]) index(ix, iy int) int {
+	return iy*(h.nBinsX+2) + ix
+}
+
+// projection sums the bins of the other axis (restricted to a range of bins, if requested)
+// for each bin of the projection axis, with index mapping a pair of bins on the projection
+// and the other axis onto the respective global bin index
+func (h *H2[T]) projection(bins []T, nOther int, index func(i, j int) int, options []ProjectionOption) *H1[T] {
+
+	opts := projectionSettings{
+		first: 0,
+		last:  nOther + 1,
+	}
+
+	// Execute functional options (if any)
+	for _, option := range options {
+		option(&opts)
+	}
+	opts.first, opts.last = max(opts.first, 0), min(opts.last, nOther+1)
+
+	n := len(bins) - 1
+	proj := NewH1(n, bins[0], bins[n])
+	copy(proj.bins, bins)
+	for i := 0; i <= n+1; i++ {
+		for j := opts.first; j <= opts.last; j++ {
+			proj.binContent[i] += h.binContent[index(i, j)]
+			proj.binVariance[i] += h.binVariance[index(i, j)]
+		}
+	}
+	proj.updateSumOfWeights()
+	proj.sumw2 = h.sumw2
+
+	proj.nEntries = h.nEntries
+	if opts.restricted {
+		proj.nEntries = int(math.Round(proj.sumOfWeights))
+	}
+
+	return proj
+}
+
+// profile accumulates the mean of the other axis for each bin of the profile axis, with
+// index mapping a pair of bins on the profile and the other axis onto the respective global
+// bin index
+func (h *H2[T]) profile(bins, otherBins []T, index func(i, j int) int) *HProf[T] {
+
+	n, nOther := len(bins)-1, len(otherBins)-1
+	prof := NewHProf(n, bins[0], bins[n])
+	copy(prof.h.bins, bins)
+	for i := 0; i <= n+1; i++ {
+		for j := 1; j <= nOther; j++ {
+			idx := index(i, j)
+			w, y := h.binContent[idx], (float64(otherBins[j-1])+float64(otherBins[j]))/2.
+
+			variance := h.binVariance[idx]
+			if !h.sumw2 {
+				variance = math.Abs(w)
+			}
+
+			prof.h.binContent[i] += w
+			prof.h.binVariance[i] += variance
+			prof.sumWY[i] += w * y
+			prof.sumWY2[i] += w * y * y
+		}
+	}
+	prof.h.updateSumOfWeights()
+	prof.h.nEntries = h.nEntries
+
+	return prof
+}
+
+// covariance computes the variances of x and y as well as their covariance from the bin
+// centers of the regular bins
+func (h *H2[T]) covariance() (varX, varY, cov float64) {
+
+	var sum, sumX, sumY, sumXX, sumYY, sumXY float64
+	for iy := 1; iy <= h.nBinsY; iy++ {
+		for ix := 1; ix <= h.nBinsX; ix++ {
+			w := h.BinContent(ix, iy)
+			x, y := h.BinCenter(ix, iy)
+			sum += w
+			sumX += w * x
+			sumY += w * y
+			sumXX += w * x * x
+			sumYY += w * y * y
+			sumXY += w * x * y
+		}
+	}
+	if sum == 0 {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+
+	meanX, meanY := sumX/sum, sumY/sum
+
+	return math.Max(0., sumXX/sum-meanX*meanX), math.Max(0., sumYY/sum-meanY*meanY), sumXY/sum - meanX*meanY
+}
@@ -0,0 +1,204 @@
+package hist
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// HCat denotes a categorical histogram, i.e. a histogram whose bins are identified by
+// string labels
+type HCat struct {
+	nEntries int
+
+	sumOfWeights float64
+
+	labels     []string
+	binContent []float64
+	index      map[string]int
+}
+
+// NewHCat instantiates a new categorical histogram, optionally predefining (the order of)
+// labels. Any other label is appended upon its first fill
+func NewHCat(labels ...string) *HCat {
+	obj := HCat{
+		index: make(map[string]int, len(labels)),
+	}
+	for _, label := range labels {
+		obj.bin(label)
+	}
+
+	return &obj
+}
+
+// Print prints out the histogram data to any io.Writer (the rendering can be customized
+// via functional options)
+func (h *HCat) Print(w io.Writer, options ...PrintOption) error {
+
+	opts := newPrintSettings(options)
+	tabw := tabwriter.NewWriter(w, 2, 2, 2, byte(' '), 0)
+
+	fmt.Fprintf(w, "Mode: %v\n", h.Mode())
+
+	maxContent := 0.
+	for _, y := range h.binContent {
+		maxContent = max(maxContent, y)
+	}
+	for i, label := range h.labels {
+		y := h.binContent[i]
+		if opts.skipEmpty && y == 0 {
+			continue
+		}
+
+		fmt.Fprintf(tabw, "%s\t%.3g%%\t%s\n",
+			label,
+			y*100.0/h.sumOfWeights,
+			bar(math.Min(opts.barLength(y, h.sumOfWeights, maxContent), float64(opts.maxBarWidth)))+"\t"+opts.fmtCount(y),
+		)
+	}
+
+	return tabw.Flush()
+}
+
+// NBins Returns the number of bins (i.e. labels) in the histogram
+func (h *HCat) NBins() int {
+	return len(h.labels)
+}
+
+// NEntries returns the number of entries in the histogram
+func (h *HCat) NEntries() int {
+	return h.nEntries
+}
+
+// Sum returns the sum of weights in the histogram
+func (h *HCat) Sum() float64 {
+	return h.sumOfWeights
+}
+
+// Labels returns the labels of all bins (in their current order)
+func (h *HCat) Labels() []string {
+	return slices.Clone(h.labels)
+}
+
+// BinContent returns the sum of weights for a particular label
+func (h *HCat) BinContent(label string) float64 {
+	idx, ok := h.index[label]
+	if !ok {
+		return 0
+	}
+
+	return h.binContent[idx]
+}
+
+// Mode returns the label with the largest sum of weights
+func (h *HCat) Mode() string {
+	max, mode := -1e99, ""
+	for i, label := range h.labels {
+		if h.binContent[i] > max {
+			max, mode = h.binContent[i], label
+		}
+	}
+
+	return mode
+}
+
+// Fill adds a weight / entry to the histogram
+func (h *HCat) Fill(label string, weight ...float64) {
+
+	if len(weight) > 1 {
+		panic("must specify no or exactly one weight")
+	}
+	w := 1.0
+	if len(weight) == 1 {
+		w = weight[0]
+	}
+
+	// Increment counters
+	h.nEntries++
+	h.sumOfWeights += w
+
+	h.binContent[h.bin(label)] += w
+}
+
+// SortByContent sorts the bins by descending sum of weights (retaining the order of bins
+// with equal content)
+func (h *HCat) SortByContent() {
+	h.sort(func(a, b int) int {
+		switch {
+		case h.binContent[a] > h.binContent[b]:
+			return -1
+		case h.binContent[a] < h.binContent[b]:
+			return 1
+		}
+		return 0
+	})
+}
+
+// SortByLabel sorts the bins lexicographically by their labels
+func (h *HCat) SortByLabel() {
+	h.sort(func(a, b int) int {
+		return strings.Compare(h.labels[a], h.labels[b])
+	})
+}
+
+// Add adds the contents of another histogram (appending any labels not yet present)
+func (h *HCat) Add(other *HCat) {
+	for i, label := range other.labels {
+		h.binContent[h.bin(label)] += other.binContent[i]
+	}
+	h.nEntries += other.nEntries
+	h.sumOfWeights += other.sumOfWeights
+}
+
+// MergeCat returns a new categorical histogram containing the sum of all provided
+// histograms
+func MergeCat(hs ...*HCat) *HCat {
+	res := NewHCat()
+	for _, h := range hs {
+		res.Add(h)
+	}
+
+	return res
+}
+
+// Reset zeroes all bin contents, the number of entries and the sum of weights while
+// retaining the labels
+func (h *HCat) Reset() {
+	h.nEntries = 0
+	h.sumOfWeights = 0
+	clear(h.binContent)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// bin returns the index of the bin for a label, creating it if required
+func (h *HCat) bin(label string) int {
+	idx, ok := h.index[label]
+	if !ok {
+		idx = len(h.labels)
+		h.index[label] = idx
+		h.labels = append(h.labels, label)
+		h.binContent = append(h.binContent, 0)
+	}
+
+	return idx
+}
+
+// sort reorders the bins according to a comparison function of bin indices
+func (h *HCat) sort(cmp func(a, b int) int) {
+	order := make([]int, len(h.labels))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, cmp)
+
+	labels, content := make([]string, len(order)), make([]float64, len(order))
+	for i, idx := range order {
+		labels[i], content[i] = h.labels[idx], h.binContent[idx]
+		h.index[labels[i]] = i
+	}
+	h.labels, h.binContent = labels, content
+}
@@ -0,0 +1,217 @@
+package hist
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fako1024/numerics"
+)
+
+// HDR denotes a high dynamic range histogram of durations (similar to HdrHistogram),
+// covering the range from a nanosecond up to a maximum value using exponentially sized
+// buckets (each power of two being divided linearly into sub-buckets), guaranteeing a
+// configurable relative precision
+type HDR struct {
+	maxValue          time.Duration
+	significantDigits int
+	subBits           int
+
+	counts   []uint64
+	overflow uint64
+	total    uint64
+	sum      float64
+	min, max time.Duration
+}
+
+// NewHDR instantiates a new high dynamic range histogram for durations up to maxValue,
+// with the given number of significant decimal digits (1 - 5) being retained for all
+// values (e.g. 3 digits yielding a relative precision of 0.1%)
+func NewHDR(maxValue time.Duration, significantDigits int) *HDR {
+	if significantDigits < 1 || significantDigits > 5 {
+		panic("number of significant digits must be between 1 and 5")
+	}
+	if maxValue < 1 {
+		panic("maximum value must be positive")
+	}
+
+	subBits := bits.Len64(2 * uint64(math.Pow10(significantDigits)))
+	obj := HDR{
+		maxValue:          maxValue,
+		significantDigits: significantDigits,
+		subBits:           subBits,
+	}
+	obj.counts = make([]uint64, obj.index(uint64(maxValue))+1)
+	obj.Reset()
+
+	return &obj
+}
+
+// Print prints out a summary of selected quantiles to any io.Writer
+func (h *HDR) Print(w io.Writer) error {
+
+	tabw := tabwriter.NewWriter(w, 2, 2, 2, byte(' '), 0)
+
+	fmt.Fprintf(tabw, "Count\t%d\n", h.total)
+	for _, q := range []float64{0.5, 0.9, 0.99, 0.999, 1.} {
+		fmt.Fprintf(tabw, "P%v\t%v\n", q*100., h.Quantile(q))
+	}
+
+	return tabw.Flush()
+}
+
+// Record adds a duration to the histogram (negative durations being recorded as zero)
+func (h *HDR) Record(d time.Duration) {
+	h.RecordN(d, 1)
+}
+
+// RecordN adds a duration n times to the histogram
+func (h *HDR) RecordN(d time.Duration, n uint64) {
+	d = max(d, 0)
+
+	h.total += n
+	h.sum += float64(d) * float64(n)
+	h.min, h.max = min(h.min, d), max(h.max, d)
+	if d > h.maxValue {
+		h.overflow += n
+		return
+	}
+	h.counts[h.index(uint64(d))] += n
+}
+
+// Count returns the number of recorded durations
+func (h *HDR) Count() uint64 {
+	return h.total
+}
+
+// Overflow returns the number of recorded durations exceeding the maximum value
+func (h *HDR) Overflow() uint64 {
+	return h.overflow
+}
+
+// Min returns the minimum recorded duration
+func (h *HDR) Min() time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	return h.min
+}
+
+// Max returns the maximum recorded duration
+func (h *HDR) Max() time.Duration {
+	return h.max
+}
+
+// Mean returns the mean of all recorded durations
+func (h *HDR) Mean() time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	return time.Duration(h.sum / float64(h.total))
+}
+
+// Sum returns the sum of all recorded durations (in nanoseconds, as float64 to avoid
+// overflows)
+func (h *HDR) Sum() float64 {
+	return h.sum
+}
+
+// SignificantDigits returns the number of significant decimal digits retained for all
+// values
+func (h *HDR) SignificantDigits() int {
+	return h.significantDigits
+}
+
+// VisitBuckets calls fn for each non-empty bucket (in ascending order), providing its
+// lower edge, width and count (excluding the overflow, see Overflow)
+func (h *HDR) VisitBuckets(fn func(low, width time.Duration, count uint64)) {
+	for i, count := range h.counts {
+		if count == 0 {
+			continue
+		}
+		low, width := h.bucket(i)
+		fn(time.Duration(low), time.Duration(width), count)
+	}
+}
+
+// Quantile returns the duration below which the fraction q of all recorded durations
+// resides (within the precision of the histogram, quantiles residing in the overflow
+// being reported as the maximum recorded duration)
+func (h *HDR) Quantile(q float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+
+	target := uint64(math.Ceil(math.Max(0., math.Min(1., q)) * float64(h.total)))
+	target = max(target, 1)
+
+	var cum uint64
+	for i, count := range h.counts {
+		cum += count
+		if cum >= target {
+			low, width := h.bucket(i)
+			return min(max(time.Duration(low+width/2), h.min), h.max)
+		}
+	}
+
+	return h.max
+}
+
+// Median returns the median of all recorded durations (see Quantile)
+func (h *HDR) Median() time.Duration {
+	return h.Quantile(0.5)
+}
+
+// Merge adds the contents of another histogram, returning an error wrapping
+// numerics.ErrIncompatibleBinning if the histograms differ in their configuration
+func (h *HDR) Merge(other *HDR) error {
+	if h.maxValue != other.maxValue || h.significantDigits != other.significantDigits {
+		return fmt.Errorf("%w: HDR histograms differ in maximum value / precision", numerics.ErrIncompatibleBinning)
+	}
+
+	for i, count := range other.counts {
+		h.counts[i] += count
+	}
+	h.overflow += other.overflow
+	h.total += other.total
+	h.sum += other.sum
+	h.min, h.max = min(h.min, other.min), max(h.max, other.max)
+
+	return nil
+}
+
+// Reset zeroes the histogram while retaining its configuration
+func (h *HDR) Reset() {
+	clear(h.counts)
+	h.overflow, h.total, h.sum = 0, 0, 0
+	h.min, h.max = math.MaxInt64, 0
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// index returns the bucket index of a value: Values below 2^subBits are represented
+// exactly, larger values by the subBits most significant bits (the upper half of each
+// power of two being covered by half the sub-buckets)
+func (h *HDR) index(v uint64) int {
+	half := 1 << (h.subBits - 1)
+	if v < 1<<h.subBits {
+		return int(v)
+	}
+
+	shift := bits.Len64(v) - h.subBits
+	return shift*half + int(v>>shift)
+}
+
+// bucket returns the lower edge and width of a bucket
+func (h *HDR) bucket(idx int) (uint64, uint64) {
+	half := 1 << (h.subBits - 1)
+	if idx < 1<<h.subBits {
+		return uint64(idx), 1
+	}
+
+	shift := idx/half - 1
+	return uint64(idx-shift*half) << shift, 1 << shift
+}
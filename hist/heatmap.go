@@ -0,0 +1,94 @@
+package hist
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultShadeRamp denotes the default characters used for the cells of a heatmap, in
+// ascending order of the bin content
+const defaultShadeRamp = " ░▒▓█"
+
+// WithShadeRamp sets the characters used for the cells of a heatmap in ascending order of
+// the bin content, the first one denoting empty bins (default: " ░▒▓█", ignored for
+// one-dimensional histograms)
+func WithShadeRamp(ramp string) PrintOption {
+	return func(s *printSettings) {
+		if utf8.RuneCountInString(ramp) >= 2 {
+			s.shadeRamp = []rune(ramp)
+		}
+	}
+}
+
+// PrintHeatmap renders the regular bins of the histogram as heatmap of shaded characters
+// (two per bin) to any io.Writer, including axis labels and a legend mapping each shade
+// to the range of bin contents it represents
+func (h *H2[T]) PrintHeatmap(w io.Writer, options ...PrintOption) error {
+
+	opts := newPrintSettings(options)
+	ramp := opts.shadeRamp
+	if ramp == nil {
+		ramp = []rune(defaultShadeRamp)
+	}
+	nLevels := len(ramp) - 1
+
+	// Determine the maximum bin content (all shades are relative to it)
+	maxContent := 0.
+	for iy := 1; iy <= h.nBinsY; iy++ {
+		for ix := 1; ix <= h.nBinsX; ix++ {
+			maxContent = math.Max(maxContent, h.BinContent(ix, iy))
+		}
+	}
+	shade := func(y float64) rune {
+		if y <= 0 || maxContent == 0 {
+			return ramp[0]
+		}
+		return ramp[min(max(int(math.Ceil(y/maxContent*float64(nLevels))), 1), nLevels)]
+	}
+
+	label := func(low, high T) string {
+		if opts.binLabel != nil {
+			return opts.binLabel(float64(low), float64(high))
+		}
+		return fmtValue(low) + "-" + fmtValue(high)
+	}
+	labels, labelWidth := make([]string, h.nBinsY+1), 0
+	for iy := 1; iy <= h.nBinsY; iy++ {
+		labels[iy] = label(h.binsY[iy-1], h.binsY[iy])
+		labelWidth = max(labelWidth, utf8.RuneCountInString(labels[iy]))
+	}
+	pad := func(s string, width int) string {
+		return strings.Repeat(" ", max(width-utf8.RuneCountInString(s), 0)) + s
+	}
+
+	var sb strings.Builder
+	for iy := h.nBinsY; iy >= 1; iy-- {
+		sb.WriteString(pad(labels[iy], labelWidth) + " │")
+		for ix := 1; ix <= h.nBinsX; ix++ {
+			c := shade(h.BinContent(ix, iy))
+			sb.WriteRune(c)
+			sb.WriteRune(c)
+		}
+		sb.WriteString("│\n")
+	}
+	sb.WriteString(strings.Repeat(" ", labelWidth) + " └" + strings.Repeat("─", 2*h.nBinsX) + "┘\n")
+
+	// Labels of the x axis (boundaries only, the lower one aligned with the left edge of
+	// the heatmap)
+	xMin, xMax := fmtValue(h.XMin()), fmtValue(h.XMax())
+	sb.WriteString(strings.Repeat(" ", labelWidth+2) + xMin)
+	sb.WriteString(pad(xMax, 2*h.nBinsX-utf8.RuneCountInString(xMin)) + "\n")
+
+	// Legend
+	sb.WriteString("\nLegend:")
+	for i := 1; i <= nLevels; i++ {
+		fmt.Fprintf(&sb, "  %c ≤ %s", ramp[i], opts.fmtCount(maxContent*float64(i)/float64(nLevels)))
+	}
+	sb.WriteString("\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
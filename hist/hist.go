@@ -3,11 +3,11 @@ package hist
 import (
 	"io"
 	"math"
-	"strings"
+	"sort"
 )
 
 type Hist1D interface {
-	Print(w io.Writer) error
+	Print(w io.Writer, options ...PrintOption) error
 
 	// NBins Returns the number of bins in the histogram
 	NBins() int
@@ -65,10 +65,38 @@ var blocks = []string{
 }
 
 func bar(v float64) string {
+	return string(appendBar(nil, v))
+}
+
+func appendBar(dst []byte, v float64) []byte {
 	if v < 0. || math.IsNaN(v) {
 		v = 0.
 	}
 
 	charIdx := int(math.Floor((v-math.Floor(v))*10.0) / 10.0 * 8.0)
-	return strings.Repeat("█", int(v)) + blocks[charIdx]
+	for i := 0; i < int(v); i++ {
+		dst = append(dst, "█"...)
+	}
+	return append(dst, blocks[charIdx]...)
+}
+
+// findBin returns the bin containing a value for a set of bin edges (with the last
+// regular bin being inclusive)
+func findBin[T Number](edges []T, val T) int {
+	n := len(edges) - 1
+
+	// Handle underflow / overflow case (the latter including NaN values)
+	if val < edges[0] {
+		return 0
+	}
+	if !(val <= edges[n]) {
+		return n + 1
+	}
+
+	// Handle standard case (last regular bin is inclusive)
+	bin := sort.Search(n, func(i int) bool {
+		return val < edges[i+1]
+	})
+
+	return min(bin+1, n)
 }
@@ -0,0 +1,2375 @@
+package hist
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"expvar"
+	"fmt"
+	"image/png"
+	"io"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fako1024/numerics"
+)
+
+func TestPublish(t *testing.T) {
+
+	v := Publish("test_histogram", NewH1D(4, 0., 4.))
+	for _, x := range []float64{-1., 0.5, 1.5, 1.7, 5.} {
+		v.Fill(x)
+	}
+
+	var res struct {
+		Entries   int       `json:"entries"`
+		Edges     []float64 `json:"edges"`
+		Content   []float64 `json:"content"`
+		Underflow float64   `json:"underflow"`
+		Overflow  float64   `json:"overflow"`
+	}
+	if err := json.Unmarshal([]byte(expvar.Get("test_histogram").String()), &res); err != nil {
+		t.Fatalf("Unexpected error decoding published histogram: %s", err)
+	}
+
+	if res.Entries != 5 || len(res.Edges) != 5 || res.Underflow != 1. || res.Overflow != 1. {
+		t.Fatalf("Unexpected published histogram: %+v", res)
+	}
+	if res.Content[0] != 1. || res.Content[1] != 2. {
+		t.Fatalf("Unexpected published bin contents: %v", res.Content)
+	}
+
+	sharded := NewShardedH1(4, 0., 4., 2)
+	PublishFunc("test_histogram_func", sharded.Snapshot)
+	for _, x := range []float64{0.5, 1.5, 1.7} {
+		sharded.Fill(x)
+	}
+	if err := json.Unmarshal([]byte(expvar.Get("test_histogram_func").String()), &res); err != nil {
+		t.Fatalf("Unexpected error decoding published histogram: %s", err)
+	}
+	if res.Entries != 3 || res.Content[1] != 2. {
+		t.Fatalf("Unexpected published histogram snapshot: %+v", res)
+	}
+}
+
+type cents int64
+
+func (c cents) Value() float64 {
+	return float64(c) / 100.
+}
+
+func (c cents) FromFloat(x float64) cents {
+	return cents(math.Round(x * 100.))
+}
+
+func TestH1V(t *testing.T) {
+
+	h := NewH1V[cents](4, 0, 400)
+	for _, c := range []cents{50, 120, 150, 199, 350} {
+		h.Fill(c)
+	}
+
+	if h.NEntries() != 5 || h.XMax() != 400 || h.BinUpEdge(1) != 100 {
+		t.Fatalf("Unexpected histogram of custom values: entries %d, xMax %v", h.NEntries(), h.XMax())
+	}
+	if h.MaximumBin() != 2 || h.Mode() != 150 || h.FindBin(cents(360)) != 4 {
+		t.Fatalf("Unexpected mode of histogram of custom values: %v", h.Mode())
+	}
+
+	// Named numeric types can be histogrammed directly
+	hc := NewH1[cents](4, 0, 400)
+	for _, c := range []cents{50, 120, 150, 199, 350} {
+		hc.Fill(c)
+	}
+	if hc.NEntries() != 5 || hc.BinUpEdge(1) != 100 || hc.MaximumBin() != 2 || hc.FindBin(cents(360)) != 4 {
+		t.Fatalf("Unexpected histogram of named numeric type: entries %d, maximum bin %d", hc.NEntries(), hc.MaximumBin())
+	}
+
+	type latency time.Duration
+	hl := NewH1[latency](2, 0, latency(time.Second))
+	hl.Fill(latency(100 * time.Millisecond))
+	hl.Fill(latency(700 * time.Millisecond))
+	if hl.BinContent(1) != 1. || hl.BinContent(2) != 1. || hl.XMax() != latency(time.Second) {
+		t.Fatalf("Unexpected histogram of named duration type: %v / %v", hl.BinContent(1), hl.BinContent(2))
+	}
+	if err := hl.Print(io.Discard); err != nil {
+		t.Fatalf("Failed to print histogram of named duration type: %s", err)
+	}
+}
+
+func TestH2(t *testing.T) {
+
+	h := NewH2D(4, 0., 4., 2, 0., 10.)
+	h.Fill(0.5, 1.)
+	h.Fill(0.5, 2., 2.)
+	h.Fill(3.5, 7.)
+	h.Fill(4., 10.)
+	h.Fill(-1., 5.)
+	h.Fill(2., 11.)
+
+	if h.NEntries() != 6 || h.Sum() != 7. || h.NBinsX() != 4 || h.NBinsY() != 2 {
+		t.Fatalf("Unexpected histogram properties: entries %d, sum %v", h.NEntries(), h.Sum())
+	}
+	if h.BinContent(1, 1) != 3. || h.BinContent(4, 2) != 2. || h.BinContent(0, 2) != 1. || h.BinContent(3, 3) != 1. {
+		t.Fatalf("Unexpected bin contents")
+	}
+	if ix, iy := h.MaximumBin(); ix != 1 || iy != 1 {
+		t.Fatalf("Unexpected maximum bin: (%d, %d)", ix, iy)
+	}
+	if x, y := h.BinCenter(2, 1); x != 1.5 || y != 2.5 {
+		t.Fatalf("Unexpected bin center: (%v, %v)", x, y)
+	}
+	if ix, iy := h.FindBin(math.NaN(), 5.); ix != 5 || iy != 2 {
+		t.Fatalf("Unexpected bin for NaN: (%d, %d)", ix, iy)
+	}
+
+	projX, projY := h.ProjectionX(), h.ProjectionY()
+	if projX.BinContent(1) != 3. || projX.BinContent(0) != 1. || projX.Sum() != h.Sum() {
+		t.Fatalf("Unexpected x projection")
+	}
+	if projY.BinContent(1) != 3. || projY.BinContent(2) != 3. || projY.BinContent(3) != 1. {
+		t.Fatalf("Unexpected y projection")
+	}
+
+	// Projections restricted to a range of bins of the other axis
+	if projX := h.ProjectionX(WithBinRange(1, 1)); projX.BinContent(1) != 3. || projX.BinContent(0) != 0. || projX.Sum() != 3. || projX.NEntries() != 3 {
+		t.Fatalf("Unexpected restricted x projection: sum %v", projX.Sum())
+	}
+	if projY := h.ProjectionY(WithBinRange(1, 4)); projY.BinContent(2) != 2. || projY.BinContent(3) != 1. || projY.Sum() != 6. {
+		t.Fatalf("Unexpected restricted y projection: sum %v", projY.Sum())
+	}
+
+	// Slices and profiles
+	if slice := h.SliceX(3); slice.BinContent(3) != 1. || slice.Sum() != 1. {
+		t.Fatalf("Unexpected x slice: sum %v", slice.Sum())
+	}
+	if slice := h.SliceY(4); slice.BinContent(2) != 2. || slice.Sum() != 2. {
+		t.Fatalf("Unexpected y slice: sum %v", slice.Sum())
+	}
+	prof := h.ProfileX()
+	if prof.BinMean(1) != 2.5 || prof.BinEntries(1) != 3. || !math.IsNaN(prof.BinMean(2)) || prof.BinMean(4) != 7.5 || prof.BinEntries(3) != 0. {
+		t.Fatalf("Unexpected x profile: %v, %v", prof.BinMean(1), prof.BinMean(4))
+	}
+	if prof := h.ProfileY(); prof.BinMean(1) != 0.5 || prof.BinMean(2) != 3.5 || prof.BinEntries(2) != 2. {
+		t.Fatalf("Unexpected y profile: %v, %v", prof.BinMean(1), prof.BinMean(2))
+	}
+
+	h.SetBinContent(1, 1, 1.)
+	h.SetBinVariance(1, 1, 0.5)
+	h.Scale(2.)
+	if h.Sum() != 10. || h.BinContent(1, 1) != 2. || h.BinVariance(1, 1) != 2. {
+		t.Fatalf("Unexpected histogram after scaling: sum %v", h.Sum())
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := h.Print(buf); err != nil || buf.Len() == 0 {
+		t.Fatalf("Failed to print histogram: %v", err)
+	}
+}
+
+func TestH2Heatmap(t *testing.T) {
+
+	h := NewH2D(4, 0., 4., 2, 0., 2.)
+	h.Fill(0.5, 0.5, 4.)
+	h.Fill(1.5, 0.5, 1.)
+	h.Fill(3.5, 1.5, 2.)
+	h.Fill(-1., 0.5, 100.)
+
+	buf := bytes.NewBuffer(nil)
+	if err := h.PrintHeatmap(buf); err != nil {
+		t.Fatalf("Failed to print heatmap: %v", err)
+	}
+	want := "1-2 │      ▒▒│\n" +
+		"0-1 │██░░    │\n" +
+		"    └────────┘\n" +
+		"     0      4\n" +
+		"\n" +
+		"Legend:  ░ ≤ 1.00  ▒ ≤ 2.00  ▓ ≤ 3.00  █ ≤ 4.00\n"
+	if buf.String() != want {
+		t.Fatalf("Unexpected heatmap, want:\n%s\nhave:\n%s", want, buf.String())
+	}
+
+	buf.Reset()
+	if err := h.PrintHeatmap(buf, WithShadeRamp(".#"), WithCountFormatter(func(y float64) string {
+		return fmt.Sprint(y)
+	})); err != nil {
+		t.Fatalf("Failed to print heatmap: %v", err)
+	}
+	if !strings.Contains(buf.String(), "│####....│") || !strings.HasSuffix(buf.String(), "Legend:  # ≤ 4\n") {
+		t.Fatalf("Unexpected heatmap with custom ramp:\n%s", buf.String())
+	}
+}
+
+func TestH1Edges(t *testing.T) {
+
+	h := NewH1Edges([]float64{0., 1., 10., 100.})
+	for _, val := range []float64{-1., 0., 0.5, 5., 50., 100., 101.} {
+		h.Fill(val)
+	}
+
+	if h.NBins() != 3 || h.XMin() != 0. || h.XMax() != 100. || h.BinWidth(3) != 90. || h.BinCenter(2) != 5.5 {
+		t.Fatalf("Unexpected binning of histogram with variable bin widths")
+	}
+	for bin, expected := range []float64{1., 2., 1., 2., 1.} {
+		if h.BinContent(bin) != expected {
+			t.Fatalf("Unexpected content of bin %d: want %v, have %v", bin, expected, h.BinContent(bin))
+		}
+	}
+	for val, expected := range map[float64]int{-0.1: 0, 0.: 1, 1.: 2, 99.: 3, 100.: 3, 100.1: 4, math.NaN(): 4} {
+		if bin := h.FindBin(val); bin != expected {
+			t.Fatalf("Unexpected bin for %v: want %d, have %d", val, expected, bin)
+		}
+	}
+}
+
+func TestBinary(t *testing.T) {
+
+	h := NewH1D(10, 0., 1.)
+	for _, val := range []float64{-1., 0.05, 0.5, 0.55, 1., 2.} {
+		h.Fill(val)
+	}
+	hEdges := NewH1Edges([]time.Duration{0, time.Millisecond, time.Second})
+	hEdges.Fill(time.Microsecond, 2.)
+	hEdges.SetBinVariance(1, 4.)
+
+	buf := bytes.NewBuffer(nil)
+	if err := h.Encode(buf); err != nil {
+		t.Fatalf("Failed to encode histogram: %s", err)
+	}
+	if err := hEdges.Encode(buf); err != nil {
+		t.Fatalf("Failed to encode histogram: %s", err)
+	}
+
+	var restored H1D
+	if err := restored.Decode(buf); err != nil {
+		t.Fatalf("Failed to decode histogram: %s", err)
+	}
+	if !reflect.DeepEqual(&restored, h) {
+		t.Fatalf("Unexpected decoded histogram: %v", restored)
+	}
+	var restoredEdges H1[time.Duration]
+	if err := restoredEdges.Decode(buf); err != nil {
+		t.Fatalf("Failed to decode histogram: %s", err)
+	}
+	if !reflect.DeepEqual(&restoredEdges, hEdges) {
+		t.Fatalf("Unexpected decoded histogram: %v", restoredEdges)
+	}
+	if err := restored.Decode(buf); err != io.EOF {
+		t.Fatalf("Unexpected error at end of data: %v", err)
+	}
+
+	// Decoding into a histogram of an incompatible type must fail
+	data, _ := h.MarshalBinary()
+	if err := NewH1I(1, 0, 1).UnmarshalBinary(data); err != ErrInvalidData {
+		t.Fatalf("Unexpected error for incompatible type: %v", err)
+	}
+	if err := restored.UnmarshalBinary(data[:len(data)-1]); err != ErrInvalidData {
+		t.Fatalf("Unexpected error for truncated data: %v", err)
+	}
+
+	// Gob support
+	buf.Reset()
+	if err := gob.NewEncoder(buf).Encode(hEdges); err != nil {
+		t.Fatalf("Failed to gob-encode histogram: %s", err)
+	}
+	restoredGob := new(H1[time.Duration])
+	if err := gob.NewDecoder(buf).Decode(restoredGob); err != nil {
+		t.Fatalf("Failed to gob-decode histogram: %s", err)
+	}
+	if !reflect.DeepEqual(restoredGob, hEdges) {
+		t.Fatalf("Unexpected gob-decoded histogram: %v", restoredGob)
+	}
+}
+
+func TestMerge(t *testing.T) {
+
+	hs := make([]*H1I, 3)
+	for i := range hs {
+		hs[i] = NewH1I(5, 0, 10)
+		for j := -1; j <= 11; j += 1 + i {
+			hs[i].Fill(j)
+		}
+		hs[i].SetBinVariance(1, float64(i))
+	}
+
+	merged, err := Merge(hs...)
+	if err != nil {
+		t.Fatalf("Failed to merge histograms: %s", err)
+	}
+	if merged.NEntries() != hs[0].NEntries()+hs[1].NEntries()+hs[2].NEntries() || merged.BinVariance(1) != 3. {
+		t.Fatalf("Unexpected merged histogram: %d entries", merged.NEntries())
+	}
+	for bin := 0; bin <= merged.NBins()+1; bin++ {
+		if expected := hs[0].BinContent(bin) + hs[1].BinContent(bin) + hs[2].BinContent(bin); merged.BinContent(bin) != expected {
+			t.Fatalf("Unexpected content of merged bin %d: want %v, have %v", bin, expected, merged.BinContent(bin))
+		}
+	}
+	if merged.Sum() != float64(merged.NEntries()) || hs[0].Sum() != float64(hs[0].NEntries()) {
+		t.Fatalf("Unexpected sum of weights of merged histogram: %v", merged.Sum())
+	}
+
+	if err := hs[0].Add(NewH1I(4, 0, 10)); !errors.Is(err, numerics.ErrIncompatibleBinning) {
+		t.Fatalf("Unexpected error for different number of bins: %v", err)
+	}
+	if err := hs[0].Add(NewH1I(5, 0, 20)); !errors.Is(err, numerics.ErrIncompatibleBinning) {
+		t.Fatalf("Unexpected error for different bin edges: %v", err)
+	}
+	if _, err := Merge[int](); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected error for empty merge: %v", err)
+	}
+}
+
+func TestDivide(t *testing.T) {
+
+	num, den := NewH1D(2, 0., 2.), NewH1D(2, 0., 2.)
+	num.SetBinContent(1, 25.)
+	den.SetBinContent(1, 100.)
+	num.SetBinContent(2, 4.)
+
+	ratio := NewH1D(2, 0., 2.)
+	if err := ratio.Divide(num, den); err != nil {
+		t.Fatalf("Failed to divide histograms: %s", err)
+	}
+	if ratio.BinContent(1) != 0.25 || ratio.BinContent(2) != 0. || ratio.Sum() != 0.25 {
+		t.Fatalf("Unexpected ratio: %v, %v", ratio.BinContent(1), ratio.BinContent(2))
+	}
+	if expected := (25.*100.*100. + 100.*25.*25.) / math.Pow(100., 4); math.Abs(ratio.BinVariance(1)-expected) > 1e-15 {
+		t.Fatalf("Unexpected variance of ratio: want %v, have %v", expected, ratio.BinVariance(1))
+	}
+
+	if err := ratio.Divide(num, den, WithBinomialErrors()); err != nil {
+		t.Fatalf("Failed to divide histograms: %s", err)
+	}
+	if expected := 0.25 * 0.75 / 100.; math.Abs(ratio.BinVariance(1)-expected) > 1e-15 {
+		t.Fatalf("Unexpected binomial variance of ratio: want %v, have %v", expected, ratio.BinVariance(1))
+	}
+
+	if err := ratio.Divide(num, NewH1D(3, 0., 2.)); !errors.Is(err, numerics.ErrIncompatibleBinning) {
+		t.Fatalf("Unexpected error for incompatible binning: %v", err)
+	}
+
+	// Filling after a division must keep accumulating the propagated variances
+	ratio.Fill(0.5)
+	if expected := math.Sqrt(0.25*0.75/100. + 1.); ratio.BinContent(1) != 1.25 || math.Abs(ratio.BinError(1)-expected) > 1e-15 {
+		t.Fatalf("Unexpected bin error after division and fill: want %v, have %v", expected, ratio.BinError(1))
+	}
+}
+
+func TestSubtractMultiply(t *testing.T) {
+
+	sig, bkg := NewH1D(2, 0., 2.), NewH1D(2, 0., 2.)
+	for _, val := range []float64{0.5, 0.5, 0.5, 1.5, 1.5} {
+		sig.Fill(val)
+	}
+	bkg.Fill(0.5)
+
+	if err := sig.Subtract(bkg); err != nil {
+		t.Fatalf("Failed to subtract histograms: %s", err)
+	}
+	if sig.BinContent(1) != 2. || sig.BinVariance(1) != 4. || sig.BinVariance(2) != 2. || sig.Sum() != 4. || sig.NEntries() != 6 {
+		t.Fatalf("Unexpected histogram after subtraction: %v +- %v", sig.BinContent(1), sig.BinVariance(1))
+	}
+
+	weights := NewH1D(2, 0., 2.)
+	weights.SetBinContent(1, 0.5)
+	weights.SetBinVariance(1, 0.01)
+	weights.SetBinContent(2, 2.)
+	weights.SetBinVariance(2, 0.1)
+	if err := sig.Multiply(weights); err != nil {
+		t.Fatalf("Failed to multiply histograms: %s", err)
+	}
+	if sig.BinContent(1) != 1. || sig.BinContent(2) != 4. || sig.Sum() != 5. || sig.NEntries() != 6 {
+		t.Fatalf("Unexpected histogram after multiplication: %v, %v", sig.BinContent(1), sig.BinContent(2))
+	}
+	if expected := 4.*0.25 + 0.01*4.; math.Abs(sig.BinVariance(1)-expected) > 1e-12 {
+		t.Fatalf("Unexpected variance after multiplication: want %v, have %v", expected, sig.BinVariance(1))
+	}
+
+	if err := sig.Subtract(NewH1D(2, 0., 3.)); !errors.Is(err, numerics.ErrIncompatibleBinning) {
+		t.Fatalf("Unexpected error for incompatible binning: %v", err)
+	}
+	if err := sig.Multiply(NewH1D(3, 0., 2.)); !errors.Is(err, numerics.ErrIncompatibleBinning) {
+		t.Fatalf("Unexpected error for incompatible binning: %v", err)
+	}
+
+	// Filling after a subtraction must keep accumulating the propagated variances
+	h := NewH1D(2, 0., 2.)
+	for i := 0; i < 4; i++ {
+		h.Fill(0.5)
+	}
+	if err := h.Subtract(bkg); err != nil {
+		t.Fatalf("Failed to subtract histograms: %s", err)
+	}
+	h.Fill(0.5)
+	h.Fill(1.5)
+	if h.BinContent(1) != 4. || h.BinError(1) != math.Sqrt(6.) || h.BinError(2) != 1. {
+		t.Fatalf("Unexpected bin errors after subtraction and fill: %v, %v", h.BinError(1), h.BinError(2))
+	}
+}
+
+func TestNormalize(t *testing.T) {
+
+	h := NewH1Edges([]float64{0., 1., 3.})
+	for _, val := range []float64{-1., 0.5, 2., 2., 4.} {
+		h.Fill(val)
+	}
+
+	if integral := h.Integral(); integral != 5. {
+		t.Fatalf("Unexpected integral: %v", integral)
+	}
+	if integral := h.Integral(WithCounts()); integral != 3. {
+		t.Fatalf("Unexpected sum of bin contents: %v", integral)
+	}
+	if integral := h.Integral(WithCounts(), WithFlowBins()); integral != 5. {
+		t.Fatalf("Unexpected sum of bin contents including flow bins: %v", integral)
+	}
+
+	// Flow bins lack a finite width, i.e. cannot contribute to the width-weighted integral
+	if integral := h.Integral(WithFlowBins()); !math.IsNaN(integral) {
+		t.Fatalf("Unexpected integral including flow bins: %v", integral)
+	}
+	if err := h.Normalize(1., WithFlowBins()); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected error for normalization including flow bins: %v", err)
+	}
+
+	if err := h.Normalize(1.); err != nil {
+		t.Fatalf("Failed to normalize histogram: %s", err)
+	}
+	if h.BinContent(1) != 0.2 || h.BinContent(2) != 0.4 || math.Abs(h.Integral()-1.) > 1e-15 {
+		t.Fatalf("Unexpected normalized histogram: %v, %v", h.BinContent(1), h.BinContent(2))
+	}
+	if err := h.Normalize(1., WithCounts(), WithFlowBins()); err != nil {
+		t.Fatalf("Failed to normalize histogram: %s", err)
+	}
+	if math.Abs(h.BinContent(0)-0.2) > 1e-12 || math.Abs(h.BinContent(2)-0.4) > 1e-12 || math.Abs(h.Integral(WithCounts(), WithFlowBins())-1.) > 1e-12 {
+		t.Fatalf("Unexpected normalized histogram including flow bins: %v", h.BinContent(0))
+	}
+
+	if err := NewH1D(2, 0., 1.).Normalize(1.); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected error for empty histogram: %v", err)
+	}
+}
+
+func TestQuantile(t *testing.T) {
+
+	h := NewH1(10, 0, 100*time.Millisecond)
+	for i := 0; i < 100; i++ {
+		h.Fill(time.Duration(i)*time.Millisecond + 500*time.Microsecond)
+	}
+
+	if median := h.Median(); median != 50*time.Millisecond {
+		t.Fatalf("Unexpected median: %v", median)
+	}
+	for q, expected := range map[float64]time.Duration{0.: 0, 0.05: 5 * time.Millisecond, 0.95: 95 * time.Millisecond, 1.: 100 * time.Millisecond, 2.: 100 * time.Millisecond} {
+		if quantile := h.Quantile(q); quantile != expected {
+			t.Fatalf("Unexpected quantile %v: want %v, have %v", q, expected, quantile)
+		}
+	}
+
+	h.Fill(-time.Second, 20.)
+	h.Fill(time.Second, 20.)
+	if h.Quantile(0.1) != 0 || h.Quantile(0.9) != 100*time.Millisecond || h.Median() != 50*time.Millisecond {
+		t.Fatalf("Unexpected quantiles including flow bins: %v, %v", h.Quantile(0.1), h.Quantile(0.9))
+	}
+	if NewH1D(2, 1., 2.).Median() != 1. {
+		t.Fatalf("Unexpected median of empty histogram")
+	}
+}
+
+func TestCumulative(t *testing.T) {
+
+	h := NewH1I(3, 0, 3)
+	for _, val := range []int{-1, 0, 1, 1, 2, 5} {
+		h.Fill(val)
+	}
+
+	forward, backward := h.Cumulative(true), h.Cumulative(false)
+	for bin, expected := range []float64{1., 2., 4., 5., 6.} {
+		if forward.BinContent(bin) != expected {
+			t.Fatalf("Unexpected content of forward cumulative bin %d: want %v, have %v", bin, expected, forward.BinContent(bin))
+		}
+	}
+	for bin, expected := range []float64{6., 5., 4., 2., 1.} {
+		if backward.BinContent(bin) != expected {
+			t.Fatalf("Unexpected content of backward cumulative bin %d: want %v, have %v", bin, expected, backward.BinContent(bin))
+		}
+	}
+	if forward.NEntries() != h.NEntries() || forward.XMax() != h.XMax() || h.BinContent(2) != 2. {
+		t.Fatalf("Unexpected properties of cumulative histogram")
+	}
+}
+
+func TestSumw2(t *testing.T) {
+
+	h := NewH1D(2, 0., 2.)
+	h.Fill(0.5, 2.)
+	if h.Sumw2() || h.BinVariance(1) != 0. || h.BinError(1) != math.Sqrt(2.) {
+		t.Fatalf("Unexpected variance without sum of squared weights")
+	}
+
+	h.EnableSumw2()
+	h.Fill(0.5, 3.)
+	h.Fill(1.5, 0.5)
+	h.Fill(5., 4.)
+	if !h.Sumw2() || h.BinVariance(1) != 11. || h.BinVariance(2) != 0.25 || h.BinVariance(3) != 16. || h.BinError(2) != 0.5 {
+		t.Fatalf("Unexpected variances: %v, %v, %v", h.BinVariance(1), h.BinVariance(2), h.BinVariance(3))
+	}
+
+	h.Scale(2.)
+	if h.BinVariance(1) != 44. {
+		t.Fatalf("Unexpected variance after scaling: %v", h.BinVariance(1))
+	}
+
+	h2 := NewH2D(2, 0., 2., 2, 0., 2.)
+	h2.EnableSumw2()
+	h2.Fill(0.5, 0.5, 3.)
+	if h2.BinVariance(1, 1) != 9. || h2.ProjectionX().BinError(1) != 3. {
+		t.Fatalf("Unexpected variance of two-dimensional histogram: %v", h2.BinVariance(1, 1))
+	}
+}
+
+func TestFlowBins(t *testing.T) {
+
+	h := NewH1I(4, 0, 4)
+	h.EnableSumw2()
+	h.Fill(-1, 2.)
+	h.Fill(4)
+	h.Fill(5, 3.)
+
+	if content, variance := h.Underflow(); content != 2. || variance != 4. {
+		t.Fatalf("Unexpected underflow: %v +- %v", content, variance)
+	}
+	if content, variance := h.Overflow(); content != 3. || variance != 9. {
+		t.Fatalf("Unexpected overflow: %v +- %v", content, variance)
+	}
+}
+
+func TestReset(t *testing.T) {
+
+	h := NewH1D(4, 0., 4.)
+	h.EnableSumw2()
+	for _, val := range []float64{-1., 0.5, 3.5, 5.} {
+		h.Fill(val, 2.)
+	}
+	h.Reset()
+	if h.NEntries() != 0 || h.Sum() != 0. || h.NBins() != 4 || h.XMax() != 4. || !h.Sumw2() {
+		t.Fatalf("Unexpected histogram after reset")
+	}
+	for bin := 0; bin <= h.NBins()+1; bin++ {
+		if h.BinContent(bin) != 0. || h.BinVariance(bin) != 0. {
+			t.Fatalf("Unexpected content of bin %d after reset", bin)
+		}
+	}
+
+	h2 := NewH2I(2, 0, 2, 2, 0, 2)
+	h2.Fill(1, 1)
+	h2.Reset()
+	if h2.NEntries() != 0 || h2.Sum() != 0. || h2.BinContent(2, 2) != 0. {
+		t.Fatalf("Unexpected two-dimensional histogram after reset")
+	}
+}
+
+func TestClone(t *testing.T) {
+
+	h := NewH1Edges([]float64{0., 1., 10.})
+	h.EnableSumw2()
+	h.Fill(0.5, 2.)
+	h.Fill(20.)
+
+	clone := h.Clone()
+	if !reflect.DeepEqual(clone, h) {
+		t.Fatalf("Unexpected clone: %v", clone)
+	}
+
+	h.Scale(2.)
+	h.Fill(5.)
+	if clone.BinContent(1) != 2. || clone.BinVariance(1) != 4. || clone.BinContent(2) != 0. || clone.NEntries() != 2 || clone.Sum() != 3. {
+		t.Fatalf("Clone was modified by operations on the original histogram")
+	}
+}
+
+func TestSafeH1(t *testing.T) {
+
+	s := NewSafeH1(NewH1I(10, 0, 100))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				s.Fill(j % 100)
+				if j%100 == 0 {
+					s.Snapshot()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if s.NEntries() != 8000 || s.Sum() != 8000. {
+		t.Fatalf("Unexpected number of entries after concurrent fills: %d", s.NEntries())
+	}
+	s.View(func(h *H1I) {
+		if h.BinContent(1) != 800. {
+			t.Fatalf("Unexpected bin content after concurrent fills: %v", h.BinContent(1))
+		}
+	})
+	s.Reset()
+	if s.Snapshot().NEntries() != 0 {
+		t.Fatalf("Unexpected number of entries after reset")
+	}
+}
+
+func TestSafeH1Snapshot(t *testing.T) {
+
+	s := NewSafeH1(NewH1I(10, 0, 100))
+	s.Fill(5)
+
+	// Views remain unchanged while the histogram continues to be filled concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				s.Fill(j % 100)
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		v := s.Snapshot()
+		n := v.NEntries()
+		sum := 0.
+		v.Visit(func(_ int, _, _ int, content, _ float64) bool {
+			sum += content
+			return true
+		})
+		if sum != float64(n) || v.NEntries() != n {
+			t.Fatalf("Inconsistent view: %d entries, sum %v", n, sum)
+		}
+	}
+	wg.Wait()
+
+	v := s.Snapshot()
+	s.Reset()
+	if v.NEntries() != 4001 || v.BinContent(1) != 401. || s.NEntries() != 0 {
+		t.Fatalf("Unexpected view after reset: %d entries", v.NEntries())
+	}
+
+	// Modifications of a clone do not affect the view
+	h := v.Clone()
+	h.Fill(50)
+	if v.NEntries() != 4001 || h.NEntries() != 4002 {
+		t.Fatalf("Clone of view not independent")
+	}
+}
+
+func TestShardedH1(t *testing.T) {
+
+	s := NewShardedH1Edges([]float64{0., 1., 10., 100.}, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				s.Fill(float64(j%200), 2.)
+			}
+		}()
+	}
+	wg.Wait()
+
+	h := s.Snapshot()
+	if h.NEntries() != 8000 || h.Sum() != 16000. || !h.Sumw2() {
+		t.Fatalf("Unexpected histogram after concurrent fills: %d entries, sum %v", h.NEntries(), h.Sum())
+	}
+	for bin, expected := range []float64{0., 8 * 5 * 2., 8 * 5 * 18., 8 * 5 * 182., 8 * 5 * 198.} {
+		if h.BinContent(bin) != expected || h.BinVariance(bin) != 2.*expected {
+			t.Fatalf("Unexpected content of bin %d: want %v, have %v +- %v", bin, expected, h.BinContent(bin), h.BinVariance(bin))
+		}
+	}
+
+	s.Reset()
+	if h := s.Snapshot(); h.NEntries() != 0 || h.Sum() != 0. {
+		t.Fatalf("Unexpected histogram after reset")
+	}
+
+	// Bulk fills are equivalent to the ones of a plain histogram
+	vals := []float64{-1., 0.5, 1.5, 1.5, 3.9, 4., 7.}
+	weights := []float64{1., 2., 3., 4., 5., 6., 7.}
+	for _, options := range [][]H1Option{nil, {WithoutFlowBins()}} {
+		h, hW := NewH1D(4, 0., 4., options...), NewH1D(4, 0., 4., options...)
+		h.EnableSumw2()
+		hW.EnableSumw2()
+		h.FillN(vals)
+		hW.FillNW(vals, weights)
+		for _, v := range vals {
+			h.Fill(v)
+		}
+
+		s, sW := NewShardedH1(4, 0., 4., 2, options...), NewShardedH1(4, 0., 4., 2, options...)
+		s.FillN(vals)
+		sW.FillNW(vals, weights)
+		for _, v := range vals {
+			s.Fill(v)
+		}
+		if !reflect.DeepEqual(h, s.Snapshot()) || !reflect.DeepEqual(hW, sW.Snapshot()) {
+			t.Fatalf("Unexpected histogram after bulk fills (options: %v): %v", options, s.Snapshot())
+		}
+	}
+
+	// Values outside of the x axis are rejected if the flow bins are disabled
+	var rejected []float64
+	s = NewShardedH1Edges([]float64{0., 1., 10.}, 2, WithOutOfRangeHandler(func(val float64) {
+		rejected = append(rejected, val)
+	}))
+	s.Fill(-1.)
+	s.Fill(5.)
+	s.Fill(math.NaN())
+	if h := s.Snapshot(); h.NEntries() != 1 || h.BinContent(0) != 0. || h.BinContent(2) != 1. || len(rejected) != 2 {
+		t.Fatalf("Unexpected histogram without flow bins: %d entries, rejected %v", h.NEntries(), rejected)
+	}
+}
+
+func BenchmarkConcurrentFill(b *testing.B) {
+
+	b.Run("SafeH1", func(b *testing.B) {
+		s := NewSafeH1(NewH1D(1000, 0., 1.))
+		b.RunParallel(func(pb *testing.PB) {
+			for x := 0.; pb.Next(); x += 1e-3 {
+				s.Fill(x - math.Floor(x))
+			}
+		})
+	})
+
+	b.Run("ShardedH1", func(b *testing.B) {
+		s := NewShardedH1(1000, 0., 1., 0)
+		b.RunParallel(func(pb *testing.PB) {
+			for x := 0.; pb.Next(); x += 1e-3 {
+				s.Fill(x - math.Floor(x))
+			}
+		})
+	})
+
+	b.Run("ShardedH1FillN", func(b *testing.B) {
+		s := NewShardedH1(1000, 0., 1., 0)
+		vals := make([]float64, 100)
+		for i := range vals {
+			vals[i] = float64(i) / 100.
+		}
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				s.FillN(vals)
+			}
+		})
+	})
+}
+
+func TestFillN(t *testing.T) {
+
+	vals := []float64{-1., 0.5, 1.5, 1.5, 3.9, 4., 7.}
+	weights := []float64{1., 2., 3., 4., 5., 6., 7.}
+
+	h, hN := NewH1D(4, 0., 4.), NewH1D(4, 0., 4.)
+	hW, hNW := NewH1D(4, 0., 4.), NewH1D(4, 0., 4.)
+	hW.EnableSumw2()
+	hNW.EnableSumw2()
+	for i, val := range vals {
+		h.Fill(val)
+		hW.Fill(val, weights[i])
+	}
+	hN.FillN(vals)
+	hNW.FillNW(vals, weights)
+
+	if !reflect.DeepEqual(h, hN) {
+		t.Fatalf("Unexpected histogram after FillN: %v", hN)
+	}
+	if !reflect.DeepEqual(hW, hNW) {
+		t.Fatalf("Unexpected histogram after FillNW: %v", hNW)
+	}
+
+	s := NewSafeH1(NewH1D(4, 0., 4.))
+	s.FillN(vals)
+	s.FillNW(vals, weights)
+	if s.NEntries() != 2*len(vals) || s.Sum() != float64(len(vals))+28. {
+		t.Fatalf("Unexpected histogram after concurrency-safe batch fills")
+	}
+}
+
+func BenchmarkFill(b *testing.B) {
+
+	vals := make([]float64, 1024)
+	for i := range vals {
+		vals[i] = float64(i) / 1024.
+	}
+
+	b.Run("Fill", func(b *testing.B) {
+		h := NewH1D(100, 0., 1.)
+		for i := 0; i < b.N; i++ {
+			for _, val := range vals {
+				h.Fill(val)
+			}
+		}
+	})
+
+	b.Run("FillN", func(b *testing.B) {
+		h := NewH1D(100, 0., 1.)
+		for i := 0; i < b.N; i++ {
+			h.FillN(vals)
+		}
+	})
+}
+
+func TestFindBinEquidistant(t *testing.T) {
+
+	h := NewH1D(10000, -0.3, 0.7)
+	hInt := NewH1I(7, -3, 11)
+	if !h.equidistant || !hInt.equidistant || NewH1Edges([]float64{0, 1, 3}).equidistant {
+		t.Fatalf("Unexpected detection of equidistant bins")
+	}
+
+	// The closed-form lookup must be consistent with a binary search over the bin edges,
+	// including values exactly on the bin edges
+	for i := 0; i <= h.NBins(); i++ {
+		for _, val := range []float64{h.bins[i], math.Nextafter(h.bins[i], -1.), math.Nextafter(h.bins[i], 1.)} {
+			if bin, expected := h.FindBin(val), findBin(h.bins, val); bin != expected {
+				t.Fatalf("Unexpected bin for %v: want %d, have %d", val, expected, bin)
+			}
+		}
+	}
+	for val := -5; val <= 15; val++ {
+		if bin, expected := hInt.FindBin(val), findBin(hInt.bins, val); bin != expected {
+			t.Fatalf("Unexpected bin for %v: want %d, have %d", val, expected, bin)
+		}
+	}
+}
+
+func TestHCat(t *testing.T) {
+
+	h := NewHCat("GET", "POST", "DELETE")
+	for _, method := range []string{"POST", "GET", "GET", "PUT", "GET", "PUT"} {
+		h.Fill(method)
+	}
+	h.Fill("DELETE", 0.5)
+
+	if h.NBins() != 4 || h.NEntries() != 7 || h.Sum() != 6.5 || h.Mode() != "GET" || h.BinContent("PUT") != 2. || h.BinContent("HEAD") != 0. {
+		t.Fatalf("Unexpected categorical histogram: %v", h.Labels())
+	}
+	if labels := h.Labels(); !reflect.DeepEqual(labels, []string{"GET", "POST", "DELETE", "PUT"}) {
+		t.Fatalf("Unexpected labels: %v", labels)
+	}
+
+	h.SortByContent()
+	if labels := h.Labels(); !reflect.DeepEqual(labels, []string{"GET", "PUT", "POST", "DELETE"}) || h.BinContent("POST") != 1. {
+		t.Fatalf("Unexpected labels after sorting by content: %v", labels)
+	}
+	h.SortByLabel()
+	if labels := h.Labels(); !reflect.DeepEqual(labels, []string{"DELETE", "GET", "POST", "PUT"}) || h.BinContent("PUT") != 2. {
+		t.Fatalf("Unexpected labels after sorting by label: %v", labels)
+	}
+
+	other := NewHCat()
+	other.Fill("HEAD")
+	other.Fill("GET", 2.)
+	merged := MergeCat(h, other)
+	if merged.NBins() != 5 || merged.NEntries() != 9 || merged.Sum() != 9.5 || merged.BinContent("GET") != 5. || h.BinContent("GET") != 3. {
+		t.Fatalf("Unexpected merged categorical histogram: %v", merged.Labels())
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := merged.Print(buf); err != nil || !strings.Contains(buf.String(), "HEAD") {
+		t.Fatalf("Failed to print categorical histogram: %v", err)
+	}
+
+	// Bars must not exceed the maximum width (e.g. for negative weights)
+	neg := NewHCat()
+	neg.Fill("a", 3.)
+	neg.Fill("b", -2.)
+	buf.Reset()
+	if err := neg.Print(buf, WithMaxBarWidth(10)); err != nil {
+		t.Fatalf("Failed to print categorical histogram: %v", err)
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if n := strings.Count(line, "█"); n > 10 {
+			t.Fatalf("Unexpected bar width %d exceeding maximum:\n%s", n, buf.String())
+		}
+	}
+
+	merged.Reset()
+	if merged.NBins() != 5 || merged.Sum() != 0. || merged.BinContent("GET") != 0. {
+		t.Fatalf("Unexpected categorical histogram after reset")
+	}
+}
+
+func TestHProf(t *testing.T) {
+
+	h := NewHProf(24, 0, 24)
+	for hour := 0; hour < 24; hour++ {
+		for _, delta := range []float64{-1., 1.} {
+			h.Fill(hour, float64(100*hour)+delta)
+		}
+	}
+	h.Fill(12, 1200., 2.)
+	h.Fill(30, 1.)
+
+	if h.NEntries() != 50 || h.NBins() != 24 || h.BinEntries(13) != 4. || h.BinEntries(25) != 1. {
+		t.Fatalf("Unexpected profile histogram: %d entries", h.NEntries())
+	}
+	if h.BinMean(1) != 0. || h.BinSpread(1) != 1. || h.BinMean(13) != 1200. || math.Abs(h.BinSpread(13)-math.Sqrt(0.5)) > 1e-9 {
+		t.Fatalf("Unexpected mean / spread: %v +- %v", h.BinMean(13), h.BinSpread(13))
+	}
+	if math.Abs(h.BinError(1)-math.Sqrt(0.5)) > 1e-12 {
+		t.Fatalf("Unexpected error of mean: %v", h.BinError(1))
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := h.Print(buf); err != nil || buf.Len() == 0 {
+		t.Fatalf("Failed to print profile histogram: %v", err)
+	}
+
+	h.Reset()
+	if h.NEntries() != 0 || !math.IsNaN(h.BinMean(1)) {
+		t.Fatalf("Unexpected profile histogram after reset")
+	}
+}
+
+func TestAutoExtend(t *testing.T) {
+
+	h := NewH1D(5, 0., 10.)
+	h.EnableAutoExtend()
+	h.EnableSumw2()
+	for _, val := range []float64{1., 3., 5., 7., 9.} {
+		h.Fill(val)
+	}
+
+	h.Fill(15.)
+	if h.XMin() != 0. || h.XMax() != 20. || h.NBins() != 5 || h.NEntries() != 6 {
+		t.Fatalf("Unexpected axis after upper extension: [%v, %v]", h.XMin(), h.XMax())
+	}
+	for bin, expected := range []float64{0., 2., 2., 1., 1., 0., 0.} {
+		if h.BinContent(bin) != expected || h.BinVariance(bin) != expected {
+			t.Fatalf("Unexpected content of bin %d after upper extension: want %v, have %v", bin, expected, h.BinContent(bin))
+		}
+	}
+
+	h.FillN([]float64{-50.})
+	if h.XMin() != -60. || h.XMax() != 20. {
+		t.Fatalf("Unexpected axis after lower extension: [%v, %v]", h.XMin(), h.XMax())
+	}
+	for bin, expected := range []float64{0., 1., 0., 0., 2., 4., 0.} {
+		if h.BinContent(bin) != expected {
+			t.Fatalf("Unexpected content of bin %d after lower extension: want %v, have %v", bin, expected, h.BinContent(bin))
+		}
+	}
+
+	h.Fill(math.Inf(1))
+	h.Fill(math.NaN())
+	if _, overflow := h.Overflow(); overflow != 2. || h.XMax() != 20. || h.Sum() != float64(h.NEntries()) {
+		t.Fatalf("Unexpected handling of non-finite values: [%v, %v]", h.XMin(), h.XMax())
+	}
+
+	hInt := NewH1I(4, 0, 8)
+	hInt.EnableAutoExtend()
+	hInt.FillNW([]int{1, 7, 100}, []float64{1., 1., 1.})
+	if hInt.XMax() != 128 || hInt.BinContent(1) != 2. || hInt.BinContent(4) != 1. || !hInt.AutoExtend() {
+		t.Fatalf("Unexpected integer histogram after extension: [%v, %v]", hInt.XMin(), hInt.XMax())
+	}
+}
+
+func TestHDR(t *testing.T) {
+
+	h := NewHDR(time.Hour, 3)
+	for i := 1; i <= 10000; i++ {
+		h.Record(time.Duration(i) * time.Microsecond)
+	}
+	h.Record(2 * time.Hour)
+	h.Record(-time.Second)
+
+	if h.Count() != 10002 || h.Overflow() != 1 || h.Min() != 0 || h.Max() != 2*time.Hour {
+		t.Fatalf("Unexpected HDR histogram: count %d, min %v, max %v", h.Count(), h.Min(), h.Max())
+	}
+	for q, expected := range map[float64]time.Duration{0.5: 5 * time.Millisecond, 0.9: 9 * time.Millisecond, 0.99: 9900 * time.Microsecond} {
+		if quantile := h.Quantile(q); math.Abs(float64(quantile-expected)) > 1e-3*float64(expected) {
+			t.Fatalf("Unexpected quantile %v: want %v, have %v", q, expected, quantile)
+		}
+	}
+	if h.Quantile(1.) != 2*time.Hour || h.Quantile(0.) != 0 {
+		t.Fatalf("Unexpected extreme quantiles: %v, %v", h.Quantile(0.), h.Quantile(1.))
+	}
+
+	// Small values are represented exactly
+	small := NewHDR(time.Second, 2)
+	small.RecordN(7, 3)
+	if small.Median() != 7 || small.Mean() != 7 {
+		t.Fatalf("Unexpected median of small values: %v", small.Median())
+	}
+
+	if err := h.Merge(small); !errors.Is(err, numerics.ErrIncompatibleBinning) {
+		t.Fatalf("Unexpected error for incompatible histograms: %v", err)
+	}
+	other := NewHDR(time.Hour, 3)
+	other.RecordN(time.Minute, 10002)
+	if err := h.Merge(other); err != nil || h.Count() != 20004 || math.Abs(float64(h.Quantile(0.75)-time.Minute)) > 1e-3*float64(time.Minute) {
+		t.Fatalf("Unexpected merged HDR histogram: %v (error: %v)", h.Quantile(0.75), err)
+	}
+
+	var total uint64
+	h.VisitBuckets(func(low, width time.Duration, count uint64) {
+		if count == 0 || width <= 0 || low+width <= low {
+			t.Fatalf("Unexpected bucket [%v, %v): %d", low, low+width, count)
+		}
+		total += count
+	})
+	if total != h.Count()-h.Overflow() || h.SignificantDigits() != 3 {
+		t.Fatalf("Unexpected total count of buckets: %d", total)
+	}
+	if sum := small.Sum(); sum != 21 {
+		t.Fatalf("Unexpected sum: %v", sum)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := h.Print(buf); err != nil || !strings.Contains(buf.String(), "P99.9") {
+		t.Fatalf("Failed to print HDR histogram: %v", err)
+	}
+
+	h.Reset()
+	if h.Count() != 0 || h.Median() != 0 || h.Min() != 0 {
+		t.Fatalf("Unexpected HDR histogram after reset")
+	}
+}
+
+func TestWindow(t *testing.T) {
+
+	now := time.Now()
+	w := NewWindow(NewH1D(10, 0., 10.), time.Minute, 6)
+	w.now = func() time.Time {
+		return now
+	}
+	w.Reset()
+
+	// Fill one value every 10 seconds, i.e. one per slot
+	for i := 0; i < 6; i++ {
+		w.Fill(float64(i))
+		now = now.Add(10 * time.Second)
+	}
+	if h := w.Merge(); h.NEntries() != 5 || h.BinContent(1) != 0. || h.BinContent(6) != 1. {
+		t.Fatalf("Unexpected windowed histogram: %d entries", h.NEntries())
+	}
+
+	w.Fill(9., 2.)
+	w.Rotate()
+	w.Fill(9.)
+	if h := w.Merge(); h.NEntries() != 6 || h.Sum() != 7. || h.BinContent(10) != 3. || h.BinContent(2) != 0. {
+		t.Fatalf("Unexpected windowed histogram after rotation: %d entries", h.NEntries())
+	}
+
+	now = now.Add(time.Hour)
+	if h := w.Merge(); h.NEntries() != 0 || h.Sum() != 0. || h.NBins() != 10 {
+		t.Fatalf("Unexpected windowed histogram after expiry: %d entries", h.NEntries())
+	}
+}
+
+func TestDecay(t *testing.T) {
+
+	now := time.Now()
+	h := NewH1D(10, 0., 10.)
+	h.now = func() time.Time {
+		return now
+	}
+	h.EnableSumw2()
+	h.EnableDecay(time.Minute)
+
+	h.Fill(1.5, 4.)
+	now = now.Add(time.Minute)
+	h.Fill(2.5, 4.)
+	now = now.Add(2 * time.Minute)
+	h.FillN([]float64{2.5})
+
+	if h.NEntries() != 3 || math.Abs(h.Sum()-2.5) > 1e-12 {
+		t.Fatalf("Unexpected decayed histogram: %d entries, sum %v", h.NEntries(), h.Sum())
+	}
+	if math.Abs(h.BinContent(2)-0.5) > 1e-12 || math.Abs(h.BinContent(3)-2.) > 1e-12 {
+		t.Fatalf("Unexpected decayed bin contents: %v / %v", h.BinContent(2), h.BinContent(3))
+	}
+	if math.Abs(h.BinVariance(2)-1./4.) > 1e-12 {
+		t.Fatalf("Unexpected decayed bin variance: %v", h.BinVariance(2))
+	}
+
+	h.Decay(time.Minute)
+	if math.Abs(h.Sum()-1.25) > 1e-12 {
+		t.Fatalf("Unexpected sum after explicit decay: %v", h.Sum())
+	}
+	if h.HalfLife() != time.Minute || NewH1D(1, 0., 1.).HalfLife() != 0 {
+		t.Fatalf("Unexpected half-life")
+	}
+}
+
+func TestPrintOptions(t *testing.T) {
+
+	h := NewH1D(4, 0., 4.)
+	h.Fill(0.5, 3.)
+	h.Fill(2.5)
+
+	buf := bytes.NewBuffer(nil)
+	if err := h.Print(buf,
+		WithMaxBarWidth(10),
+		WithAbsoluteScale(),
+		WithoutEmptyBins(),
+		WithBinLabelFormatter(func(low, high float64) string {
+			return fmt.Sprintf("[%v,%v)", low, high)
+		}),
+		WithCountFormatter(func(y float64) string {
+			return fmt.Sprintf("n=%v", y)
+		}),
+	); err != nil {
+		t.Fatalf("Failed to print histogram: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Unexpected number of lines, want 3, have %d:\n%s", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[1], "[0,1)") || !strings.Contains(lines[1], strings.Repeat("█", 10)) || !strings.HasSuffix(lines[1], "n=3") {
+		t.Fatalf("Unexpected first bin: %s", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "[2,3)") || strings.Contains(lines[2], strings.Repeat("█", 4)) || !strings.HasSuffix(lines[2], "n=1") {
+		t.Fatalf("Unexpected second bin: %s", lines[2])
+	}
+
+	buf.Reset()
+	if err := h.Print(buf); err != nil || !strings.Contains(buf.String(), strings.Repeat("█", 75)) {
+		t.Fatalf("Unexpected default rendering: %v", err)
+	}
+
+	// Uncertainties are printed if the bin variances are tracked
+	buf.Reset()
+	h.EnableSumw2()
+	h.Fill(0.5, 2.)
+	if err := h.Print(buf, WithoutEmptyBins()); err != nil {
+		t.Fatalf("Failed to print histogram: %v", err)
+	}
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.HasSuffix(lines[1], "5.00 ± 2.65") || !strings.HasSuffix(lines[2], "1.00 ± 1.00") {
+		t.Fatalf("Unexpected rendering of uncertainties:\n%s", buf.String())
+	}
+}
+
+func TestPrintColors(t *testing.T) {
+
+	h := NewH1D(3, 0., 3.)
+	h.Fill(-1.)
+	h.Fill(0.5)
+	h.Fill(1.5, 3.)
+	h.Fill(2.5, 2.)
+
+	plain, colored := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	if err := h.Print(plain, WithUnderOverflow()); err != nil {
+		t.Fatalf("Failed to print histogram: %v", err)
+	}
+	if err := h.Print(colored, WithUnderOverflow(), WithForcedANSIColors()); err != nil {
+		t.Fatalf("Failed to print histogram: %v", err)
+	}
+
+	// Removing the ANSI codes restores the plain (aligned) output
+	lines := strings.Split(strings.TrimSpace(colored.String()), "\n")
+	if len(lines) != 6 || !strings.HasPrefix(lines[1], ansiDim+"<0"+ansiReset) || !strings.HasPrefix(lines[3], ansiBold+"1-2"+ansiReset) ||
+		!strings.Contains(lines[2], ansiGreen+"█") || !strings.Contains(lines[3], ansiRed+"█") || !strings.Contains(lines[4], ansiYellow+"█") {
+		t.Fatalf("Unexpected colored output:\n%q", colored.String())
+	}
+	stripped := colored.String()
+	for _, code := range []string{ansiReset, ansiBold, ansiDim, ansiRed, ansiGreen, ansiYellow} {
+		stripped = strings.ReplaceAll(stripped, code, "")
+	}
+	if stripped != plain.String() {
+		t.Fatalf("Unexpected colored output, want (without colors):\n%s\nhave:\n%s", plain.String(), stripped)
+	}
+	if !strings.Contains(plain.String(), ">3") {
+		t.Fatalf("Missing overflow bin:\n%s", plain.String())
+	}
+
+	// Colors are disabled for writers that are not a terminal
+	buf := bytes.NewBuffer(nil)
+	if err := h.Print(buf, WithANSIColors()); err != nil || strings.Contains(buf.String(), "\x1b") {
+		t.Fatalf("Unexpected colored output for non-terminal writer: %v", err)
+	}
+}
+
+func TestPrintDuration(t *testing.T) {
+
+	for _, c := range []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{999, "999ns"},
+		{1234567, "1.235ms"},
+		{-1234567, "-1.235ms"},
+		{1500 * time.Millisecond, "1.5s"},
+		{time.Hour + 2*time.Minute + 3456*time.Millisecond, "1h2m3s"},
+	} {
+		if have := fmtDuration(c.d); have != c.want {
+			t.Fatalf("Unexpected duration format for %d, want %s, have %s", c.d, c.want, have)
+		}
+	}
+
+	h := NewH1(5, time.Millisecond, 2*time.Millisecond)
+	h.Fill(1100 * time.Microsecond)
+
+	buf := bytes.NewBuffer(nil)
+	if err := h.Print(buf); err != nil || !strings.Contains(buf.String(), "1ms-1.2ms") || !strings.Contains(buf.String(), "Mode: 1.1ms") {
+		t.Fatalf("Unexpected duration histogram rendering (%v):\n%s", err, buf.String())
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+
+	h := NewH1D(20, 0., 10.)
+	for i := 0; i < 100; i++ {
+		h.Fill(float64(i%10) + 0.5)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := h.RenderSVG(buf, WithSize(800, 300), WithTitle("Latency <p99>"), WithAxisLabels("x", "count"), WithColor("red")); err != nil {
+		t.Fatalf("Failed to render histogram: %v", err)
+	}
+
+	// Ensure that the output is well-formed XML and contains all elements
+	var nRects, nLines, nTexts int
+	dec := xml.NewDecoder(buf)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to parse SVG: %v", err)
+		}
+		if el, ok := tok.(xml.StartElement); ok {
+			switch el.Name.Local {
+			case "rect":
+				nRects++
+			case "line":
+				nLines++
+			case "text":
+				nTexts++
+			}
+		}
+	}
+
+	// Background + bars, error bars for filled bins + axes + x / y ticks, title + axis
+	// labels + tick labels
+	if nRects != 21 || nLines != 10+2+11+6 || nTexts != 3+11+6 {
+		t.Fatalf("Unexpected number of SVG elements: %d rects, %d lines, %d texts", nRects, nLines, nTexts)
+	}
+
+	buf.Reset()
+	if err := h.RenderSVG(buf, WithoutErrorBars()); err != nil || strings.Contains(buf.String(), "Latency") {
+		t.Fatalf("Unexpected rendering without error bars: %v", err)
+	}
+}
+
+func TestRenderPNG(t *testing.T) {
+
+	h := NewH1D(10, 0., 10.)
+	for i := 0; i < 100; i++ {
+		h.Fill(float64(i % 7))
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := h.RenderPNG(buf, 200, 100); err != nil {
+		t.Fatalf("Failed to render histogram: %v", err)
+	}
+	img, err := png.Decode(buf)
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 200 || b.Dy() != 100 {
+		t.Fatalf("Unexpected image size: %v", b)
+	}
+
+	// Ensure that the first bin (filled) and the last bin (empty) are rendered as expected
+	if r, g, b, _ := img.At(15, 80).RGBA(); r == g && g == b {
+		t.Fatalf("Expected bar color in first bin")
+	}
+	if r, g, b, _ := img.At(185, 50).RGBA(); r != 0xffff || g != 0xffff || b != 0xffff {
+		t.Fatalf("Expected background color in last bin")
+	}
+
+	buf.Reset()
+	if err := h.RenderPNG(buf, 20, 100); !errors.Is(err, numerics.ErrDomain) || buf.Len() != 0 {
+		t.Fatalf("Unexpected result for too small image size: %v", err)
+	}
+}
+
+func TestWriteData(t *testing.T) {
+
+	h := NewH1(2, time.Duration(0), 2*time.Second)
+	h.Fill(500*time.Millisecond, 4.)
+	h.Fill(time.Minute)
+
+	buf := bytes.NewBuffer(nil)
+	if err := h.WriteData(buf, FormatGnuplot); err != nil {
+		t.Fatalf("Failed to write histogram data: %v", err)
+	}
+	if want := "# binLow binHigh content error\n0 1e+09 4 2\n1e+09 2e+09 0 0\n"; buf.String() != want {
+		t.Fatalf("Unexpected gnuplot data, want:\n%s\nhave:\n%s", want, buf.String())
+	}
+
+	buf.Reset()
+	if err := h.WriteData(buf, FormatTSV); err != nil || !strings.HasPrefix(buf.String(), "binLow\tbinHigh\tcontent\terror\n0\t1e+09\t4\t2\n") {
+		t.Fatalf("Unexpected TSV data (%v):\n%s", err, buf.String())
+	}
+
+	if err := h.WriteData(buf, Format(42)); !errors.Is(err, ErrUnknownFormat) {
+		t.Fatalf("Expected ErrUnknownFormat, have %v", err)
+	}
+}
+
+func TestCSV(t *testing.T) {
+
+	h := NewH1Edges([]int{-5, 0, 10, 100})
+	h.EnableSumw2()
+	for _, val := range []int{-10, -1, 5, 5, 50, 1000} {
+		h.Fill(val, 2.)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := h.ToCSV(buf); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "# entries: 6\n# sum: 12\n# sumw2: true\nbinLow,binHigh,content,variance\n-Inf,-5,2,4\n") {
+		t.Fatalf("Unexpected CSV output:\n%s", buf.String())
+	}
+
+	var res H1I
+	if err := res.FromCSV(buf); err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	if !reflect.DeepEqual(h, &res) {
+		t.Fatalf("Unexpected histogram after CSV round trip, want %+v, have %+v", h, res)
+	}
+
+	hd := NewH1D(4, 0., 1.)
+	hd.Fill(0.3)
+	buf.Reset()
+	if err := hd.ToCSV(buf); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	var resD H1D
+	if err := resD.FromCSV(buf); err != nil || !reflect.DeepEqual(hd, &resD) || !resD.equidistant {
+		t.Fatalf("Unexpected histogram after CSV round trip (%v)", err)
+	}
+
+	for _, data := range []string{
+		"",
+		"# entries: x\n",
+		"# entries: 1\n# sum: 1\n# sumw2: false\nbinLow,binHigh,content,variance\n-Inf,0,0,0\n",
+		"# entries: 1\n# sum: 1\n# sumw2: false\nbinLow,binHigh,content,variance\n-Inf,1,0,0\n1,0,1,0\n0,+Inf,0,0\n",
+		"# entries: 1\n# sum: 1\n# sumw2: false\nbinLow,binHigh,content,variance\n-Inf,0,0,0\n0,1,x,0\n1,+Inf,0,0\n",
+	} {
+		if err := resD.FromCSV(strings.NewReader(data)); !errors.Is(err, ErrInvalidData) {
+			t.Fatalf("Expected ErrInvalidData for %q, have %v", data, err)
+		}
+	}
+}
+
+func TestNamedTypeRoundTrip(t *testing.T) {
+
+	type celsius float64
+	type offset int32
+
+	hc := NewH1Edges([]celsius{0.5, 1.25, 2.75})
+	hc.Fill(1., 2.)
+	ho := NewH1Edges([]offset{-20, -5, 10})
+	ho.Fill(-7)
+
+	// Binary encoding
+	var resC H1[celsius]
+	if data, err := hc.MarshalBinary(); err != nil {
+		t.Fatalf("Failed to encode histogram: %s", err)
+	} else if err := resC.UnmarshalBinary(data); err != nil || !reflect.DeepEqual(hc, &resC) {
+		t.Fatalf("Unexpected histogram after binary round trip (%v): %v", err, resC.bins)
+	}
+	var resO H1[offset]
+	if data, err := ho.MarshalBinary(); err != nil {
+		t.Fatalf("Failed to encode histogram: %s", err)
+	} else if err := resO.UnmarshalBinary(data); err != nil || !reflect.DeepEqual(ho, &resO) {
+		t.Fatalf("Unexpected histogram after binary round trip (%v): %v", err, resO.bins)
+	}
+
+	// CSV
+	buf := bytes.NewBuffer(nil)
+	resC = H1[celsius]{}
+	if err := hc.ToCSV(buf); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	if err := resC.FromCSV(buf); err != nil || !reflect.DeepEqual(hc, &resC) {
+		t.Fatalf("Unexpected histogram after CSV round trip (%v): %v", err, resC.bins)
+	}
+	buf.Reset()
+	resO = H1[offset]{}
+	if err := ho.ToCSV(buf); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	if err := resO.FromCSV(buf); err != nil || !reflect.DeepEqual(ho, &resO) {
+		t.Fatalf("Unexpected histogram after CSV round trip (%v): %v", err, resO.bins)
+	}
+
+	// Equal-frequency binning must only extend the x axis minimally
+	if h := NewH1EqualFreq([]celsius{0.1, 0.2, 0.4}, 3); h.XMax() <= 0.4 || h.XMax() > 0.41 {
+		t.Fatalf("Unexpected upper boundary for named floating point type: %v", h.XMax())
+	}
+
+	// Printing
+	buf.Reset()
+	if err := hc.Print(buf); err != nil || !strings.Contains(buf.String(), "0.5-1.25") {
+		t.Fatalf("Unexpected rendering of named floating point type (%v):\n%s", err, buf.String())
+	}
+	buf.Reset()
+	if err := ho.Print(buf); err != nil || !strings.Contains(buf.String(), "-0020--0005") {
+		t.Fatalf("Unexpected rendering of named integer type (%v):\n%s", err, buf.String())
+	}
+}
+
+func TestKSTest(t *testing.T) {
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+	h1, h2, h3 := NewH1D(50, -5., 5.), NewH1D(50, -5., 5.), NewH1D(50, -5., 5.)
+	for i := 0; i < 5000; i++ {
+		h1.Fill(rnd.NormFloat64())
+		h2.Fill(rnd.NormFloat64())
+		h3.Fill(rnd.NormFloat64() + 0.2)
+	}
+
+	stat, p, err := KSTest(h1, h2)
+	if err != nil || stat > 0.03 || p < 0.05 {
+		t.Fatalf("Unexpected KS test result for identical distributions: %v / %v (%v)", stat, p, err)
+	}
+	stat, p, err = KSTest(h1, h3)
+	if err != nil || stat < 0.05 || p > 1e-6 {
+		t.Fatalf("Unexpected KS test result for shifted distributions: %v / %v (%v)", stat, p, err)
+	}
+
+	if _, _, err := KSTest(h1, NewH1D(50, -5., 6.)); !errors.Is(err, numerics.ErrIncompatibleBinning) {
+		t.Fatalf("Expected ErrIncompatibleBinning, have %v", err)
+	}
+	if _, _, err := KSTest(h1, NewH1D(50, -5., 5.)); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Expected ErrDomain, have %v", err)
+	}
+}
+
+func TestChiSquareTest(t *testing.T) {
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+	h1, h2, h3 := NewH1D(20, -4., 4.), NewH1D(20, -4., 4.), NewH1D(20, -4., 4.)
+	hw1, hw2 := NewH1D(20, -4., 4.), NewH1D(20, -4., 4.)
+	hw1.EnableSumw2()
+	hw2.EnableSumw2()
+	for i := 0; i < 5000; i++ {
+		h1.Fill(rnd.NormFloat64())
+		h2.Fill(rnd.NormFloat64())
+		h3.Fill(rnd.NormFloat64() * 1.2)
+		hw1.Fill(rnd.NormFloat64(), 0.5+rnd.Float64())
+		hw2.Fill(rnd.NormFloat64(), 0.5+rnd.Float64())
+	}
+
+	chi2, ndf, p, err := ChiSquareTest(h1, h2)
+	if err != nil || ndf < 15 || ndf > 19 || p < 0.01 || chi2/float64(ndf) > 2. {
+		t.Fatalf("Unexpected chi-square test result for identical distributions: %v / %d / %v (%v)", chi2, ndf, p, err)
+	}
+	if _, _, p, err = ChiSquareTest(h1, h3); err != nil || p > 1e-6 {
+		t.Fatalf("Unexpected chi-square test result for different distributions: %v (%v)", p, err)
+	}
+	if chi2, ndf, p, err = ChiSquareTest(hw1, hw2); err != nil || p < 0.01 || chi2/float64(ndf) > 2. {
+		t.Fatalf("Unexpected chi-square test result for weighted histograms: %v / %d / %v (%v)", chi2, ndf, p, err)
+	}
+	if _, _, p, err = ChiSquareTest(hw1, h3); err != nil || p > 1e-6 {
+		t.Fatalf("Unexpected chi-square test result for different weighted histograms: %v (%v)", p, err)
+	}
+
+	gauss := func(x float64) float64 {
+		return 5000. * 0.4 * math.Exp(-x*x/2.) / math.Sqrt(2.*math.Pi)
+	}
+	if chi2, ndf, p, err = ChiSquareTestFunc(h1, gauss, 0); err != nil || ndf != 20 || p < 0.01 {
+		t.Fatalf("Unexpected one-sample chi-square test result: %v / %d / %v (%v)", chi2, ndf, p, err)
+	}
+	if _, _, p, err = ChiSquareTestFunc(h3, gauss, 0); err != nil || p > 1e-6 {
+		t.Fatalf("Unexpected one-sample chi-square test result for different distribution: %v (%v)", p, err)
+	}
+
+	if _, _, _, err := ChiSquareTest(h1, NewH1D(10, -4., 4.)); !errors.Is(err, numerics.ErrIncompatibleBinning) {
+		t.Fatalf("Expected ErrIncompatibleBinning, have %v", err)
+	}
+	if _, _, _, err := ChiSquareTestFunc(h1, gauss, 20); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Expected ErrDomain, have %v", err)
+	}
+}
+
+func TestFit(t *testing.T) {
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+	h := NewH1D(40, -5., 5.)
+	for i := 0; i < 20000; i++ {
+		h.Fill(1. + 1.5*rnd.NormFloat64())
+	}
+
+	gauss := func(x float64, p []float64) float64 {
+		return p[0] * math.Exp(-0.5*(x-p[1])*(x-p[1])/(p[2]*p[2]))
+	}
+	res, err := h.Fit(gauss, []float64{1000., 0., 1.})
+	if err != nil {
+		t.Fatalf("Unexpected error fitting histogram: %s", err)
+	}
+	if len(res.Params) != 3 || len(res.Errors) != 3 || res.NDF < 30 {
+		t.Fatalf("Unexpected fit result: %+v", res)
+	}
+	if math.Abs(res.Params[1]-1.) > 4.*res.Errors[1] || math.Abs(math.Abs(res.Params[2])-1.5) > 4.*res.Errors[2] {
+		t.Fatalf("Unexpected fit parameters: %v ± %v", res.Params, res.Errors)
+	}
+	if res.Errors[1] > 0.03 || res.Chi2NDF() > 2. {
+		t.Fatalf("Unexpected fit quality: errors %v, chi2/ndf %v", res.Errors, res.Chi2NDF())
+	}
+
+	gaussFit, err := h.FitGaussian()
+	if err != nil {
+		t.Fatalf("Unexpected error fitting Gaussian: %s", err)
+	}
+	if math.Abs(gaussFit.Mean-res.Params[1]) > 1e-6 || math.Abs(gaussFit.Sigma-math.Abs(res.Params[2])) > 1e-6 || math.Abs(gaussFit.Amplitude-res.Params[0]) > 1e-3 {
+		t.Fatalf("Unexpected Gaussian fit: %+v", gaussFit)
+	}
+	if math.Abs(gaussFit.FWHM()-2.3548200450309493*gaussFit.Sigma) > 1e-12 || math.Abs(gaussFit.Resolution()-gaussFit.Sigma/gaussFit.Mean) > 1e-12 {
+		t.Fatalf("Unexpected FWHM / resolution: %v / %v", gaussFit.FWHM(), gaussFit.Resolution())
+	}
+	if math.Abs(h.Mean()-1.) > 0.05 || math.Abs(h.StdDev()-1.5) > 0.05 {
+		t.Fatalf("Unexpected mean / standard deviation: %v / %v", h.Mean(), h.StdDev())
+	}
+
+	// Linear model, exactly solvable
+	hLin := NewH1D(10, 0., 10.)
+	for i := 1; i <= 10; i++ {
+		hLin.SetBinContent(i, 2.+3.*hLin.BinCenter(i))
+	}
+	res, err = hLin.Fit(func(x float64, p []float64) float64 {
+		return p[0] + p[1]*x
+	}, []float64{0., 0.})
+	if err != nil || math.Abs(res.Params[0]-2.) > 1e-6 || math.Abs(res.Params[1]-3.) > 1e-6 || res.Chi2 > 1e-9 {
+		t.Fatalf("Unexpected linear fit result: %+v (%v)", res, err)
+	}
+
+	if _, err := hLin.Fit(gauss, make([]float64, 11)); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Expected ErrDomain, have %v", err)
+	}
+	if _, err := hLin.Fit(func(x float64, p []float64) float64 {
+		return p[0] + 0.*p[1]
+	}, []float64{1., 1.}); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Expected ErrDomain for unconstrained parameter, have %v", err)
+	}
+}
+
+func TestSmooth(t *testing.T) {
+
+	// Noisy parabola: smoothing reduces the deviation from the true shape
+	rnd := rand.New(rand.NewPCG(1, 2))
+	truth := func(x float64) float64 {
+		return 100. - x*x
+	}
+	h := NewH1D(50, -10., 10.)
+	for i := 1; i <= h.NBins(); i++ {
+		h.SetBinContent(i, truth(h.BinCenter(i))+5.*rnd.NormFloat64())
+	}
+	deviation := func(h *H1D) (res float64) {
+		for i := 1; i <= h.NBins(); i++ {
+			res += math.Abs(h.BinContent(i) - truth(h.BinCenter(i)))
+		}
+		return
+	}
+
+	before := deviation(h)
+	for _, c := range []struct {
+		name   string
+		smooth func(h *H1D) error
+	}{
+		{"353QH", func(h *H1D) error { return h.Smooth(1) }},
+		{"MovingAverage", func(h *H1D) error { return h.SmoothMovingAverage(5) }},
+	} {
+		hs := h.Clone()
+		if err := c.smooth(hs); err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.name, err)
+		}
+		if after := deviation(hs); after > 0.7*before {
+			t.Fatalf("%s: insufficient noise suppression: %v -> %v", c.name, before, after)
+		}
+		sum := 0.
+		for i := 0; i <= hs.NBins()+1; i++ {
+			sum += hs.BinContent(i)
+		}
+		if math.Abs(hs.Sum()-sum) > 1e-9 {
+			t.Fatalf("%s: inconsistent sum of weights after smoothing", c.name)
+		}
+	}
+
+	// A single spike is removed entirely by the running medians
+	spike := NewH1D(7, 0., 7.)
+	spike.SetBinContent(4, 10.)
+	if err := spike.Smooth(1); err != nil || spike.Sum() != 0. {
+		t.Fatalf("Unexpected smoothing of spike: sum %v (%v)", spike.Sum(), err)
+	}
+
+	if err := NewH1D(2, 0., 1.).Smooth(1); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Expected ErrDomain, have %v", err)
+	}
+	if err := h.SmoothMovingAverage(4); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Expected ErrDomain, have %v", err)
+	}
+}
+
+func TestVisit(t *testing.T) {
+
+	h := NewH1Edges([]int{0, 10, 20, 50})
+	h.EnableSumw2()
+	h.Fill(-5, 1.)
+	h.Fill(5, 2.)
+	h.Fill(35, 3.)
+	h.Fill(60, 4.)
+
+	var visited []int
+	h.Visit(func(bin int, lowEdge, highEdge int, content, variance float64) bool {
+		if lowEdge != h.BinLowEdge(bin) || highEdge != h.BinUpEdge(bin) ||
+			content != h.BinContent(bin) || variance != h.BinVariance(bin) {
+			t.Fatalf("Unexpected values for bin %d: [%d, %d) %v ± %v", bin, lowEdge, highEdge, content, variance)
+		}
+		visited = append(visited, bin)
+		return true
+	})
+	if !slices.Equal(visited, []int{1, 2, 3}) {
+		t.Fatalf("Unexpected visited bins: %v", visited)
+	}
+
+	// Early termination
+	visited = visited[:0]
+	h.Visit(func(bin int, _, _ int, _, _ float64) bool {
+		visited = append(visited, bin)
+		return bin < 2
+	})
+	if !slices.Equal(visited, []int{1, 2}) {
+		t.Fatalf("Unexpected visited bins after early termination: %v", visited)
+	}
+
+	var sum float64
+	h.Bins()(func(b Bin[int]) bool {
+		sum += b.Content
+		return b.HighEdge < 20
+	})
+	if sum != 2. {
+		t.Fatalf("Unexpected sum of visited bins: %v", sum)
+	}
+}
+
+func TestNewH1EqualFreq(t *testing.T) {
+
+	// Heavily skewed (log-normal) sample
+	rnd := rand.New(rand.NewPCG(3, 4))
+	sample := make([]float64, 10000)
+	for i := range sample {
+		sample[i] = math.Exp(2. * rnd.NormFloat64())
+	}
+
+	h := NewH1EqualFreq(sample, 10)
+	if h.NBins() != 10 {
+		t.Fatalf("Unexpected number of bins: %d", h.NBins())
+	}
+	h.FillN(sample)
+	for i := 1; i <= h.NBins(); i++ {
+		if h.BinContent(i) != 1000 {
+			t.Fatalf("Unexpected content of bin %d: %v", i, h.BinContent(i))
+		}
+	}
+	if under, _ := h.Underflow(); under != 0 {
+		t.Fatalf("Unexpected underflow: %v", under)
+	}
+	if over, _ := h.Overflow(); over != 0 {
+		t.Fatalf("Unexpected overflow: %v", over)
+	}
+
+	// Repeated values lead to merged bins
+	hi := NewH1EqualFreq([]int{1, 1, 1, 1, 1, 1, 2, 3}, 4)
+	if hi.NBins() != 2 || hi.XMin() != 1 || hi.XMax() != 4 {
+		t.Fatalf("Unexpected binning: %d bins in [%d, %d)", hi.NBins(), hi.XMin(), hi.XMax())
+	}
+
+	hf := NewH1EqualFreq([]float32{1, 2, 3}, 3)
+	hf.FillN([]float32{1, 2, 3})
+	if over, _ := hf.Overflow(); over != 0 || hf.BinContent(3) != 1 {
+		t.Fatalf("Unexpected overflow for float32 values: %v", over)
+	}
+}
+
+func TestFillFrom(t *testing.T) {
+
+	h := NewH1(10, 0, 10)
+	ch := make(chan int)
+	go func() {
+		for i := 0; i < 100; i++ {
+			ch <- i % 10
+		}
+		close(ch)
+	}()
+	if err := h.FillFrom(context.Background(), ch); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if h.NEntries() != 100 || h.BinContent(5) != 10 {
+		t.Fatalf("Unexpected histogram content: %d entries, %v in bin 5", h.NEntries(), h.BinContent(5))
+	}
+
+	hw := NewH1D(10, 0., 10.)
+	chw := make(chan Weighted[float64], 2)
+	chw <- Weighted[float64]{Value: 2.5, Weight: 3.}
+	chw <- Weighted[float64]{Value: 7.5, Weight: 0.5}
+	close(chw)
+	if err := hw.FillFromW(context.Background(), chw); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if hw.Sum() != 3.5 || hw.BinContent(3) != 3. {
+		t.Fatalf("Unexpected histogram content: sum %v, %v in bin 3", hw.Sum(), hw.BinContent(3))
+	}
+
+	// Cancellation of the context while waiting for values
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := h.FillFrom(ctx, make(chan int)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, have %v", err)
+	}
+}
+
+func TestFindBinAbove(t *testing.T) {
+
+	h := NewH1D(10, 0., 10.)
+	h.Fill(-1., 10.)
+	h.Fill(11., 10.)
+	for i, y := range []float64{0, 1, 3, 5, 8, 5, 3, 1, 0, 0} {
+		h.SetBinContent(i+1, y)
+	}
+
+	for _, c := range []struct {
+		threshold   float64
+		first, last int
+	}{
+		{-1., 1, 10},
+		{0., 2, 8},
+		{2., 3, 7},
+		{5., 5, 5},
+		{8., -1, -1},
+	} {
+		if first := h.FindFirstBinAbove(c.threshold); first != c.first {
+			t.Fatalf("Unexpected first bin above %v: want %d, have %d", c.threshold, c.first, first)
+		}
+		if last := h.FindLastBinAbove(c.threshold); last != c.last {
+			t.Fatalf("Unexpected last bin above %v: want %d, have %d", c.threshold, c.last, last)
+		}
+	}
+}
+
+func TestTransformAxis(t *testing.T) {
+
+	h := NewH1D(4, 0., 4e6)
+	h.EnableSumw2()
+	h.Fill(-1., 5.)
+	h.Fill(1.5e6, 2.)
+	h.Fill(3.5e6)
+
+	// Linear transformation: bytes -> MB
+	mb, err := h.TransformAxis(func(x float64) float64 {
+		return x / 1e6
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if mb.XMin() != 0. || mb.XMax() != 4. || mb.FindBin(1.5) != 2 || mb.BinContent(2) != 2. || mb.BinVariance(2) != 4. {
+		t.Fatalf("Unexpected transformed histogram: [%v, %v), %v", mb.XMin(), mb.XMax(), mb.BinContent(2))
+	}
+	if under, _ := mb.Underflow(); under != 5. || mb.Sum() != h.Sum() || mb.NEntries() != h.NEntries() {
+		t.Fatalf("Unexpected transformed totals: underflow %v, sum %v", under, mb.Sum())
+	}
+	if h.XMax() != 4e6 {
+		t.Fatalf("Original histogram modified")
+	}
+
+	// Decreasing transformation reverses the bins
+	neg, err := h.TransformAxis(func(x float64) float64 {
+		return -x
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if over, _ := neg.Overflow(); over != 5. || neg.XMin() != -4e6 || neg.BinContent(1) != 1. || neg.BinContent(3) != 2. {
+		t.Fatalf("Unexpected reversed histogram: overflow %v, [%v, %v)", over, neg.XMin(), neg.XMax())
+	}
+
+	// Non-monotone transformation
+	if _, err := h.TransformAxis(func(x float64) float64 {
+		return (x - 2e6) * (x - 2e6)
+	}); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Expected ErrDomain, have %v", err)
+	}
+}
+
+func TestDensity(t *testing.T) {
+
+	h := NewH1Edges([]float64{0., 1., 3., 7.})
+	h.EnableSumw2()
+	h.Fill(-1.)
+	h.Fill(0.5, 2.)
+	h.Fill(2.)
+	h.Fill(2.)
+	h.Fill(5.)
+
+	d := h.Density()
+	for i, want := range []float64{2., 1., 0.25} {
+		if d.BinContent(i+1) != want {
+			t.Fatalf("Unexpected density in bin %d: want %v, have %v", i+1, want, d.BinContent(i+1))
+		}
+	}
+	if d.BinVariance(2) != 0.5 {
+		t.Fatalf("Unexpected variance: %v", d.BinVariance(2))
+	}
+	if under, _ := d.Underflow(); under != 1. || d.Sum() != 4.25 {
+		t.Fatalf("Unexpected totals: underflow %v, sum %v", under, d.Sum())
+	}
+
+	// The integral of the density equals the sum of weights of the regular bins
+	if integral := d.Integral(); integral != 5. {
+		t.Fatalf("Unexpected integral: %v", integral)
+	}
+	if h.BinContent(2) != 2. {
+		t.Fatalf("Original histogram modified")
+	}
+}
+
+func TestH2Covariance(t *testing.T) {
+
+	h := NewH2D(10, 0., 10., 10, 0., 10.)
+	if !math.IsNaN(h.Covariance()) || !math.IsNaN(h.CorrelationFactor()) {
+		t.Fatalf("Expected NaN for empty histogram")
+	}
+
+	// Perfect linear relationship (ignoring the flow bins)
+	for i := 0; i < 10; i++ {
+		h.Fill(float64(i)+0.5, float64(i)+0.5)
+	}
+	h.Fill(-1., 5.)
+	if cov := h.Covariance(); math.Abs(cov-8.25) > 1e-12 {
+		t.Fatalf("Unexpected covariance: %v", cov)
+	}
+	if rho := h.CorrelationFactor(); math.Abs(rho-1.) > 1e-12 {
+		t.Fatalf("Unexpected correlation factor: %v", rho)
+	}
+
+	// Anti-correlation
+	h.Reset()
+	for i := 0; i < 10; i++ {
+		h.Fill(float64(i)+0.5, 9.5-float64(i))
+	}
+	if rho := h.CorrelationFactor(); math.Abs(rho+1.) > 1e-12 {
+		t.Fatalf("Unexpected correlation factor: %v", rho)
+	}
+
+	// Independent variables
+	h.Reset()
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			h.Fill(float64(i)+0.5, float64(j)+0.5)
+		}
+	}
+	if cov, rho := h.Covariance(), h.CorrelationFactor(); math.Abs(cov) > 1e-12 || math.Abs(rho) > 1e-12 {
+		t.Fatalf("Unexpected covariance / correlation factor: %v / %v", cov, rho)
+	}
+}
+
+func TestInterpolatedMode(t *testing.T) {
+
+	// Bin contents sampled from a parabola peaking at 2.3
+	h := NewH1D(10, 0., 5.)
+	for i := 1; i <= h.NBins(); i++ {
+		x := h.BinCenter(i)
+		h.SetBinContent(i, 100.-(x-2.3)*(x-2.3))
+	}
+	if h.Mode() != 2.25 {
+		t.Fatalf("Unexpected mode: %v", h.Mode())
+	}
+	if mode := h.InterpolatedMode(); math.Abs(mode-2.3) > 1e-12 {
+		t.Fatalf("Unexpected interpolated mode: %v", mode)
+	}
+
+	// Non-equidistant bins
+	he := NewH1Edges([]float64{0., 1., 1.5, 3., 5.})
+	for i := 1; i <= he.NBins(); i++ {
+		x := he.BinCenter(i)
+		he.SetBinContent(i, 10.-(x-1.4)*(x-1.4))
+	}
+	if mode := he.InterpolatedMode(); math.Abs(mode-1.4) > 1e-12 {
+		t.Fatalf("Unexpected interpolated mode for non-equidistant bins: %v", mode)
+	}
+
+	// Maximum at the boundary of the x axis
+	hb := NewH1D(4, 0., 4.)
+	hb.SetBinContent(1, 5.)
+	hb.SetBinContent(2, 1.)
+	if mode := hb.InterpolatedMode(); mode != 0.5 {
+		t.Fatalf("Unexpected interpolated mode at boundary: %v", mode)
+	}
+}
+
+func TestFWHM(t *testing.T) {
+
+	// Triangular distribution: linear interpolation is exact
+	h := NewH1D(10, 0., 10.)
+	for i, y := range []float64{0, 0, 2, 4, 6, 8, 6, 4, 2, 0} {
+		h.SetBinContent(i+1, y)
+	}
+	if fwhm, err := h.FWHM(); err != nil || fwhm != 4. {
+		t.Fatalf("Unexpected FWHM: %v (%v)", fwhm, err)
+	}
+
+	// Gaussian distribution
+	hg := NewH1D(200, -10., 10.)
+	for i := 1; i <= hg.NBins(); i++ {
+		x := hg.BinCenter(i) / 1.5
+		hg.SetBinContent(i, 1000.*math.Exp(-0.5*x*x))
+	}
+	if fwhm, err := hg.FWHM(); err != nil || math.Abs(fwhm-fwhmPerSigma*1.5) > 0.01 {
+		t.Fatalf("Unexpected FWHM: %v (%v)", fwhm, err)
+	}
+
+	if _, err := NewH1D(10, 0., 10.).FWHM(); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Expected ErrDomain for empty histogram, have %v", err)
+	}
+	for i := 1; i <= 3; i++ {
+		h.SetBinContent(i, 5.)
+	}
+	if _, err := h.FWHM(); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Expected ErrDomain for distribution without left crossing, have %v", err)
+	}
+}
+
+func TestInterpolateSpline(t *testing.T) {
+
+	// A natural cubic spline reproduces linear functions exactly and approximates smooth
+	// functions considerably better than linear interpolation
+	h := NewH1D(20, 0., 2.*math.Pi)
+	for i := 1; i <= h.NBins(); i++ {
+		h.SetBinContent(i, math.Sin(h.BinCenter(i)))
+	}
+	var maxLinear, maxSpline float64
+	for x := h.BinCenter(2); x < h.BinCenter(h.NBins()-1); x += 0.01 {
+		maxLinear = math.Max(maxLinear, math.Abs(h.Interpolate(x)-math.Sin(x)))
+		maxSpline = math.Max(maxSpline, math.Abs(h.InterpolateSpline(x)-math.Sin(x)))
+	}
+	if maxSpline > 1e-3 || maxSpline > maxLinear/10. {
+		t.Fatalf("Unexpected accuracy of spline interpolation: %v (linear: %v)", maxSpline, maxLinear)
+	}
+	for i := 1; i <= h.NBins(); i++ {
+		if y := h.InterpolateSpline(h.BinCenter(i)); math.Abs(y-h.BinContent(i)) > 1e-12 {
+			t.Fatalf("Spline does not pass through bin center %d: %v", i, y)
+		}
+	}
+	if h.InterpolateSpline(-1.) != h.BinContent(1) || h.InterpolateSpline(10.) != h.BinContent(h.NBins()) {
+		t.Fatalf("Unexpected extrapolation")
+	}
+
+	// The cached spline is invalidated by modifications of the histogram
+	hl := NewH1Edges([]float64{0., 1., 3., 4., 8.})
+	for i := 1; i <= hl.NBins(); i++ {
+		hl.SetBinContent(i, 2.*hl.BinCenter(i)+1.)
+	}
+	if y := hl.InterpolateSpline(4.); math.Abs(y-9.) > 1e-12 {
+		t.Fatalf("Unexpected spline interpolation of linear function: %v", y)
+	}
+	hl.Scale(2.)
+	if y := hl.InterpolateSpline(4.); math.Abs(y-18.) > 1e-12 {
+		t.Fatalf("Unexpected spline interpolation after scaling: %v", y)
+	}
+	hl.Fill(3.5, 10.)
+	if y := hl.InterpolateSpline(3.5); math.Abs(y-26.) > 1e-12 {
+		t.Fatalf("Unexpected spline interpolation after filling: %v", y)
+	}
+
+	// Concurrent readers may populate the cache simultaneously
+	safe := NewSafeH1(h)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			safe.View(func(h *H1[float64]) {
+				if y := h.InterpolateSpline(h.BinCenter(3)); math.Abs(y-h.BinContent(3)) > 1e-12 {
+					t.Errorf("Unexpected concurrent spline interpolation: %v", y)
+				}
+			})
+		}()
+	}
+	safe.Fill(1.)
+	wg.Wait()
+}
+
+func TestNewH1E(t *testing.T) {
+
+	h, err := NewH1E(10, 0., 1.)
+	if err != nil || h.NBins() != 10 || h.XMax() != 1. {
+		t.Fatalf("Unexpected histogram: %v", err)
+	}
+
+	for _, c := range []struct {
+		n          int
+		xMin, xMax float64
+	}{
+		{0, 0., 1.},
+		{-1, 0., 1.},
+		{10, 1., 1.},
+		{10, 1., 0.},
+		{10, math.NaN(), 1.},
+		{10, 0., math.Inf(1)},
+	} {
+		if _, err := NewH1E(c.n, c.xMin, c.xMax); !errors.Is(err, numerics.ErrDomain) {
+			t.Fatalf("Expected ErrDomain for %d bins in [%v, %v), have %v", c.n, c.xMin, c.xMax, err)
+		}
+	}
+
+	// Integer bins narrower than the resolution of the type
+	if _, err := NewH1E(10, 0, 5); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Expected ErrDomain for too narrow integer bins, have %v", err)
+	}
+}
+
+func TestTryFill(t *testing.T) {
+
+	h := NewH1D(10, 0., 10.)
+	if err := h.TryFill(1.5); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := h.TryFill(2.5, 2.); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, weights := range [][]float64{{1., 2.}, {math.NaN()}, {math.Inf(-1)}} {
+		if err := h.TryFill(3.5, weights...); !errors.Is(err, numerics.ErrDomain) {
+			t.Fatalf("Expected ErrDomain for weights %v, have %v", weights, err)
+		}
+	}
+	if h.NEntries() != 2 || h.Sum() != 3. || h.BinContent(4) != 0. {
+		t.Fatalf("Unexpected histogram content: %d entries, sum %v", h.NEntries(), h.Sum())
+	}
+}
+
+func TestMergeResample(t *testing.T) {
+
+	target := NewH1D(4, 0., 4.)
+	target.Fill(0.5)
+
+	// Source with half-width bins, partially outside of the target range
+	fine := NewH1D(10, -1., 4.)
+	for _, x := range []float64{-0.8, -0.2, 0.2, 1.2, 1.7, 3.9, 5.} {
+		fine.Fill(x)
+	}
+
+	// Source with coarse bins straddling the target bins, with identical binning
+	coarse := NewH1Edges([]float64{0.5, 2.5, 6.})
+	coarse.Fill(1., 4.)
+	coarse.Fill(3., 7.)
+	coarse.Fill(0., 2.)
+
+	same := NewH1D(4, 0., 4.)
+	same.Fill(2.5, 3.)
+
+	MergeResample(target, fine, coarse, same)
+
+	for i, want := range []float64{1. + 1. + 1., 2. + 2., 1. + 1. + 3., 1. + 2.} {
+		if got := target.BinContent(i + 1); math.Abs(got-want) > 1e-12 {
+			t.Fatalf("Unexpected content of bin %d: want %v, have %v", i+1, want, got)
+		}
+	}
+	if under, _ := target.Underflow(); under != 4. {
+		t.Fatalf("Unexpected underflow: %v", under)
+	}
+	if over, _ := target.Overflow(); math.Abs(over-5.) > 1e-12 {
+		t.Fatalf("Unexpected overflow: %v", over)
+	}
+	if math.Abs(target.Sum()-24.) > 1e-12 || target.NEntries() != 12 {
+		t.Fatalf("Unexpected totals: sum %v, %d entries", target.Sum(), target.NEntries())
+	}
+}
+
+func TestWeightedQuantile(t *testing.T) {
+
+	for _, c := range []struct {
+		values  []float64
+		weights []float64
+		q       float64
+		want    float64
+	}{
+		// Ties at the transition between two values yield the lower one
+		{[]float64{4, 3, 2, 1}, []float64{1, 1, 1, 1}, 0.5, 2},
+		{[]float64{1, 2, 3, 4}, []float64{1, 1, 1, 1}, 0.25, 1},
+		{[]float64{1, 2, 3, 4}, []float64{1, 1, 1, 1}, 0.26, 2},
+		{[]float64{1, 2, 3, 4}, []float64{1, 1, 1, 1}, 1., 4},
+
+		// Very unequal weights
+		{[]float64{1, 2, 3}, []float64{1, 1, 1e6}, 0.5, 3},
+		{[]float64{3, 2, 1}, []float64{1e16, 1, 1}, 0.5, 3},
+		{[]float64{1, 2, 3}, []float64{1e16, 1, 1}, 1., 3},
+		{[]float64{1, 2, 3}, []float64{1e16, 1, 1}, 0.99, 1},
+
+		// Leading / trailing values with zero weight are ignored
+		{[]float64{0, 0, 5, 6, 7}, []float64{0, 0, 1, 1, 0}, 0., 5},
+		{[]float64{0, 0, 5, 6, 7}, []float64{0, 0, 1, 1, 0}, 0.5, 5},
+		{[]float64{0, 0, 5, 6, 7}, []float64{0, 0, 1, 1, 0}, 1., 6},
+	} {
+		if got, err := WeightedQuantile(c.values, c.weights, c.q); err != nil || got != c.want {
+			t.Fatalf("Unexpected quantile %v of %v (weights %v): want %v, have %v (%v)", c.q, c.values, c.weights, c.want, got, err)
+		}
+	}
+
+	if median, err := WeightedMedian([]int{10, 20, 30}, []float64{2, 1, 2}); err != nil || median != 20 {
+		t.Fatalf("Unexpected weighted median: %v (%v)", median, err)
+	}
+
+	for _, c := range []struct {
+		values  []float64
+		weights []float64
+		q       float64
+	}{
+		{[]float64{1, 2}, []float64{1}, 0.5},
+		{[]float64{1, 2}, []float64{1, -1}, 0.5},
+		{[]float64{1, 2}, []float64{1, math.NaN()}, 0.5},
+		{[]float64{1, 2}, []float64{0, 0}, 0.5},
+		{[]float64{1, 2}, []float64{1, 1}, 1.5},
+		{nil, nil, 0.5},
+	} {
+		if _, err := WeightedQuantile(c.values, c.weights, c.q); !errors.Is(err, numerics.ErrDomain) {
+			t.Fatalf("Expected ErrDomain for %v (weights %v, q %v), have %v", c.values, c.weights, c.q, err)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+
+	h := NewH1I(10, 0, 10)
+	for i, y := range []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 0.1} {
+		h.SetBinContent(i+1, y)
+	}
+	h.Fill(-5, 100.)
+
+	if line := h.Sparkline(); line != " ▁▂▃▄▅▆▇█▁" {
+		t.Fatalf("Unexpected sparkline: %q", line)
+	}
+	if line := NewH1D(3, 0., 1.).Sparkline(); line != "   " {
+		t.Fatalf("Unexpected sparkline of empty histogram: %q", line)
+	}
+}
+
+func TestTrimming(t *testing.T) {
+
+	sample := make([]float64, 0, 1001)
+	for i := 0; i < 1000; i++ {
+		sample = append(sample, float64(i%100))
+	}
+	sample = append(sample, 1e12)
+
+	xMin, xMax, err := SuggestRange(sample, 0.01, 0.99)
+	if err != nil || xMin != 1. || xMax != 99. {
+		t.Fatalf("Unexpected suggested range: [%v, %v] (%v)", xMin, xMax, err)
+	}
+	if xMin, xMax, err := SuggestRange([]int{5, 5, 5}, 0., 1.); err != nil || xMin != 5 || xMax != 6 {
+		t.Fatalf("Unexpected suggested range for constant sample: [%v, %v] (%v)", xMin, xMax, err)
+	}
+	for _, q := range [][2]float64{{0.5, 0.5}, {-0.1, 0.5}, {0.5, 1.1}, {0.9, 0.1}} {
+		if _, _, err := SuggestRange(sample, q[0], q[1]); !errors.Is(err, numerics.ErrDomain) {
+			t.Fatalf("Expected ErrDomain for quantiles %v, have %v", q, err)
+		}
+	}
+	if _, _, err := SuggestRange([]float64{}, 0.1, 0.9); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Expected ErrDomain for empty sample, have %v", err)
+	}
+
+	// A single huge value dominates the range of the histogram, trimming recovers the bulk
+	h := NewH1D(100, 0., 1e12)
+	h.EnableSumw2()
+	h.Fill(-1.)
+	h.FillN(sample)
+	trimmed := h.Trimmed(0.01, 0.99)
+	if trimmed.NBins() != 1 || trimmed.BinContent(1) != 1000. || trimmed.Sum() != h.Sum() || trimmed.NEntries() != h.NEntries() {
+		t.Fatalf("Unexpected trimmed histogram: %d bins, sum %v", trimmed.NBins(), trimmed.Sum())
+	}
+	if under, _ := trimmed.Underflow(); under != 1. {
+		t.Fatalf("Unexpected underflow: %v", under)
+	}
+	if over, variance := trimmed.Overflow(); over != 1. || variance != 1. {
+		t.Fatalf("Unexpected overflow: %v ± %v", over, variance)
+	}
+
+	hl := NewH1D(10, 0., 10.)
+	for i, y := range []float64{1, 0, 10, 20, 30, 20, 10, 0, 0, 1} {
+		hl.SetBinContent(i+1, y)
+	}
+	trimmed = hl.Trimmed(0.05, 0.95)
+	if trimmed.XMin() != 2. || trimmed.XMax() != 7. || trimmed.BinContent(3) != 30. {
+		t.Fatalf("Unexpected trimmed histogram: [%v, %v)", trimmed.XMin(), trimmed.XMax())
+	}
+	if under, _ := trimmed.Underflow(); under != 1. {
+		t.Fatalf("Unexpected underflow: %v", under)
+	}
+}
+
+func TestStats(t *testing.T) {
+
+	h := NewH1D(10, 0., 10.)
+	if stats := h.Stats(); stats.Entries != 0 || stats.Mean != 0. || stats.Min != 0. {
+		t.Fatalf("Unexpected stats of empty histogram: %+v", stats)
+	}
+	if _, err := json.Marshal(h.Stats()); err != nil {
+		t.Fatalf("Failed to marshal stats of empty histogram: %s", err)
+	}
+
+	h.Fill(-1.)
+	for _, x := range []float64{2.5, 3.5, 3.5, 4.5, 4.5, 4.5, 5.5, 5.5, 6.5} {
+		h.Fill(x)
+	}
+
+	stats := h.Stats()
+	if stats.Entries != 10 || stats.Sum != 10. || stats.Min != 2. || stats.Max != 7. || stats.Mode != 4.5 ||
+		stats.Mean != h.Mean() || stats.StdDev != h.StdDev() || stats.Median != h.Median() || stats.P90 != h.Quantile(0.9) {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatalf("Failed to marshal stats: %s", err)
+	}
+	var decoded Summary[float64]
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != stats {
+		t.Fatalf("Unexpected decoded stats: %+v (%v)", decoded, err)
+	}
+	if !strings.Contains(string(data), `"stddev":`) || !strings.Contains(string(data), `"p99":`) {
+		t.Fatalf("Unexpected JSON representation: %s", data)
+	}
+}
+
+func TestWithoutFlowBins(t *testing.T) {
+
+	h := NewH1D(10, 0., 10., WithoutFlowBins())
+	h.Fill(-1.)
+	h.Fill(5.)
+	h.FillN([]float64{1., 11., math.NaN()})
+	h.FillNW([]float64{2., -5.}, []float64{2., 3.})
+	if h.NEntries() != 3 || h.Sum() != 4. {
+		t.Fatalf("Unexpected histogram content: %d entries, sum %v", h.NEntries(), h.Sum())
+	}
+	if under, _ := h.Underflow(); under != 0. {
+		t.Fatalf("Unexpected underflow: %v", under)
+	}
+	if over, _ := h.Overflow(); over != 0. {
+		t.Fatalf("Unexpected overflow: %v", over)
+	}
+
+	if err := h.TryFill(10.); err != nil {
+		t.Fatalf("Unexpected error for value at the upper boundary: %s", err)
+	}
+	if err := h.TryFill(10.5); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Expected ErrDomain for out-of-range value, have %v", err)
+	}
+
+	// Handler for rejected values
+	var rejected []float64
+	he := NewH1Edges([]int{0, 10, 100}, WithOutOfRangeHandler(func(val float64) {
+		rejected = append(rejected, val)
+	}))
+	he.Fill(-1)
+	he.Fill(50)
+	he.FillN([]int{100, 101, 5})
+	if err := he.TryFill(200); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Expected ErrDomain for out-of-range value, have %v", err)
+	}
+	if !slices.Equal(rejected, []float64{-1, 101, 200}) || he.NEntries() != 3 {
+		t.Fatalf("Unexpected rejected values: %v (%d entries)", rejected, he.NEntries())
+	}
+
+	// Flow bins are retained by default
+	hd := NewH1D(10, 0., 10.)
+	if err := hd.TryFill(-1.); err != nil || hd.NEntries() != 1 {
+		t.Fatalf("Unexpected rejection of value: %v", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+
+	h := NewH1D(4, 0., 4.)
+	h.EnableSumw2()
+	h.Fill(0.5)
+	h.Fill(5.)
+	prev := h.Clone()
+
+	h.Fill(0.5, 2.)
+	h.Fill(2.5)
+	h.Fill(-1.)
+
+	diff, err := h.Diff(prev)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if diff.NEntries() != 3 || diff.Sum() != 4. || diff.BinContent(1) != 2. || diff.BinVariance(1) != 4. || diff.BinContent(3) != 1. {
+		t.Fatalf("Unexpected increment: %d entries, sum %v", diff.NEntries(), diff.Sum())
+	}
+	if over, _ := diff.Overflow(); over != 0. {
+		t.Fatalf("Unexpected overflow increment: %v", over)
+	}
+
+	// Applying the increment to the previous snapshot restores the current state
+	if err := prev.Add(diff); err != nil || prev.Sum() != h.Sum() || prev.NEntries() != h.NEntries() || prev.BinContent(1) != h.BinContent(1) {
+		t.Fatalf("Unexpected histogram after applying increment: %v", err)
+	}
+
+	// Reset in the meantime
+	snapshot := h.Clone()
+	h.Reset()
+	h.Fill(1.5)
+	if diff, err := h.Diff(snapshot); err != nil || diff.NEntries() != 1 || diff.Sum() != 1. {
+		t.Fatalf("Unexpected increment after reset: %v", err)
+	}
+
+	if _, err := h.Diff(NewH1D(5, 0., 4.)); !errors.Is(err, numerics.ErrIncompatibleBinning) {
+		t.Fatalf("Expected ErrIncompatibleBinning, have %v", err)
+	}
+}
+
+func TestAllocations(t *testing.T) {
+
+	h := NewH1D(20, 0., 20.)
+	h.EnableSumw2()
+	ref := h.Clone()
+	for i := 0; i < 100; i++ {
+		h.FillW(float64(i%25), 0.5)
+		ref.Fill(float64(i%25), 0.5)
+	}
+	if h.Sum() != ref.Sum() || h.NEntries() != ref.NEntries() || h.BinVariance(3) != ref.BinVariance(3) {
+		t.Fatalf("Unexpected histogram after FillW: sum %v vs. %v", h.Sum(), ref.Sum())
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { h.FillW(3.5, 2.) }); allocs != 0 {
+		t.Fatalf("Unexpected number of allocations for FillW: %v", allocs)
+	}
+	if err := h.Print(io.Discard); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { _ = h.Print(io.Discard) }); allocs > 10 {
+		t.Fatalf("Unexpected number of allocations for Print: %v", allocs)
+	}
+}
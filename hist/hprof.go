@@ -0,0 +1,125 @@
+package hist
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"text/tabwriter"
+)
+
+// HProf denotes a profile histogram, accumulating the mean (and spread) of a second
+// variable y per bin of x
+type HProf[T Number] struct {
+	h *H1[T]
+
+	sumWY  []float64
+	sumWY2 []float64
+}
+
+// NewHProf instantiates a new profile histogram
+func NewHProf[T Number](n int, xMin, xMax T) *HProf[T] {
+	return &HProf[T]{
+		h:      NewH1(n, xMin, xMax),
+		sumWY:  make([]float64, n+2),
+		sumWY2: make([]float64, n+2),
+	}
+}
+
+// Print prints out the mean and spread of y per bin to any io.Writer
+func (h *HProf[T]) Print(w io.Writer) error {
+
+	tabw := tabwriter.NewWriter(w, 2, 2, 2, byte(' '), 0)
+
+	for i := 1; i <= h.h.nBins; i++ {
+		if h.h.binContent[i] == 0 {
+			fmt.Fprintf(tabw, "%s-%s\t\t\n", fmtValue(h.h.bins[i-1]), fmtValue(h.h.bins[i]))
+			continue
+		}
+		fmt.Fprintf(tabw, "%s-%s\t%.4g\t± %.4g\n", fmtValue(h.h.bins[i-1]), fmtValue(h.h.bins[i]), h.BinMean(i), h.BinSpread(i))
+	}
+
+	return tabw.Flush()
+}
+
+// NBins Returns the number of bins in the histogram
+func (h *HProf[T]) NBins() int {
+	return h.h.NBins()
+}
+
+// NEntries returns the number of entries in the histogram
+func (h *HProf[T]) NEntries() int {
+	return h.h.NEntries()
+}
+
+// XMin returns the lower boundary of the x axis
+func (h *HProf[T]) XMin() T {
+	return h.h.XMin()
+}
+
+// XMax returns the upper boundary of the x axis
+func (h *HProf[T]) XMax() T {
+	return h.h.XMax()
+}
+
+// BinCenter returns the center x value of a particular bin
+func (h *HProf[T]) BinCenter(bin int) float64 {
+	return h.h.BinCenter(bin)
+}
+
+// BinEntries returns the sum of weights in a particular bin
+func (h *HProf[T]) BinEntries(bin int) float64 {
+	return h.h.BinContent(bin)
+}
+
+// BinMean returns the (weighted) mean of y in a particular bin (NaN if the bin is empty)
+func (h *HProf[T]) BinMean(bin int) float64 {
+	if h.h.binContent[bin] == 0 {
+		return math.NaN()
+	}
+
+	return h.sumWY[bin] / h.h.binContent[bin]
+}
+
+// BinSpread returns the (weighted) standard deviation of y in a particular bin (NaN if
+// the bin is empty)
+func (h *HProf[T]) BinSpread(bin int) float64 {
+	mean := h.BinMean(bin)
+
+	return math.Sqrt(math.Max(0., h.sumWY2[bin]/h.h.binContent[bin]-mean*mean))
+}
+
+// BinError returns the uncertainty of the mean of y in a particular bin, i.e. the spread
+// divided by the square root of the effective number of entries
+func (h *HProf[T]) BinError(bin int) float64 {
+	nEff := h.h.binContent[bin] * h.h.binContent[bin] / h.h.binVariance[bin]
+
+	return h.BinSpread(bin) / math.Sqrt(nEff)
+}
+
+// Fill adds a value y for the value x (with an optional weight) to the histogram
+func (h *HProf[T]) Fill(x T, y float64, weight ...float64) {
+
+	if len(weight) > 1 {
+		panic("must specify no or exactly one weight")
+	}
+	w := 1.0
+	if len(weight) == 1 {
+		w = weight[0]
+	}
+
+	bin := h.h.findBin(x)
+	h.h.nEntries++
+	h.h.sumOfWeights += w
+	h.h.binContent[bin] += w
+	h.h.binVariance[bin] += w * w
+
+	h.sumWY[bin] += w * y
+	h.sumWY2[bin] += w * y * y
+}
+
+// Reset zeroes all bins while retaining the binning, allowing to reuse the histogram
+func (h *HProf[T]) Reset() {
+	h.h.Reset()
+	clear(h.sumWY)
+	clear(h.sumWY2)
+}
@@ -0,0 +1,32 @@
+package hist
+
+import (
+	"encoding/json"
+)
+
+// h1JSON denotes the JSON representation of a one-dimensional histogram
+type h1JSON[T Number] struct {
+	NEntries  int       `json:"entries"`
+	Sum       float64   `json:"sum"`
+	Mode      float64   `json:"mode"`
+	Edges     []T       `json:"edges"`
+	Content   []float64 `json:"content"`
+	Variance  []float64 `json:"variance"`
+	Underflow float64   `json:"underflow"`
+	Overflow  float64   `json:"overflow"`
+}
+
+// MarshalJSON implements json.Marshaler, representing the histogram by its bin edges
+// and the contents / variances of its regular bins (plus under- / overflow)
+func (h *H1[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h1JSON[T]{
+		NEntries:  h.nEntries,
+		Sum:       h.sumOfWeights,
+		Mode:      h.Mode(),
+		Edges:     h.bins,
+		Content:   h.binContent[1 : h.nBins+1],
+		Variance:  h.binVariance[1 : h.nBins+1],
+		Underflow: h.binContent[0],
+		Overflow:  h.binContent[h.nBins+1],
+	})
+}
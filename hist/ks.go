@@ -0,0 +1,74 @@
+package hist
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fako1024/numerics"
+)
+
+// KSTest performs a Kolmogorov-Smirnov test comparing the distributions represented by two
+// histograms with identical binning, returning the test statistic (i.e. the maximum
+// distance between their cumulative distributions) and the asymptotic p-value. Only the
+// regular bins are considered and the effective number of entries (accounting for
+// weights) is used. Note that the binning renders the test conservative, i.e. the
+// p-value is overestimated for coarse bins
+func KSTest(h1, h2 Hist1D) (stat, pValue float64, err error) {
+
+	if err := compatibleBinning(h1, h2); err != nil {
+		return math.NaN(), math.NaN(), err
+	}
+
+	sum1, nEff1 := ksTotals(h1)
+	sum2, nEff2 := ksTotals(h2)
+	if sum1 <= 0 || sum2 <= 0 {
+		return math.NaN(), math.NaN(), fmt.Errorf("%w: histogram without (positive) contents", numerics.ErrDomain)
+	}
+
+	var cdf1, cdf2 float64
+	for i := 1; i <= h1.NBins(); i++ {
+		cdf1 += h1.BinContent(i) / sum1
+		cdf2 += h2.BinContent(i) / sum2
+		stat = math.Max(stat, math.Abs(cdf1-cdf2))
+	}
+
+	return stat, numerics.KolmogorovQ(math.Sqrt(nEff1*nEff2/(nEff1+nEff2)) * stat), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// compatibleBinning checks if two histograms share the same binning (based on their range
+// and bin centers), returning an error wrapping numerics.ErrIncompatibleBinning otherwise
+func compatibleBinning(h1, h2 Hist1D) error {
+	n := h1.NBins()
+	if n != h2.NBins() || h1.XMin() != h2.XMin() || h1.XMax() != h2.XMax() {
+		return fmt.Errorf("%w: [%v, %v] (%d bins) vs. [%v, %v] (%d bins)",
+			numerics.ErrIncompatibleBinning, h1.XMin(), h1.XMax(), n, h2.XMin(), h2.XMax(), h2.NBins())
+	}
+	for i := 1; i <= n; i++ {
+		if h1.BinCenter(i) != h2.BinCenter(i) {
+			return fmt.Errorf("%w: bin %d differs", numerics.ErrIncompatibleBinning, i)
+		}
+	}
+
+	return nil
+}
+
+// ksTotals returns the sum of weights and the effective number of entries of the regular
+// bins of a histogram (assuming unit weights for bins without recorded variance)
+func ksTotals(h Hist1D) (sum, nEff float64) {
+	var sumw2 float64
+	for i := 1; i <= h.NBins(); i++ {
+		sum += h.BinContent(i)
+		if v := h.BinVariance(i); v != 0 {
+			sumw2 += v
+		} else {
+			sumw2 += math.Abs(h.BinContent(i))
+		}
+	}
+	if sumw2 > 0 {
+		nEff = sum * sum / sumw2
+	}
+
+	return
+}
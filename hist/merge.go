@@ -0,0 +1,129 @@
+package hist
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fako1024/numerics"
+)
+
+// Add adds the contents of another histogram (including variances, number of entries and
+// under- / overflow), returning an error wrapping numerics.ErrIncompatibleBinning if the
+// binning of the histograms differs
+func (h *H1[T]) Add(other *H1[T]) error {
+	if err := h.checkBinning(other); err != nil {
+		return err
+	}
+
+	h.nEntries += other.nEntries
+	h.sumOfWeights += other.sumOfWeights
+	h.spline.invalidate()
+	for i := range h.binContent {
+		h.binContent[i] += other.binContent[i]
+		if h.sumw2 {
+			h.binVariance[i] += other.effectiveVariance(i)
+		} else {
+			h.binVariance[i] += other.binVariance[i]
+		}
+	}
+
+	return nil
+}
+
+// Merge returns a new histogram containing the sum of all provided histograms (which must
+// share the same binning)
+func Merge[T Number](hs ...*H1[T]) (*H1[T], error) {
+	if len(hs) == 0 {
+		return nil, fmt.Errorf("%w: no histograms to merge", numerics.ErrDomain)
+	}
+
+	res := hs[0].Clone()
+	res.Reset()
+	for _, h := range hs {
+		if h.sumw2 {
+			res.EnableSumw2()
+		}
+	}
+	for _, h := range hs {
+		if err := res.Add(h); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// MergeResample adds the contents of histograms with arbitrary binning to the target
+// histogram, redistributing the content of each source bin onto the target bins in
+// proportion to their overlap (i.e. assuming a uniform distribution within each source
+// bin). Content outside the range of the target axis as well as the under- / overflow of
+// the sources is assigned to the under- / overflow of the target. Sources with identical
+// binning are added exactly (see Add)
+func MergeResample[T Number](target *H1[T], sources ...*H1[T]) {
+	for _, src := range sources {
+		if target.checkBinning(src) == nil {
+			_ = target.Add(src)
+			continue
+		}
+		target.resample(src)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (h *H1[T]) checkBinning(other *H1[T]) error {
+	if h.nBins != other.nBins {
+		return fmt.Errorf("%w: %d vs. %d bins", numerics.ErrIncompatibleBinning, h.nBins, other.nBins)
+	}
+	for i := range h.bins {
+		if h.bins[i] != other.bins[i] {
+			return fmt.Errorf("%w: bin edge %d differs (%v vs. %v)", numerics.ErrIncompatibleBinning, i, h.bins[i], other.bins[i])
+		}
+	}
+
+	return nil
+}
+
+// resample adds the contents of a histogram with different binning (see MergeResample)
+func (h *H1[T]) resample(src *H1[T]) {
+
+	variance := func(bin int) float64 {
+		if h.sumw2 {
+			return src.effectiveVariance(bin)
+		}
+		return src.binVariance[bin]
+	}
+	add := func(bin, srcBin int, fraction float64) {
+		h.binContent[bin] += fraction * src.binContent[srcBin]
+		h.binVariance[bin] += fraction * variance(srcBin)
+	}
+
+	add(0, 0, 1.)
+	add(h.nBins+1, src.nBins+1, 1.)
+
+	xMin, xMax := float64(h.XMin()), float64(h.XMax())
+	j := 1
+	for k := 1; k <= src.nBins; k++ {
+		lo, hi := float64(src.bins[k-1]), float64(src.bins[k])
+		width := hi - lo
+
+		if below := math.Min(hi, xMin) - lo; below > 0 {
+			add(0, k, below/width)
+		}
+		if above := hi - math.Max(lo, xMax); above > 0 {
+			add(h.nBins+1, k, above/width)
+		}
+
+		for j > 1 && float64(h.bins[j-1]) > lo {
+			j--
+		}
+		for ; j <= h.nBins && float64(h.bins[j-1]) < hi; j++ {
+			if overlap := math.Min(hi, float64(h.bins[j])) - math.Max(lo, float64(h.bins[j-1])); overlap > 0 {
+				add(j, k, overlap/width)
+			}
+		}
+	}
+
+	h.nEntries += src.nEntries
+	h.updateSumOfWeights()
+}
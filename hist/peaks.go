@@ -0,0 +1,23 @@
+package hist
+
+import (
+	"github.com/fako1024/numerics/dsp"
+)
+
+// FindPeaks locates all peaks in the bin contents of the histogram (see dsp.FindPeaks
+// for details and options). The Index of each peak denotes the respective bin number
+func (h *H1[T]) FindPeaks(options ...func(*dsp.PeakFinder)) []dsp.Peak {
+
+	xs, ys := make([]float64, h.nBins), make([]float64, h.nBins)
+	for i := 0; i < h.nBins; i++ {
+		xs[i] = h.BinCenter(i + 1)
+		ys[i] = h.BinContent(i + 1)
+	}
+
+	peaks := dsp.FindPeaks(xs, ys, options...)
+	for i := range peaks {
+		peaks[i].Index++
+	}
+
+	return peaks
+}
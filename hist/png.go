@@ -0,0 +1,76 @@
+package hist
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/fako1024/numerics"
+)
+
+const pngMargin = 10
+
+var (
+	pngBarColor  = color.RGBA{R: 0x48, G: 0x78, B: 0xa8, A: 0xff}
+	pngAxisColor = color.Black
+)
+
+// RenderPNG renders the histogram as PNG bar chart of the provided size (in pixels) to any
+// io.Writer, including axes and error bars derived from the bin variances. Since the
+// standard library does not provide any font rendering, no text labels are drawn (use
+// RenderSVG() for a labeled chart). An image size too small to accommodate the margins
+// results in an error wrapping numerics.ErrDomain
+func (h *H1[T]) RenderPNG(w io.Writer, width, height int) error {
+
+	if width <= 2*pngMargin || height <= 2*pngMargin {
+		return fmt.Errorf("%w: image size %dx%d too small for rendering", numerics.ErrDomain, width, height)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	var (
+		yMin, yMax = h.renderRange(true)
+		xMin, xMax = float64(h.XMin()), float64(h.XMax())
+		plotWidth  = float64(width - 2*pngMargin)
+		plotHeight = float64(height - 2*pngMargin)
+	)
+	px := func(x float64) int {
+		return pngMargin + int(math.Round((x-xMin)/(xMax-xMin)*plotWidth))
+	}
+	py := func(y float64) int {
+		return pngMargin + int(math.Round((yMax-y)/(yMax-yMin)*plotHeight))
+	}
+
+	// Bars (separated by a one pixel gap, if sufficiently wide) and error bars
+	bar := image.NewUniform(pngBarColor)
+	for i := 1; i <= h.nBins; i++ {
+		y := h.binContent[i]
+		x0, x1 := px(float64(h.bins[i-1])), px(float64(h.bins[i]))
+		if x1-x0 > 2 {
+			x0++
+		}
+		draw.Draw(img, image.Rect(x0, py(math.Max(y, 0.)), x1, py(math.Min(y, 0.))), bar, image.Point{}, draw.Src)
+
+		if yErr := h.BinError(i); yErr > 0 {
+			xc := (x0 + x1) / 2
+			for yPix := py(y + yErr); yPix <= py(y-yErr); yPix++ {
+				img.Set(xc, yPix, pngAxisColor)
+			}
+		}
+	}
+
+	// Axes
+	for x := pngMargin; x <= width-pngMargin; x++ {
+		img.Set(x, py(0.), pngAxisColor)
+	}
+	for y := pngMargin; y <= height-pngMargin; y++ {
+		img.Set(pngMargin, y, pngAxisColor)
+	}
+
+	return png.Encode(w, img)
+}
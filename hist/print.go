@@ -0,0 +1,273 @@
+package hist
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// defaultMaxBarWidth denotes the default bar width (in characters) of a bin containing
+// all entries, i.e. one character per percent
+const defaultMaxBarWidth = 100
+
+// PrintOption denotes a functional option for Print()
+type PrintOption func(*printSettings)
+
+type printSettings struct {
+	maxBarWidth int
+	binLabel    func(low, high float64) string
+	count       func(y float64) string
+	skipEmpty   bool
+	absolute    bool
+	shadeRamp   []rune
+	flowBins    bool
+	colors      colorMode
+}
+
+// colorMode denotes if (and when) ANSI color codes are emitted
+type colorMode int
+
+const (
+	colorsOff colorMode = iota
+	colorsAuto
+	colorsForced
+)
+
+// ANSI escape sequences used for colored output (all of identical length, using two-digit
+// parameters as e.g. in LS_COLORS, since tabwriter accounts for the length of escaped text)
+const (
+	ansiReset  = "\x1b[00m"
+	ansiBold   = "\x1b[01m"
+	ansiDim    = "\x1b[02m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+// WithMaxBarWidth sets the maximum width of the bars (in characters), e.g. to fit narrow
+// terminals (default: 100)
+func WithMaxBarWidth(width int) PrintOption {
+	return func(s *printSettings) {
+		s.maxBarWidth = max(width, 1)
+	}
+}
+
+// WithBinLabelFormatter sets a custom formatter for the label of a bin, given its lower
+// and upper edge (ignored for categorical histograms)
+func WithBinLabelFormatter(f func(low, high float64) string) PrintOption {
+	return func(s *printSettings) {
+		s.binLabel = f
+	}
+}
+
+// WithCountFormatter sets a custom formatter for the sum of weights in a bin
+func WithCountFormatter(f func(y float64) string) PrintOption {
+	return func(s *printSettings) {
+		s.count = f
+	}
+}
+
+// WithoutEmptyBins suppresses bins without any content
+func WithoutEmptyBins() PrintOption {
+	return func(s *printSettings) {
+		s.skipEmpty = true
+	}
+}
+
+// WithAbsoluteScale scales the bars relative to the maximum bin content (i.e. the
+// largest bin spans the maximum bar width) instead of the percentage of all entries
+func WithAbsoluteScale() PrintOption {
+	return func(s *printSettings) {
+		s.absolute = true
+	}
+}
+
+// WithUnderOverflow additionally prints the under- / overflow bins (ignored for
+// categorical histograms)
+func WithUnderOverflow() PrintOption {
+	return func(s *printSettings) {
+		s.flowBins = true
+	}
+}
+
+// WithANSIColors enables colored output via ANSI escape codes (bars colored by magnitude,
+// highlighted mode bin and dimmed under- / overflow bins), provided that the writer is a
+// terminal and the NO_COLOR environment variable is not set
+func WithANSIColors() PrintOption {
+	return func(s *printSettings) {
+		s.colors = colorsAuto
+	}
+}
+
+// WithForcedANSIColors enables colored output via ANSI escape codes (see WithANSIColors)
+// regardless of the writer, e.g. when piping into a pager supporting colors
+func WithForcedANSIColors() PrintOption {
+	return func(s *printSettings) {
+		s.colors = colorsForced
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newPrintSettings(options []PrintOption) printSettings {
+	opts := printSettings{
+		maxBarWidth: defaultMaxBarWidth,
+	}
+
+	// Execute functional options (if any)
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return opts
+}
+
+// barLength returns the length of the bar (in characters) of a bin, given the sum of
+// weights and the maximum bin content
+func (s printSettings) barLength(y, sumOfWeights, maxContent float64) float64 {
+	if s.absolute {
+		return y / maxContent * float64(s.maxBarWidth)
+	}
+	return y / sumOfWeights * float64(s.maxBarWidth)
+}
+
+// useColors determines if colored output is to be written to the given writer
+func (s printSettings) useColors(w io.Writer) bool {
+	switch s.colors {
+	case colorsForced:
+		return true
+	case colorsAuto:
+		return os.Getenv("NO_COLOR") == "" && isTerminal(w)
+	}
+	return false
+}
+
+// initTabWriter (re-)initializes a tabwriter for printing bins, passing through (and
+// ignoring the width of) escaped ANSI codes if colored output is enabled
+func (s printSettings) initTabWriter(tabw *tabwriter.Writer, w io.Writer, colored bool) *tabwriter.Writer {
+	if colored {
+		return tabw.Init(w, 2, 2, 2, byte(' '), tabwriter.StripEscape)
+	}
+	return tabw.Init(w, 2, 2, 2, byte(' '), 0)
+}
+
+// fmtCount formats the sum of weights in a bin
+func (s printSettings) fmtCount(y float64) string {
+	if s.count != nil {
+		return s.count(y)
+	}
+	return yfmt(y)
+}
+
+// appendCount appends the formatted sum of weights in a bin to a buffer
+func (s printSettings) appendCount(dst []byte, y float64) []byte {
+	if s.count != nil {
+		return append(dst, s.count(y)...)
+	}
+	return appendY(dst, y)
+}
+
+// printBuffer denotes reusable buffers for printing, avoiding allocations on each call
+type printBuffer struct {
+	tabw tabwriter.Writer
+	line []byte
+}
+
+var printBuffers = sync.Pool{
+	New: func() any {
+		return new(printBuffer)
+	},
+}
+
+// appendEscaped appends an ANSI code (escaped for tabwriter) if colored output is enabled.
+// Since tabwriter accounts for the escaped segments, each cell must contain the same number
+// of (equally long) codes in all rows to retain the alignment of the columns
+func appendEscaped(dst []byte, code string, colored bool) []byte {
+	if !colored {
+		return dst
+	}
+
+	return append(append(append(dst, tabwriter.Escape), code...), tabwriter.Escape)
+}
+
+// magnitudeColor returns the color of a bar given its content relative to the maximum
+func magnitudeColor(y, maxContent float64) string {
+	switch ratio := y / maxContent; {
+	case ratio > 2./3.:
+		return ansiRed
+	case ratio > 1./3.:
+		return ansiYellow
+	}
+	return ansiGreen
+}
+
+// isTerminal determines if the writer is a terminal (character device)
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// fmtValue formats a value (e.g. a bin edge) for printing, rendering durations in a
+// human-readable form (e.g. 1.235ms instead of the raw number of nanoseconds)
+func fmtValue[T Number](v T) string {
+	return string(appendFmtValue(nil, v))
+}
+
+// appendFmtValue appends a formatted value (see fmtValue) to a buffer, equivalent to the
+// verb %.4v for numbers (i.e. four significant digits for floating point values and a
+// minimum of four digits for integers) while avoiding allocations for the common types
+func appendFmtValue[T Number](dst []byte, v T) []byte {
+	if isDuration[T]() {
+		return append(dst, fmtDuration(time.Duration(v))...)
+	}
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Float64:
+		return strconv.AppendFloat(dst, float64(v), 'g', 4, 64)
+	case reflect.Float32:
+		return strconv.AppendFloat(dst, float64(v), 'g', 4, 32)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendPaddedInt(dst, int64(v))
+	}
+	return fmt.Appendf(dst, "%.4d", uint64(v))
+}
+
+// appendPaddedInt appends an integer with a minimum of four digits (i.e. %.4d)
+func appendPaddedInt(dst []byte, v int64) []byte {
+	if v < 0 {
+		dst = append(dst, '-')
+	}
+	digits := strconv.AppendUint(nil, uint64(max(v, -v)), 10)
+	for i := len(digits); i < 4; i++ {
+		dst = append(dst, '0')
+	}
+	return append(dst, digits...)
+}
+
+// fmtDuration formats a duration, rounded to four significant digits
+func fmtDuration(d time.Duration) string {
+	digits := 0
+	for v := d; v != 0; v /= 10 {
+		digits++
+	}
+
+	unit := time.Duration(1)
+	for i := 4; i < digits; i++ {
+		unit *= 10
+	}
+
+	return d.Round(unit).String()
+}
+
+func isDuration[T Number]() bool {
+	return reflect.TypeFor[T]() == reflect.TypeFor[time.Duration]()
+}
@@ -0,0 +1,120 @@
+package hist
+
+import (
+	"sync"
+)
+
+// SafeH1 denotes a wrapper around a one-dimensional histogram, allowing to safely fill /
+// read it from multiple goroutines
+type SafeH1[T Number] struct {
+	h  *H1[T]
+	mu sync.RWMutex
+
+	// shared denotes that the underlying histogram is referenced by a view, i.e. it must
+	// be copied before being modified (see Snapshot)
+	shared bool
+}
+
+// NewSafeH1 wraps a histogram for concurrent use. All subsequent access to the histogram
+// must be performed via the wrapper
+func NewSafeH1[T Number](h *H1[T]) *SafeH1[T] {
+	return &SafeH1[T]{
+		h: h,
+	}
+}
+
+// Fill adds a weight / entry to the underlying histogram
+func (s *SafeH1[T]) Fill(val T, weight ...float64) {
+	s.mu.Lock()
+	s.own()
+	s.h.Fill(val, weight...)
+	s.mu.Unlock()
+}
+
+// FillW adds an entry with the provided weight to the underlying histogram
+func (s *SafeH1[T]) FillW(val T, weight float64) {
+	s.mu.Lock()
+	s.own()
+	s.h.FillW(val, weight)
+	s.mu.Unlock()
+}
+
+// FillN adds an entry for each of the provided values to the underlying histogram
+func (s *SafeH1[T]) FillN(vals []T) {
+	s.mu.Lock()
+	s.own()
+	s.h.FillN(vals)
+	s.mu.Unlock()
+}
+
+// FillNW adds an entry for each of the provided values with the respective weight to the
+// underlying histogram
+func (s *SafeH1[T]) FillNW(vals []T, weights []float64) {
+	s.mu.Lock()
+	s.own()
+	s.h.FillNW(vals, weights)
+	s.mu.Unlock()
+}
+
+// Do executes fn with exclusive access to the underlying histogram, e.g. to reset or
+// scale it
+func (s *SafeH1[T]) Do(fn func(h *H1[T])) {
+	s.mu.Lock()
+	s.own()
+	fn(s.h)
+	s.mu.Unlock()
+}
+
+// View executes fn with shared (read-only) access to the underlying histogram
+func (s *SafeH1[T]) View(fn func(h *H1[T])) {
+	s.mu.RLock()
+	fn(s.h)
+	s.mu.RUnlock()
+}
+
+// Snapshot returns an immutable view of the current state of the underlying histogram.
+// No copy is made up front: The data is shared with the view and only copied (once) upon
+// the next modification, allowing e.g. metric scrapers to read a consistent state without
+// holding a lock for the duration of the scrape. A mutable copy can be obtained via Clone
+func (s *SafeH1[T]) Snapshot() H1View[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.shared = true
+
+	return H1View[T]{h: s.h}
+}
+
+// NEntries returns the number of entries in the underlying histogram
+func (s *SafeH1[T]) NEntries() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.h.NEntries()
+}
+
+// Sum returns the sum of weights in the underlying histogram
+func (s *SafeH1[T]) Sum() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.h.Sum()
+}
+
+// Reset resets the underlying histogram (see H1.Reset)
+func (s *SafeH1[T]) Reset() {
+	s.mu.Lock()
+	s.own()
+	s.h.Reset()
+	s.mu.Unlock()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// own ensures exclusive ownership of the underlying histogram (copying it if it is shared
+// with a view) prior to a modification. Must be called under the write lock
+func (s *SafeH1[T]) own() {
+	if s.shared {
+		s.h, s.shared = s.h.Clone(), false
+	}
+}
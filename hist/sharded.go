@@ -0,0 +1,182 @@
+package hist
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// cacheLineFloats denotes the number of (64 bit) values occupying a cache line
+const cacheLineFloats = 8
+
+// ShardedH1 denotes a one-dimensional histogram optimized for high-throughput concurrent
+// fills: Each fill is applied lock-free (via atomic operations) to one of several shards,
+// which are only merged upon reading (via Snapshot). Decay and automatic extension of the
+// x axis are not supported (since they would require modifying all shards at once)
+type ShardedH1[T Number] struct {
+	template *H1[T]
+	shards   []shard
+
+	// Shards are handed out via a pool, such that consecutive fills on the same P (i.e.
+	// typically from the same goroutine) stick to the same shard, while a new one is
+	// assigned round-robin if the pool is empty
+	pool      sync.Pool
+	nAssigned atomic.Uint32
+}
+
+// shard denotes a single set of atomically updated bin contents and variances (stored as
+// interleaved float64 bits), padded to avoid false sharing with adjacent shards
+type shard struct {
+	nEntries atomic.Int64
+	bins     []atomic.Uint64
+	_        [64]byte
+}
+
+// NewShardedH1 instantiates a new sharded one-dimensional histogram with the provided
+// number of shards (or GOMAXPROCS shards if nShards <= 0). If the under- / overflow bins
+// are disabled (see WithoutFlowBins), the handler for rejected values (if any) may be
+// called concurrently
+func NewShardedH1[T Number](n int, xMin, xMax T, nShards int, options ...H1Option) *ShardedH1[T] {
+	return newShardedH1(NewH1(n, xMin, xMax, options...), nShards)
+}
+
+// NewShardedH1Edges instantiates a new sharded one-dimensional histogram with arbitrary
+// bins (see NewH1Edges)
+func NewShardedH1Edges[T Number](edges []T, nShards int, options ...H1Option) *ShardedH1[T] {
+	return newShardedH1(NewH1Edges(edges, options...), nShards)
+}
+
+// Fill adds a weight / entry to the histogram
+func (s *ShardedH1[T]) Fill(val T, weight ...float64) {
+
+	if len(weight) > 1 {
+		panic("must specify no or exactly one weight")
+	}
+	w := 1.0
+	if len(weight) == 1 {
+		w = weight[0]
+	}
+
+	bin := s.template.findBin(val)
+	if s.template.rejectBin(bin, val) {
+		return
+	}
+
+	sh := s.acquire()
+	sh.nEntries.Add(1)
+	addFloat(&sh.bins[2*bin], w)
+	addFloat(&sh.bins[2*bin+1], w*w)
+	s.pool.Put(sh)
+}
+
+// FillN adds an entry for each of the provided values to the histogram
+func (s *ShardedH1[T]) FillN(vals []T) {
+	sh := s.acquire()
+	n := 0
+	for _, val := range vals {
+		bin := s.template.findBin(val)
+		if s.template.rejectBin(bin, val) {
+			continue
+		}
+		addFloat(&sh.bins[2*bin], 1.)
+		addFloat(&sh.bins[2*bin+1], 1.)
+		n++
+	}
+	sh.nEntries.Add(int64(n))
+	s.pool.Put(sh)
+}
+
+// FillNW adds an entry for each of the provided values with the respective weight to the
+// histogram (both slices must have the same length)
+func (s *ShardedH1[T]) FillNW(vals []T, weights []float64) {
+	if len(vals) != len(weights) {
+		panic("must specify exactly one weight per value")
+	}
+
+	sh := s.acquire()
+	n := 0
+	for i, val := range vals {
+		bin := s.template.findBin(val)
+		if s.template.rejectBin(bin, val) {
+			continue
+		}
+		addFloat(&sh.bins[2*bin], weights[i])
+		addFloat(&sh.bins[2*bin+1], weights[i]*weights[i])
+		n++
+	}
+	sh.nEntries.Add(int64(n))
+	s.pool.Put(sh)
+}
+
+// Snapshot merges all shards into a new histogram (with bin variances being tracked)
+func (s *ShardedH1[T]) Snapshot() *H1[T] {
+	res := s.template.Clone()
+	res.sumw2 = true
+
+	for i := range s.shards {
+		sh := &s.shards[i]
+		res.nEntries += int(sh.nEntries.Load())
+		for j := range res.binContent {
+			res.binContent[j] += math.Float64frombits(sh.bins[2*j].Load())
+			res.binVariance[j] += math.Float64frombits(sh.bins[2*j+1].Load())
+		}
+	}
+	res.updateSumOfWeights()
+
+	return res
+}
+
+// Reset zeroes all shards (fills performed concurrently may or may not be retained)
+func (s *ShardedH1[T]) Reset() {
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.nEntries.Store(0)
+		for j := range sh.bins {
+			sh.bins[j].Store(0)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newShardedH1[T Number](template *H1[T], nShards int) *ShardedH1[T] {
+	if nShards <= 0 {
+		nShards = runtime.GOMAXPROCS(0)
+	}
+
+	obj := &ShardedH1[T]{
+		template: template,
+		shards:   make([]shard, nShards),
+	}
+	obj.pool.New = func() any {
+		return &obj.shards[int(obj.nAssigned.Add(1)-1)%len(obj.shards)]
+	}
+
+	// Allocate the bins of all shards from a single block, each one rounded up to a
+	// multiple of the cache line size and separated by an additional (unused) cache line
+	nBins := 2 * (template.nBins + 2)
+	stride := (nBins+cacheLineFloats-1)/cacheLineFloats*cacheLineFloats + cacheLineFloats
+	block := make([]atomic.Uint64, nShards*stride)
+	for i := range obj.shards {
+		obj.shards[i].bins = block[i*stride : i*stride+nBins : i*stride+nBins]
+	}
+
+	return obj
+}
+
+// acquire obtains a shard for the calling goroutine (which must be returned to the pool
+// after use)
+func (s *ShardedH1[T]) acquire() *shard {
+	return s.pool.Get().(*shard)
+}
+
+// addFloat atomically adds a value to a float64 stored as its bits
+func addFloat(v *atomic.Uint64, delta float64) {
+	for {
+		old := v.Load()
+		if v.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package hist
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/fako1024/numerics"
+	"github.com/fako1024/numerics/dsp"
+)
+
+// Smooth smooths the contents of the regular bins in place by applying the 353QH,twice
+// algorithm (running medians of 3, 5 and 3 bins, quadratic interpolation of flat segments
+// and Hanning smoothing, repeated on the residuals) ntimes, see J. Friedman, "Data Analysis
+// Techniques for High Energy Physics", Proceedings of the CERN School of Computing (1974).
+// Bin variances are retained. Requires at least three bins
+func (h *H1[T]) Smooth(ntimes int) error {
+	if h.nBins < 3 {
+		return fmt.Errorf("%w: smoothing requires at least 3 bins, have %d", numerics.ErrDomain, h.nBins)
+	}
+
+	xs := h.binContent[1 : h.nBins+1]
+	for i := 0; i < ntimes; i++ {
+		smooth353QHTwice(xs)
+	}
+	h.updateSumOfWeights()
+
+	return nil
+}
+
+// SmoothMovingAverage smooths the contents of the regular bins in place by replacing each
+// one with the (unweighted) average of the window (of odd length) centered on it, repeating
+// the first / last bin beyond the edges of the x axis. Bin variances are retained
+func (h *H1[T]) SmoothMovingAverage(window int) error {
+	if window < 1 || window%2 == 0 {
+		return fmt.Errorf("%w: window length must be odd and positive, have %d", numerics.ErrDomain, window)
+	}
+
+	kernel := make([]float64, window)
+	for i := range kernel {
+		kernel[i] = 1. / float64(window)
+	}
+
+	xs := h.binContent[1 : h.nBins+1]
+	copy(xs, dsp.Convolve(xs, kernel, dsp.WithMode(dsp.Same), dsp.WithBoundary(dsp.Nearest)))
+	h.updateSumOfWeights()
+
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// smooth353QHTwice performs a single pass of the 353QH,twice algorithm in place (following
+// the implementation of TH1::SmoothArray() in ROOT)
+func smooth353QHTwice(xs []float64) {
+
+	n := len(xs)
+	zz := slices.Clone(xs)
+	var rr []float64
+
+	for pass := 0; pass < 2; pass++ {
+
+		// Running medians of 3, 5 and 3 bins
+		for k := 0; k < 3; k++ {
+			yy := slices.Clone(zz)
+			width, first, last := 3, 1, n-1
+			if k == 1 {
+				width, first, last = 5, 2, n-2
+			}
+			for i := first; i < last; i++ {
+				zz[i] = median(yy[i-first : i-first+width])
+			}
+
+			switch k {
+			case 0:
+				zz[0] = median([]float64{zz[1], zz[0], 3*zz[1] - 2*zz[2]})
+				zz[n-1] = median([]float64{zz[n-2], zz[n-1], 3*zz[n-2] - 2*zz[n-3]})
+			case 1:
+				zz[1] = median(yy[0:3])
+				zz[n-2] = median(yy[n-3 : n])
+			}
+		}
+
+		// Quadratic interpolation of flat segments
+		yy := slices.Clone(zz)
+		for i := 2; i < n-2; i++ {
+			if zz[i-1] != zz[i] || zz[i] != zz[i+1] {
+				continue
+			}
+			left, right := zz[i-2]-zz[i], zz[i+2]-zz[i]
+			if left*right <= 0 {
+				continue
+			}
+			j := 1
+			if math.Abs(right) > math.Abs(left) {
+				j = -1
+			}
+			yy[i] = -0.5*zz[i-2*j] + zz[i]/0.75 + zz[i+2*j]/6.
+			yy[i+j] = 0.5*(zz[i+2*j]-zz[i-2*j]) + zz[i]
+		}
+
+		// Hanning smoothing (running means with weights 1/4, 1/2, 1/4)
+		for i := 1; i < n-1; i++ {
+			zz[i] = 0.25*yy[i-1] + 0.5*yy[i] + 0.25*yy[i+1]
+		}
+		zz[0], zz[n-1] = yy[0], yy[n-1]
+
+		// Repeat the procedure on the residuals ("twice")
+		if pass == 0 {
+			rr = slices.Clone(zz)
+			for i := range zz {
+				zz[i] = xs[i] - zz[i]
+			}
+		}
+	}
+
+	// Retain non-negativity of the contents (if given)
+	nonNegative := slices.Min(xs) >= 0
+	for i := range xs {
+		xs[i] = rr[i] + zz[i]
+		if nonNegative {
+			xs[i] = max(xs[i], 0)
+		}
+	}
+}
+
+// median returns the median of a (short) slice of values without modifying it
+func median(xs []float64) float64 {
+	sorted := slices.Clone(xs)
+	slices.Sort(sorted)
+
+	return sorted[len(sorted)/2]
+}
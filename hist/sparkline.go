@@ -0,0 +1,31 @@
+package hist
+
+import (
+	"math"
+	"strings"
+)
+
+// sparkBlocks denotes the characters used for sparklines, in ascending order of height
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline compresses the regular bins of the histogram into a single line of block
+// characters (one per bin, with heights relative to the maximum bin content), e.g. for
+// embedding the shape of a distribution in log lines. Empty bins are rendered as space
+func (h *H1[T]) Sparkline() string {
+
+	maxContent := h.MaximumWeight()
+
+	var sb strings.Builder
+	for i := 1; i <= h.nBins; i++ {
+		y := h.binContent[i]
+		if !(y > 0) || !(maxContent > 0) {
+			sb.WriteRune(' ')
+			continue
+		}
+
+		level := int(math.Ceil(y/maxContent*float64(len(sparkBlocks)))) - 1
+		sb.WriteRune(sparkBlocks[min(max(level, 0), len(sparkBlocks)-1)])
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,116 @@
+package hist
+
+import (
+	"sort"
+	"sync/atomic"
+
+	"github.com/fako1024/numerics/linalg"
+)
+
+// InterpolateSpline interpolates between the bin centers via a natural cubic spline through
+// the contents of the regular bins, providing a smooth alternative to Interpolate. Outside
+// of the outermost bin centers, the content of the respective bin is returned. The spline is
+// computed on first use and cached until the histogram is modified (the cache is populated
+// atomically, i.e. concurrent read-only access, e.g. via SafeH1.View, is safe)
+func (h *H1[T]) InterpolateSpline(x float64) float64 {
+
+	if x <= h.BinCenter(1) {
+		return h.binContent[1]
+	}
+	if x >= h.BinCenter(h.nBins) {
+		return h.binContent[h.nBins]
+	}
+
+	spline := h.spline.load()
+	if spline == nil {
+		spline = h.splineCoefficients()
+		h.spline.store(spline)
+	}
+
+	// Determine the bin whose center is the lower end of the interval containing x
+	bin := sort.Search(h.nBins, func(i int) bool {
+		return h.BinCenter(i+1) > x
+	})
+
+	x0, x1 := h.BinCenter(bin), h.BinCenter(bin+1)
+	step := x1 - x0
+	a, b := (x1-x)/step, (x-x0)/step
+
+	return a*h.binContent[bin] + b*h.binContent[bin+1] +
+		((a*a*a-a)*spline[bin-1]+(b*b*b-b)*spline[bin])*step*step/6.
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// splineCache holds the second derivatives of the cubic spline (if computed). Since it is
+// populated lazily by read-only methods, all access is atomic. A nil cache (e.g. for a
+// zero-value histogram) disables caching
+type splineCache struct {
+	coeffs atomic.Pointer[[]float64]
+}
+
+func (c *splineCache) load() []float64 {
+	if c == nil {
+		return nil
+	}
+	if coeffs := c.coeffs.Load(); coeffs != nil {
+		return *coeffs
+	}
+	return nil
+}
+
+func (c *splineCache) store(coeffs []float64) {
+	if c != nil {
+		c.coeffs.Store(&coeffs)
+	}
+}
+
+// invalidate discards the cached spline (if any), avoiding an atomic store on the fill
+// path if there is nothing to discard
+func (c *splineCache) invalidate() {
+	if c != nil && c.coeffs.Load() != nil {
+		c.coeffs.Store(nil)
+	}
+}
+
+// clone returns a separate cache (sharing the immutable coefficients, if any)
+func (c *splineCache) clone() *splineCache {
+	res := new(splineCache)
+	if c != nil {
+		res.coeffs.Store(c.coeffs.Load())
+	}
+	return res
+}
+
+// splineCoefficients computes the second derivatives of the natural cubic spline through
+// the contents of the regular bins at their centers (vanishing at the outermost centers)
+func (h *H1[T]) splineCoefficients() []float64 {
+
+	res := make([]float64, h.nBins)
+	if h.nBins < 3 {
+		return res
+	}
+
+	n := h.nBins - 2
+	sub, diag, super, rhs := make([]float64, n-1), make([]float64, n), make([]float64, n-1), make([]float64, n)
+	for i := 0; i < n; i++ {
+		bin := i + 2
+		left, right := h.BinCenter(bin)-h.BinCenter(bin-1), h.BinCenter(bin+1)-h.BinCenter(bin)
+
+		diag[i] = 2. * (left + right)
+		rhs[i] = 6. * ((h.binContent[bin+1]-h.binContent[bin])/right - (h.binContent[bin]-h.binContent[bin-1])/left)
+		if i > 0 {
+			sub[i-1] = left
+		}
+		if i < n-1 {
+			super[i] = right
+		}
+	}
+
+	// The system is strictly diagonally dominant, hence always solvable
+	if sol, err := linalg.SolveTridiagonal(sub, diag, super, rhs); err == nil {
+		copy(res[1:], sol)
+	}
+
+	return res
+}
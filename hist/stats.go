@@ -0,0 +1,173 @@
+package hist
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fako1024/numerics"
+)
+
+// Summary denotes summary statistics of a histogram, as obtained via Stats()
+type Summary[T Number] struct {
+	Entries int     `json:"entries"`
+	Sum     float64 `json:"sum"`
+
+	// Mean, StdDev and Mode are estimated from the bin centers of the regular bins (zero if
+	// these are empty)
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stddev"`
+	Mode   float64 `json:"mode"`
+
+	// Min / Max denote the lower / upper edge of the first / last non-empty regular bin
+	Min T `json:"min"`
+	Max T `json:"max"`
+
+	// Median and the percentiles P25 - P99 are determined via Quantile()
+	Median T `json:"median"`
+	P25    T `json:"p25"`
+	P75    T `json:"p75"`
+	P90    T `json:"p90"`
+	P95    T `json:"p95"`
+	P99    T `json:"p99"`
+}
+
+// Stats returns summary statistics of the histogram (serializable to JSON)
+func (h *H1[T]) Stats() Summary[T] {
+
+	res := Summary[T]{
+		Entries: h.nEntries,
+		Sum:     h.sumOfWeights,
+		Median:  h.Median(),
+		P25:     h.Quantile(0.25),
+		P75:     h.Quantile(0.75),
+		P90:     h.Quantile(0.9),
+		P95:     h.Quantile(0.95),
+		P99:     h.Quantile(0.99),
+	}
+
+	first, last := h.FindFirstBinAbove(0), h.FindLastBinAbove(0)
+	if first < 0 {
+		return res
+	}
+	res.Min, res.Max = h.bins[first-1], h.bins[last]
+	res.Mean, res.StdDev, res.Mode = h.Mean(), h.StdDev(), h.Mode()
+
+	return res
+}
+
+// Quantile returns the value below which the fraction q of the sum of weights (including
+// under- / overflow) resides, interpolating linearly within the containing bin. Quantiles
+// residing in the underflow / overflow are reported as the boundaries of the x axis
+func (h *H1[T]) Quantile(q float64) T {
+	q = math.Max(0., math.Min(1., q))
+	if !(h.sumOfWeights > 0) {
+		return h.XMin()
+	}
+
+	target := q * h.sumOfWeights
+	cum := h.binContent[0]
+	if cum > 0 && target <= cum {
+		return h.XMin()
+	}
+
+	for i := 1; i <= h.nBins; i++ {
+		content := h.binContent[i]
+		if content > 0 && cum+content >= target {
+			frac := math.Max(0., math.Min(1., (target-cum)/content))
+			return T(float64(h.bins[i-1]) + frac*h.BinWidth(i))
+		}
+		cum += content
+	}
+
+	return h.XMax()
+}
+
+// Median returns the median of the histogram (see Quantile)
+func (h *H1[T]) Median() T {
+	return h.Quantile(0.5)
+}
+
+// Cumulative returns a histogram (with identical binning) whose bins hold the running sum
+// of weights (and variances) up to and including the respective bin, either accumulated
+// from the underflow (forward) or from the overflow (backward)
+func (h *H1[T]) Cumulative(forward bool) *H1[T] {
+	res := h.Clone()
+
+	var content, variance float64
+	for j := range h.binContent {
+		i := j
+		if !forward {
+			i = len(h.binContent) - 1 - j
+		}
+		content += h.binContent[i]
+		variance += h.binVariance[i]
+		res.binContent[i], res.binVariance[i] = content, variance
+	}
+	res.updateSumOfWeights()
+
+	return res
+}
+
+// Mean returns the mean of the histogram, estimated from the bin centers of the regular
+// bins (i.e. excluding under- / overflow)
+func (h *H1[T]) Mean() float64 {
+	var sum, sumX float64
+	for i := 1; i <= h.nBins; i++ {
+		sum += h.binContent[i]
+		sumX += h.binContent[i] * h.BinCenter(i)
+	}
+	if sum == 0 {
+		return math.NaN()
+	}
+
+	return sumX / sum
+}
+
+// StdDev returns the standard deviation of the histogram, estimated from the bin centers
+// of the regular bins (i.e. excluding under- / overflow)
+func (h *H1[T]) StdDev() float64 {
+	mean := h.Mean()
+
+	var sum, sumDX2 float64
+	for i := 1; i <= h.nBins; i++ {
+		dx := h.BinCenter(i) - mean
+		sum += h.binContent[i]
+		sumDX2 += h.binContent[i] * dx * dx
+	}
+
+	return math.Sqrt(sumDX2 / sum)
+}
+
+// FWHM returns the full width at half maximum of the distribution, interpolating linearly
+// between the bin centers at the half-height crossings on either side of the maximum bin.
+// Returns an error wrapping numerics.ErrDomain if the histogram is empty or the distribution
+// does not fall below half of its maximum on either side within the x axis
+func (h *H1[T]) FWHM() (T, error) {
+
+	bin := h.MaximumBin()
+	half := h.binContent[bin] / 2.
+	if !(half > 0) {
+		return 0, fmt.Errorf("%w: cannot determine FWHM of empty histogram", numerics.ErrDomain)
+	}
+
+	// crossing interpolates the position of the half-height crossing between two bins
+	crossing := func(inner, outer int) float64 {
+		xIn, xOut := h.BinCenter(inner), h.BinCenter(outer)
+		yIn, yOut := h.binContent[inner], h.binContent[outer]
+		return xIn + (xOut-xIn)*(yIn-half)/(yIn-yOut)
+	}
+
+	left := bin - 1
+	for left >= 1 && h.binContent[left] >= half {
+		left--
+	}
+	right := bin + 1
+	for right <= h.nBins && h.binContent[right] >= half {
+		right++
+	}
+	if left < 1 || right > h.nBins {
+		return 0, fmt.Errorf("%w: distribution does not fall below half maximum within the x axis", numerics.ErrDomain)
+	}
+
+	return T(crossing(right-1, right) - crossing(left+1, left)), nil
+}
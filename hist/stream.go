@@ -0,0 +1,42 @@
+package hist
+
+import "context"
+
+// Weighted denotes a value along with its weight, e.g. for ingestion via FillFromW()
+type Weighted[T Number] struct {
+	Value  T
+	Weight float64
+}
+
+// FillFrom fills all values received from a channel into the histogram until the channel is
+// closed (returning nil) or the context is cancelled (returning the context error). Since the
+// histogram is not synchronized, it must not be accessed concurrently while being fed
+func (h *H1[T]) FillFrom(ctx context.Context, ch <-chan T) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case val, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			h.Fill(val)
+		}
+	}
+}
+
+// FillFromW fills all weighted values received from a channel into the histogram (see
+// FillFrom())
+func (h *H1[T]) FillFromW(ctx context.Context, ch <-chan Weighted[T]) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case val, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			h.Fill(val.Value, val.Weight)
+		}
+	}
+}
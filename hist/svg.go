@@ -0,0 +1,191 @@
+package hist
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultSVGWidth  = 640
+	defaultSVGHeight = 400
+	defaultSVGColor  = "#4878a8"
+
+	svgMarginLeft   = 60.
+	svgMarginRight  = 20.
+	svgMarginTop    = 30.
+	svgMarginBottom = 40.
+
+	svgMaxXTicks = 10
+	svgNYTicks   = 5
+)
+
+// RenderOption denotes a functional option for RenderSVG()
+type RenderOption func(*renderSettings)
+
+type renderSettings struct {
+	width, height int
+	title         string
+	xLabel        string
+	yLabel        string
+	color         string
+	errorBars     bool
+}
+
+// WithSize sets the size of the SVG image in pixels (default: 640x400)
+func WithSize(width, height int) RenderOption {
+	return func(s *renderSettings) {
+		s.width, s.height = width, height
+	}
+}
+
+// WithTitle sets the title of the chart
+func WithTitle(title string) RenderOption {
+	return func(s *renderSettings) {
+		s.title = title
+	}
+}
+
+// WithAxisLabels sets the labels of the x and y axis
+func WithAxisLabels(xLabel, yLabel string) RenderOption {
+	return func(s *renderSettings) {
+		s.xLabel, s.yLabel = xLabel, yLabel
+	}
+}
+
+// WithColor sets the fill color of the bars (any SVG color specification, default: #4878a8)
+func WithColor(color string) RenderOption {
+	return func(s *renderSettings) {
+		s.color = color
+	}
+}
+
+// WithoutErrorBars suppresses the error bars (derived from the bin variances)
+func WithoutErrorBars() RenderOption {
+	return func(s *renderSettings) {
+		s.errorBars = false
+	}
+}
+
+// RenderSVG renders the histogram as standalone SVG bar chart (including axes, labels and
+// error bars derived from the bin variances) to any io.Writer
+func (h *H1[T]) RenderSVG(w io.Writer, options ...RenderOption) error {
+
+	opts := renderSettings{
+		width:     defaultSVGWidth,
+		height:    defaultSVGHeight,
+		color:     defaultSVGColor,
+		errorBars: true,
+	}
+
+	// Execute functional options (if any)
+	for _, option := range options {
+		option(&opts)
+	}
+
+	yMin, yMax := h.renderRange(opts.errorBars)
+
+	var (
+		plotWidth  = float64(opts.width) - svgMarginLeft - svgMarginRight
+		plotHeight = float64(opts.height) - svgMarginTop - svgMarginBottom
+		xMin, xMax = float64(h.XMin()), float64(h.XMax())
+	)
+	px := func(x float64) float64 {
+		return svgMarginLeft + (x-xMin)/(xMax-xMin)*plotWidth
+	}
+	py := func(y float64) float64 {
+		return svgMarginTop + (yMax-y)/(yMax-yMin)*plotHeight
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`+"\n",
+		opts.width, opts.height, opts.width, opts.height)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="white"/>`+"\n", opts.width, opts.height)
+	if opts.title != "" {
+		fmt.Fprintf(&sb, `<text x="%s" y="%s" text-anchor="middle" font-size="14">%s</text>`+"\n",
+			svgFmt(float64(opts.width)/2.), svgFmt(svgMarginTop/2.+5.), html.EscapeString(opts.title))
+	}
+
+	// Bars and error bars
+	for i := 1; i <= h.nBins; i++ {
+		y := h.binContent[i]
+		x0, x1 := px(float64(h.bins[i-1])), px(float64(h.bins[i]))
+		y0, y1 := py(math.Max(y, 0.)), py(math.Min(y, 0.))
+		fmt.Fprintf(&sb, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s" stroke="white" stroke-width="0.5"/>`+"\n",
+			svgFmt(x0), svgFmt(y0), svgFmt(x1-x0), svgFmt(y1-y0), html.EscapeString(opts.color))
+
+		if yErr := h.BinError(i); opts.errorBars && yErr > 0 {
+			xc := (x0 + x1) / 2.
+			fmt.Fprintf(&sb, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="black"/>`+"\n",
+				svgFmt(xc), svgFmt(py(y-yErr)), svgFmt(xc), svgFmt(py(y+yErr)))
+		}
+	}
+
+	// Axes
+	fmt.Fprintf(&sb, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="black"/>`+"\n",
+		svgFmt(svgMarginLeft), svgFmt(py(0.)), svgFmt(svgMarginLeft+plotWidth), svgFmt(py(0.)))
+	fmt.Fprintf(&sb, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="black"/>`+"\n",
+		svgFmt(svgMarginLeft), svgFmt(svgMarginTop), svgFmt(svgMarginLeft), svgFmt(svgMarginTop+plotHeight))
+
+	// Ticks / labels of the x axis (at the bin edges, thinned out for many bins)
+	step := (h.nBins + svgMaxXTicks - 1) / svgMaxXTicks
+	for i := 0; i <= h.nBins; i += step {
+		x := px(float64(h.bins[i]))
+		fmt.Fprintf(&sb, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="black"/>`+"\n",
+			svgFmt(x), svgFmt(svgMarginTop+plotHeight), svgFmt(x), svgFmt(svgMarginTop+plotHeight+4.))
+		fmt.Fprintf(&sb, `<text x="%s" y="%s" text-anchor="middle">%s</text>`+"\n",
+			svgFmt(x), svgFmt(svgMarginTop+plotHeight+16.), html.EscapeString(fmtValue(h.bins[i])))
+	}
+
+	// Ticks / labels of the y axis
+	for i := 0; i <= svgNYTicks; i++ {
+		yVal := yMin + float64(i)*(yMax-yMin)/svgNYTicks
+		y := py(yVal)
+		fmt.Fprintf(&sb, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="black"/>`+"\n",
+			svgFmt(svgMarginLeft-4.), svgFmt(y), svgFmt(svgMarginLeft), svgFmt(y))
+		fmt.Fprintf(&sb, `<text x="%s" y="%s" text-anchor="end">%s</text>`+"\n",
+			svgFmt(svgMarginLeft-6.), svgFmt(y+4.), strconv.FormatFloat(yVal, 'g', 4, 64))
+	}
+
+	// Axis labels
+	if opts.xLabel != "" {
+		fmt.Fprintf(&sb, `<text x="%s" y="%s" text-anchor="middle">%s</text>`+"\n",
+			svgFmt(svgMarginLeft+plotWidth/2.), svgFmt(float64(opts.height)-6.), html.EscapeString(opts.xLabel))
+	}
+	if opts.yLabel != "" {
+		fmt.Fprintf(&sb, `<text x="12" y="%s" text-anchor="middle" transform="rotate(-90 12 %s)">%s</text>`+"\n",
+			svgFmt(svgMarginTop+plotHeight/2.), svgFmt(svgMarginTop+plotHeight/2.), html.EscapeString(opts.yLabel))
+	}
+	sb.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// renderRange determines the range of the y axis for rendering (including the error bars,
+// if any)
+func (h *H1[T]) renderRange(errorBars bool) (yMin, yMax float64) {
+	for i := 1; i <= h.nBins; i++ {
+		y, yErr := h.binContent[i], 0.
+		if errorBars {
+			yErr = h.BinError(i)
+		}
+		yMin = math.Min(yMin, y-yErr)
+		yMax = math.Max(yMax, y+yErr)
+	}
+	if yMax == yMin {
+		yMax = yMin + 1.
+	}
+
+	return yMin, yMax + 0.05*(yMax-yMin)
+}
+
+// svgFmt formats a coordinate with limited precision
+func svgFmt(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
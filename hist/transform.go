@@ -0,0 +1,45 @@
+package hist
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/fako1024/numerics"
+)
+
+// TransformAxis returns a new histogram whose bin edges are mapped through a strictly
+// monotone function (e.g. to convert units), retaining the contents of all bins. For a
+// decreasing function, the order of the bins (and the under- / overflow) is reversed
+func (h *H1[T]) TransformAxis(f func(T) T) (*H1[T], error) {
+
+	edges := make([]T, len(h.bins))
+	for i, edge := range h.bins {
+		edges[i] = f(edge)
+	}
+
+	increasing, decreasing := true, true
+	for i := 1; i < len(edges); i++ {
+		increasing = increasing && edges[i] > edges[i-1]
+		decreasing = decreasing && edges[i] < edges[i-1]
+	}
+	if !increasing && !decreasing {
+		return nil, fmt.Errorf("%w: transformed bin edges not strictly monotone", numerics.ErrDomain)
+	}
+
+	res := h.Clone()
+	res.bins = edges
+	res.spline.invalidate()
+	if decreasing {
+		slices.Reverse(res.bins)
+		slices.Reverse(res.binContent)
+		slices.Reverse(res.binVariance)
+	}
+
+	res.equidistant, res.invWidth = false, 0.
+	if res.isEquidistant() {
+		res.setEquidistant()
+	}
+	res.autoExtend = res.autoExtend && res.equidistant
+
+	return res, nil
+}
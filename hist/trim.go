@@ -0,0 +1,63 @@
+package hist
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/fako1024/numerics"
+)
+
+// SuggestRange suggests the range of the x axis for a histogram of a sample, spanning its
+// quantiles qLow and qHigh (e.g. 0.01 and 0.99) instead of its extreme values, such that
+// individual outliers do not lead to an excessively wide axis (they end up in the under- /
+// overflow instead). Returns an error wrapping numerics.ErrDomain for an empty sample or
+// invalid quantiles
+func SuggestRange[T Number](sample []T, qLow, qHigh float64) (xMin, xMax T, err error) {
+	if len(sample) == 0 {
+		return 0, 0, fmt.Errorf("%w: cannot suggest range for empty sample", numerics.ErrDomain)
+	}
+	if !(qLow >= 0 && qLow < qHigh && qHigh <= 1) {
+		return 0, 0, fmt.Errorf("%w: invalid quantiles [%v, %v]", numerics.ErrDomain, qLow, qHigh)
+	}
+
+	sorted := slices.Clone(sample)
+	slices.Sort(sorted)
+
+	quantile := func(q float64) T {
+		return sorted[min(int(q*float64(len(sorted))), len(sorted)-1)]
+	}
+	xMin, xMax = quantile(qLow), quantile(qHigh)
+	if !(xMax > xMin) {
+		xMax = nextAbove(xMin)
+	}
+
+	return xMin, xMax, nil
+}
+
+// Trimmed returns a copy of the histogram with the x axis narrowed to the bins containing
+// the quantiles qLow and qHigh (see Quantile), moving the contents of all bins outside of
+// this range into the under- / overflow. Thus, the total sum of weights is retained while
+// the bulk of the distribution is not dwarfed by a few outliers
+func (h *H1[T]) Trimmed(qLow, qHigh float64) *H1[T] {
+
+	first := min(max(h.FindBin(h.Quantile(qLow)), 1), h.nBins)
+	last := min(max(h.FindBin(h.Quantile(qHigh)), first), h.nBins)
+
+	res := NewH1Edges(h.bins[first-1 : last+1])
+	res.nEntries, res.sumOfWeights, res.sumw2 = h.nEntries, h.sumOfWeights, h.sumw2
+	res.halfLife, res.lastDecay, res.now = h.halfLife, h.lastDecay, h.now
+
+	for i := 0; i <= h.nBins+1; i++ {
+		bin := i - first + 1
+		switch {
+		case i < first:
+			bin = 0
+		case i > last:
+			bin = res.nBins + 1
+		}
+		res.binContent[bin] += h.binContent[i]
+		res.binVariance[bin] += h.binVariance[i]
+	}
+
+	return res
+}
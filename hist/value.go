@@ -0,0 +1,129 @@
+package hist
+
+import (
+	"io"
+)
+
+// Valuer provides a type constraint for user-defined types that can be histogrammed via
+// H1V. Named numeric types (e.g. type Cents int64) satisfy Number and can be used with H1
+// directly, hence this is only required for types without a numeric underlying type (e.g.
+// decimals represented by a struct), which are mapped onto float64 internally
+type Valuer[T any] interface {
+
+	// Value returns the numeric representation of the value
+	Value() float64
+
+	// FromFloat returns the value corresponding to a numeric representation (it is called
+	// on the zero value of T)
+	FromFloat(x float64) T
+}
+
+// H1V denotes a one-dimensional histogram of user-defined values, based on a float64
+// histogram of their numeric representation
+type H1V[T Valuer[T]] struct {
+	h *H1D
+}
+
+// NewH1V instantiates a new one-dimensional histogram of user-defined values
+func NewH1V[T Valuer[T]](n int, xMin, xMax T) *H1V[T] {
+	return &H1V[T]{
+		h: NewH1D(n, xMin.Value(), xMax.Value()),
+	}
+}
+
+// Float returns the underlying float64 histogram of the numeric representations, providing
+// access to all methods of H1D
+func (h *H1V[T]) Float() *H1D {
+	return h.h
+}
+
+// Print prints out the histogram data to any io.Writer (the rendering can be customized
+// via functional options)
+func (h *H1V[T]) Print(w io.Writer, options ...PrintOption) error {
+	return h.h.Print(w, options...)
+}
+
+// NBins Returns the number of bins in the histogram
+func (h *H1V[T]) NBins() int {
+	return h.h.NBins()
+}
+
+// NEntries returns the number of entries in the histogram
+func (h *H1V[T]) NEntries() int {
+	return h.h.NEntries()
+}
+
+// Sum returns the sum of weights in the histogram
+func (h *H1V[T]) Sum() float64 {
+	return h.h.Sum()
+}
+
+// XMin returns the lower boundary of the x axis
+func (h *H1V[T]) XMin() T {
+	return fromFloat[T](h.h.XMin())
+}
+
+// XMax returns the upper boundary of the x axis
+func (h *H1V[T]) XMax() T {
+	return fromFloat[T](h.h.XMax())
+}
+
+// BinLowEdge returns the lower edge of a particular (regular) bin
+func (h *H1V[T]) BinLowEdge(bin int) T {
+	return fromFloat[T](h.h.BinLowEdge(bin))
+}
+
+// BinUpEdge returns the upper edge of a particular (regular) bin
+func (h *H1V[T]) BinUpEdge(bin int) T {
+	return fromFloat[T](h.h.BinUpEdge(bin))
+}
+
+// BinCenter returns the center value of a particular bin
+func (h *H1V[T]) BinCenter(bin int) T {
+	return fromFloat[T](h.h.BinCenter(bin))
+}
+
+// BinContent returns the sum of weights in a particular bin
+func (h *H1V[T]) BinContent(bin int) float64 {
+	return h.h.BinContent(bin)
+}
+
+// BinVariance returns the variance in a particular bin
+func (h *H1V[T]) BinVariance(bin int) float64 {
+	return h.h.BinVariance(bin)
+}
+
+// MaximumBin returns the maximum bin
+func (h *H1V[T]) MaximumBin() int {
+	return h.h.MaximumBin()
+}
+
+// Mode returns the mode of the histogram
+func (h *H1V[T]) Mode() T {
+	return fromFloat[T](h.h.Mode())
+}
+
+// Fill adds a weight / entry to the histogram
+func (h *H1V[T]) Fill(val T, weight ...float64) {
+	h.h.Fill(val.Value(), weight...)
+}
+
+// Scale scales the histogram by a constant factor
+func (h *H1V[T]) Scale(scale float64) {
+	h.h.Scale(scale)
+}
+
+// FindBin returns the bin best matching the value x
+func (h *H1V[T]) FindBin(x T) int {
+	return h.h.FindBin(x.Value())
+}
+
+// Interpolate linearly interpolates between the nearest bin neigbors
+func (h *H1V[T]) Interpolate(x T) float64 {
+	return h.h.Interpolate(x.Value())
+}
+
+func fromFloat[T Valuer[T]](x float64) T {
+	var zero T
+	return zero.FromFloat(x)
+}
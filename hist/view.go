@@ -0,0 +1,116 @@
+package hist
+
+import "io"
+
+// H1View denotes an immutable view of a one-dimensional histogram, obtained via
+// SafeH1.Snapshot(). Since the underlying data is never modified, a view can be read
+// without any locking while the original histogram continues to be filled
+type H1View[T Number] struct {
+	h *H1[T]
+}
+
+// Clone returns a (mutable) deep copy of the histogram
+func (v H1View[T]) Clone() *H1[T] {
+	return v.h.Clone()
+}
+
+// Print prints out the histogram data to any io.Writer (see H1.Print)
+func (v H1View[T]) Print(w io.Writer, options ...PrintOption) error {
+	return v.h.Print(w, options...)
+}
+
+// MarshalJSON implements json.Marshaler (see H1.MarshalJSON)
+func (v H1View[T]) MarshalJSON() ([]byte, error) {
+	return v.h.MarshalJSON()
+}
+
+// NBins returns the number of (regular) bins in the histogram
+func (v H1View[T]) NBins() int {
+	return v.h.NBins()
+}
+
+// NEntries returns the number of entries in the histogram
+func (v H1View[T]) NEntries() int {
+	return v.h.NEntries()
+}
+
+// Sum returns the sum of weights in the histogram
+func (v H1View[T]) Sum() float64 {
+	return v.h.Sum()
+}
+
+// XMin returns the lower boundary of the x axis
+func (v H1View[T]) XMin() T {
+	return v.h.XMin()
+}
+
+// XMax returns the upper boundary of the x axis
+func (v H1View[T]) XMax() T {
+	return v.h.XMax()
+}
+
+// BinLowEdge returns the lower edge of a particular (regular) bin
+func (v H1View[T]) BinLowEdge(bin int) T {
+	return v.h.BinLowEdge(bin)
+}
+
+// BinUpEdge returns the upper edge of a particular (regular) bin
+func (v H1View[T]) BinUpEdge(bin int) T {
+	return v.h.BinUpEdge(bin)
+}
+
+// BinCenter returns the center x value of a particular bin
+func (v H1View[T]) BinCenter(bin int) float64 {
+	return v.h.BinCenter(bin)
+}
+
+// BinContent returns the sum of weights in a particular bin
+func (v H1View[T]) BinContent(bin int) float64 {
+	return v.h.BinContent(bin)
+}
+
+// BinVariance returns the variance in a particular bin
+func (v H1View[T]) BinVariance(bin int) float64 {
+	return v.h.BinVariance(bin)
+}
+
+// BinError returns the statistical uncertainty of a particular bin (see H1.BinError)
+func (v H1View[T]) BinError(bin int) float64 {
+	return v.h.BinError(bin)
+}
+
+// Underflow returns the sum of weights and the variance of the underflow bin
+func (v H1View[T]) Underflow() (float64, float64) {
+	return v.h.Underflow()
+}
+
+// Overflow returns the sum of weights and the variance of the overflow bin
+func (v H1View[T]) Overflow() (float64, float64) {
+	return v.h.Overflow()
+}
+
+// Quantile returns the value below which the fraction q of the sum of weights resides
+// (see H1.Quantile)
+func (v H1View[T]) Quantile(q float64) T {
+	return v.h.Quantile(q)
+}
+
+// Mean returns the mean of the histogram (see H1.Mean)
+func (v H1View[T]) Mean() float64 {
+	return v.h.Mean()
+}
+
+// StdDev returns the standard deviation of the histogram (see H1.StdDev)
+func (v H1View[T]) StdDev() float64 {
+	return v.h.StdDev()
+}
+
+// Visit calls fn for each regular bin of the histogram (see H1.Visit)
+func (v H1View[T]) Visit(fn func(bin int, lowEdge, highEdge T, content, variance float64) bool) {
+	v.h.Visit(fn)
+}
+
+// Stats returns summary statistics of the histogram (see H1.Stats)
+func (v H1View[T]) Stats() Summary[T] {
+	return v.h.Stats()
+}
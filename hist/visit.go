@@ -0,0 +1,11 @@
+package hist
+
+// Visit calls fn for each regular bin of the histogram in ascending order (with bin indices
+// as used by BinContent() et al.), stopping early if fn returns false
+func (h *H1[T]) Visit(fn func(bin int, lowEdge, highEdge T, content, variance float64) bool) {
+	for i := 1; i <= h.nBins; i++ {
+		if !fn(i, h.bins[i-1], h.bins[i], h.binContent[i], h.binVariance[i]) {
+			return
+		}
+	}
+}
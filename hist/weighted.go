@@ -0,0 +1,77 @@
+package hist
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/fako1024/numerics"
+)
+
+// WeightedQuantile returns the weighted quantile q of a sample, i.e. the smallest value
+// whose cumulative weight (over all values sorted in ascending order, including the value
+// itself) reaches the fraction q of the total weight. No interpolation is performed, hence
+// the result is always an element of the sample: If the cumulative weight exactly equals the
+// fraction q at the transition between two values, the lower one is returned (consistently
+// yielding the lower weighted median for q = 0.5). Values with zero weight are ignored, in
+// particular WeightedQuantile(..., 0) returns the smallest value with positive weight.
+// Returns an error wrapping numerics.ErrDomain for mismatching lengths, negative / non-finite
+// weights or a vanishing total weight
+func WeightedQuantile[T Number](values []T, weights []float64, q float64) (T, error) {
+	if len(values) != len(weights) {
+		return 0, fmt.Errorf("%w: must specify exactly one weight per value (%d vs. %d)", numerics.ErrDomain, len(values), len(weights))
+	}
+	if !(q >= 0 && q <= 1) {
+		return 0, fmt.Errorf("%w: quantile must be in [0, 1], have %v", numerics.ErrDomain, q)
+	}
+
+	idx := make([]int, 0, len(values))
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return 0, fmt.Errorf("%w: weights must be non-negative and finite, have %v", numerics.ErrDomain, w)
+		}
+		if w > 0 {
+			idx = append(idx, i)
+		}
+	}
+	if len(idx) == 0 {
+		return 0, fmt.Errorf("%w: total weight of sample vanishes", numerics.ErrDomain)
+	}
+
+	// Sort by value (retaining the original order of equal values), accumulating the total
+	// weight in the same order as the cumulative weight below to avoid rounding mismatches
+	sort.SliceStable(idx, func(i, j int) bool {
+		return values[idx[i]] < values[idx[j]]
+	})
+	var total, comp float64
+	for _, i := range idx {
+		total, comp = kahanAdd(total, comp, weights[i])
+	}
+
+	target := q * total
+	var cum float64
+	comp = 0
+	for _, i := range idx {
+		if cum, comp = kahanAdd(cum, comp, weights[i]); cum >= target {
+			return values[i], nil
+		}
+	}
+
+	return values[idx[len(idx)-1]], nil
+}
+
+// WeightedMedian returns the (lower) weighted median of a sample (see WeightedQuantile)
+func WeightedMedian[T Number](values []T, weights []float64) (T, error) {
+	return WeightedQuantile(values, weights, 0.5)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// kahanAdd adds a value to a sum using compensated (Kahan) summation, which retains the
+// precision of sums of values of very different magnitude
+func kahanAdd(sum, comp, v float64) (float64, float64) {
+	y := v - comp
+	t := sum + y
+
+	return t, (t - sum) - y
+}
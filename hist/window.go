@@ -0,0 +1,105 @@
+package hist
+
+import (
+	"sync"
+	"time"
+)
+
+// Window denotes a rolling, time-windowed histogram, representing only the values filled
+// within the most recent time window. It is based on a ring of sub-histograms, each
+// covering an equal slot of the window, which are rotated as time progresses (and can
+// safely be used from multiple goroutines)
+type Window[T Number] struct {
+	slots   []*H1[T]
+	current int
+
+	slotDuration time.Duration
+	slotStart    time.Time
+	now          func() time.Time
+
+	mu sync.Mutex
+}
+
+// NewWindow instantiates a new rolling histogram covering the provided time window, split
+// into nSlots sub-histograms (with the binning of the provided template histogram)
+func NewWindow[T Number](template *H1[T], window time.Duration, nSlots int) *Window[T] {
+	if nSlots < 1 || window < time.Duration(nSlots) {
+		panic("window must be split into at least one slot of positive duration")
+	}
+
+	obj := Window[T]{
+		slots:        make([]*H1[T], nSlots),
+		slotDuration: window / time.Duration(nSlots),
+		now:          time.Now,
+	}
+	for i := range obj.slots {
+		obj.slots[i] = template.Clone()
+		obj.slots[i].Reset()
+	}
+	obj.slotStart = obj.now()
+
+	return &obj
+}
+
+// Fill adds a weight / entry to the current slot of the window
+func (w *Window[T]) Fill(val T, weight ...float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.advance()
+	w.slots[w.current].Fill(val, weight...)
+}
+
+// Merge returns the aggregate histogram of all slots within the live window
+func (w *Window[T]) Merge() *H1[T] {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.advance()
+	res, _ := Merge(w.slots...)
+
+	return res
+}
+
+// Rotate starts a new slot immediately, discarding the oldest one (e.g. to align the
+// slots with external reporting intervals)
+func (w *Window[T]) Rotate() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.rotate()
+	w.slotStart = w.now()
+}
+
+// Reset zeroes all slots of the window
+func (w *Window[T]) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, slot := range w.slots {
+		slot.Reset()
+	}
+	w.slotStart = w.now()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// advance rotates the slots according to the time elapsed since the start of the current
+// slot
+func (w *Window[T]) advance() {
+	elapsed := w.now().Sub(w.slotStart)
+	if elapsed < w.slotDuration {
+		return
+	}
+
+	n := int(elapsed / w.slotDuration)
+	for i := 0; i < min(n, len(w.slots)); i++ {
+		w.rotate()
+	}
+	w.slotStart = w.slotStart.Add(time.Duration(n) * w.slotDuration)
+}
+
+func (w *Window[T]) rotate() {
+	w.current = (w.current + 1) % len(w.slots)
+	w.slots[w.current].Reset()
+}
@@ -0,0 +1,138 @@
+// Package arrow provides writers producing Apache Arrow (https://arrow.apache.org) record
+// batches from histograms and raw samples, allowing analysis tools such as pandas, polars
+// or DuckDB to consume data without any parsing
+package arrow
+
+import (
+	"io"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/fako1024/numerics/hist"
+)
+
+// HistogramSchema denotes the schema of a record batch representing a histogram. The
+// under- / overflow bins are included with an infinite lower / upper edge, respectively,
+// the error denoting the statistical uncertainty of the bin content (see H1.BinError)
+var HistogramSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "bin", Type: arrow.PrimitiveTypes.Int32},
+	{Name: "low_edge", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "high_edge", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "content", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "error", Type: arrow.PrimitiveTypes.Float64},
+}, nil)
+
+// HistogramRecord creates a record batch (following HistogramSchema) from a histogram.
+// The caller is responsible for releasing the record
+func HistogramRecord[T hist.Number](h *hist.H1[T], mem memory.Allocator) arrow.Record {
+
+	b := array.NewRecordBuilder(mem, HistogramSchema)
+	defer b.Release()
+
+	n := h.NBins()
+	for bin := 0; bin <= n+1; bin++ {
+		var low, high float64
+		switch bin {
+		case 0:
+			low, high = math.Inf(-1), float64(h.BinLowEdge(1))
+		case n + 1:
+			low, high = float64(h.BinUpEdge(n)), math.Inf(1)
+		default:
+			low, high = float64(h.BinLowEdge(bin)), float64(h.BinUpEdge(bin))
+		}
+
+		b.Field(0).(*array.Int32Builder).Append(int32(bin))
+		b.Field(1).(*array.Float64Builder).Append(low)
+		b.Field(2).(*array.Float64Builder).Append(high)
+		b.Field(3).(*array.Float64Builder).Append(h.BinContent(bin))
+		b.Field(4).(*array.Float64Builder).Append(h.BinError(bin))
+	}
+
+	return b.NewRecord()
+}
+
+// WriteHistograms writes the histograms as consecutive record batches (following
+// HistogramSchema) in the Arrow IPC stream format
+func WriteHistograms[T hist.Number](w io.Writer, hs ...*hist.H1[T]) error {
+
+	mem := memory.NewGoAllocator()
+	writer := ipc.NewWriter(w, ipc.WithSchema(HistogramSchema), ipc.WithAllocator(mem))
+
+	for _, h := range hs {
+		rec := HistogramRecord(h, mem)
+		err := writer.Write(rec)
+		rec.Release()
+		if err != nil {
+			writer.Close()
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// SampleSchema returns the schema of a record batch holding a single column of raw
+// samples of type T under the given name
+func SampleSchema[T hist.Number](name string) *arrow.Schema {
+	return arrow.NewSchema([]arrow.Field{
+		{Name: name, Type: dataType[T]()},
+	}, nil)
+}
+
+// SampleRecord creates a record batch (following SampleSchema) from a slice of raw samples.
+// The caller is responsible for releasing the record
+func SampleRecord[T hist.Number](name string, samples []T, mem memory.Allocator) arrow.Record {
+
+	b := array.NewRecordBuilder(mem, SampleSchema[T](name))
+	defer b.Release()
+
+	appendSamples(b.Field(0), samples)
+
+	return b.NewRecord()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// dataType maps the type of samples onto the respective Arrow data type (based on the
+// underlying kind of named types)
+func dataType[T hist.Number]() arrow.DataType {
+
+	typ := reflect.TypeFor[T]()
+	if typ == reflect.TypeFor[time.Duration]() {
+		return arrow.FixedWidthTypes.Duration_ns
+	}
+	switch typ.Kind() {
+	case reflect.Float32:
+		return arrow.PrimitiveTypes.Float32
+	case reflect.Float64:
+		return arrow.PrimitiveTypes.Float64
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return arrow.PrimitiveTypes.Int64
+	}
+
+	return arrow.PrimitiveTypes.Uint64
+}
+
+func appendSamples[T hist.Number](b array.Builder, samples []T) {
+
+	b.Reserve(len(samples))
+	for _, v := range samples {
+		switch bt := b.(type) {
+		case *array.Float32Builder:
+			bt.UnsafeAppend(float32(v))
+		case *array.Float64Builder:
+			bt.UnsafeAppend(float64(v))
+		case *array.DurationBuilder:
+			bt.UnsafeAppend(arrow.Duration(v))
+		case *array.Int64Builder:
+			bt.UnsafeAppend(int64(v))
+		case *array.Uint64Builder:
+			bt.UnsafeAppend(uint64(v))
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package arrow
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/fako1024/numerics/hist"
+)
+
+func TestWriteHistograms(t *testing.T) {
+
+	h := hist.NewH1D(4, 0., 2.)
+	for _, x := range []float64{-1., 0.1, 0.6, 0.7, 1.9, 3.} {
+		h.Fill(x)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := WriteHistograms(buf, h, h); err != nil {
+		t.Fatalf("Unexpected error writing histograms: %s", err)
+	}
+
+	reader, err := ipc.NewReader(buf)
+	if err != nil {
+		t.Fatalf("Unexpected error reading stream: %s", err)
+	}
+	defer reader.Release()
+
+	nRecords := 0
+	for reader.Next() {
+		rec := reader.Record()
+		if rec.NumRows() != int64(h.NBins()+2) {
+			t.Fatalf("Unexpected number of rows: want %d, have %d", h.NBins()+2, rec.NumRows())
+		}
+
+		low := rec.Column(1).(*array.Float64).Float64Values()
+		high := rec.Column(2).(*array.Float64).Float64Values()
+		content := rec.Column(3).(*array.Float64).Float64Values()
+		binErr := rec.Column(4).(*array.Float64).Float64Values()
+		if !math.IsInf(low[0], -1) || !math.IsInf(high[5], 1) || low[2] != 0.5 || high[2] != 1. {
+			t.Fatalf("Unexpected bin edges: %v / %v", low, high)
+		}
+		for i := range content {
+			if content[i] != h.BinContent(i) {
+				t.Fatalf("Unexpected content in bin %d: want %v, have %v", i, h.BinContent(i), content[i])
+			}
+
+			// Without variance tracking, the error falls back to the Poisson error
+			if binErr[i] != math.Sqrt(h.BinContent(i)) {
+				t.Fatalf("Unexpected error in bin %d: want %v, have %v", i, math.Sqrt(h.BinContent(i)), binErr[i])
+			}
+		}
+		nRecords++
+	}
+	if nRecords != 2 {
+		t.Fatalf("Unexpected number of records: want 2, have %d", nRecords)
+	}
+}
+
+func TestSampleWriter(t *testing.T) {
+
+	buf := new(bytes.Buffer)
+	writer := NewSampleWriter[time.Duration](buf, "latency", 3)
+	for i := 0; i < 4; i++ {
+		if err := writer.Write(time.Duration(i)*time.Millisecond, time.Second); err != nil {
+			t.Fatalf("Unexpected error writing samples: %s", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Unexpected error closing writer: %s", err)
+	}
+
+	reader, err := ipc.NewReader(buf)
+	if err != nil {
+		t.Fatalf("Unexpected error reading stream: %s", err)
+	}
+	defer reader.Release()
+
+	var values []arrow.Duration
+	for reader.Next() {
+		values = append(values, reader.Record().Column(0).(*array.Duration).DurationValues()...)
+	}
+	if len(values) != 8 || values[2] != arrow.Duration(time.Millisecond) || values[7] != arrow.Duration(time.Second) {
+		t.Fatalf("Unexpected samples: %v", values)
+	}
+
+	rec := SampleRecord("x", []int{1, 2, 3}, memory.NewGoAllocator())
+	defer rec.Release()
+	if rec.Schema().Field(0).Type.ID() != arrow.INT64 || rec.NumRows() != 3 {
+		t.Fatalf("Unexpected sample record: %v", rec)
+	}
+
+	// Named types are mapped according to their underlying type
+	type celsius float64
+	recC := SampleRecord("temperature", []celsius{-1.5, 0.25, 21.75}, memory.NewGoAllocator())
+	defer recC.Release()
+	if recC.Schema().Field(0).Type.ID() != arrow.FLOAT64 {
+		t.Fatalf("Unexpected data type for named floating point type: %v", recC.Schema().Field(0).Type)
+	}
+	if values := recC.Column(0).(*array.Float64).Float64Values(); len(values) != 3 || values[0] != -1.5 || values[2] != 21.75 {
+		t.Fatalf("Unexpected samples for named floating point type: %v", values)
+	}
+	type offset int16
+	recO := SampleRecord("offset", []offset{-3, 7}, memory.NewGoAllocator())
+	defer recO.Release()
+	if values := recO.Column(0).(*array.Int64).Int64Values(); len(values) != 2 || values[0] != -3 || values[1] != 7 {
+		t.Fatalf("Unexpected samples for named integer type: %v", values)
+	}
+}
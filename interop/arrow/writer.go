@@ -0,0 +1,81 @@
+package arrow
+
+import (
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/fako1024/numerics/hist"
+)
+
+// SampleWriter denotes a buffered writer of raw samples, emitting a record batch (following
+// SampleSchema) in the Arrow IPC stream format whenever the batch size is reached
+type SampleWriter[T hist.Number] struct {
+	batchSize int
+
+	builder *array.RecordBuilder
+	writer  *ipc.Writer
+}
+
+// NewSampleWriter instantiates a new writer of raw samples of type T (stored in a column
+// with the given name), emitting record batches of (at most) batchSize samples to w
+func NewSampleWriter[T hist.Number](w io.Writer, name string, batchSize int) *SampleWriter[T] {
+
+	if batchSize < 1 {
+		panic("batch size must be positive")
+	}
+
+	mem := memory.NewGoAllocator()
+	schema := SampleSchema[T](name)
+
+	return &SampleWriter[T]{
+		batchSize: batchSize,
+		builder:   array.NewRecordBuilder(mem, schema),
+		writer:    ipc.NewWriter(w, ipc.WithSchema(schema), ipc.WithAllocator(mem)),
+	}
+}
+
+// Write appends samples to the current batch, flushing all full batches
+func (s *SampleWriter[T]) Write(samples ...T) error {
+
+	for len(samples) > 0 {
+		n := min(len(samples), s.batchSize-s.builder.Field(0).Len())
+		appendSamples(s.builder.Field(0), samples[:n])
+		samples = samples[n:]
+
+		if s.builder.Field(0).Len() >= s.batchSize {
+			if err := s.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Flush emits all buffered samples as a record batch (if any)
+func (s *SampleWriter[T]) Flush() error {
+
+	if s.builder.Field(0).Len() == 0 {
+		return nil
+	}
+
+	rec := s.builder.NewRecord()
+	defer rec.Release()
+
+	return s.writer.Write(rec)
+}
+
+// Close flushes all buffered samples and terminates the stream
+func (s *SampleWriter[T]) Close() error {
+
+	defer s.builder.Release()
+
+	if err := s.Flush(); err != nil {
+		s.writer.Close()
+		return err
+	}
+
+	return s.writer.Close()
+}
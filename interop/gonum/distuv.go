@@ -0,0 +1,158 @@
+package gonum
+
+import (
+	"math"
+	"sort"
+
+	"github.com/fako1024/numerics/hist"
+	"golang.org/x/exp/rand"
+)
+
+// CDFer denotes any distribution providing a cumulative distribution function, such
+// as all univariate distributions of gonum's distuv package
+type CDFer interface {
+	CDF(x float64) float64
+}
+
+// FillExpected sets the contents of all bins of a histogram (including under- /
+// overflow) to the expected number of entries for a total number of entries drawn
+// from the distribution
+func FillExpected[T hist.Number](h *hist.H1[T], dist CDFer, total float64) {
+
+	n := h.NBins()
+	prev := 0.
+	for i := 1; i <= n; i++ {
+		cdf := dist.CDF(float64(h.BinLowEdge(i)))
+		h.SetBinContent(i-1, total*(cdf-prev))
+		prev = cdf
+	}
+	cdf := dist.CDF(float64(h.BinUpEdge(n)))
+	h.SetBinContent(n, total*(cdf-prev))
+	h.SetBinContent(n+1, total*(1.-cdf))
+}
+
+// Distribution denotes a univariate distribution based on the regular bins of a
+// histogram, assuming a uniform density within each bin. It satisfies the interfaces
+// of gonum's distuv package (e.g. distuv.Quantiler and distuv.RandLogProber)
+type Distribution struct {
+	dividers []float64
+	cdf      []float64
+
+	// Src denotes the random source used by Rand (if nil, the global source is used)
+	Src rand.Source
+}
+
+// NewDistribution instantiates a new distribution from a snapshot of the regular bins
+// of a histogram (i.e. subsequent modifications of the histogram are not reflected)
+func NewDistribution[T hist.Number](h *hist.H1[T]) *Distribution {
+
+	obj := Distribution{
+		dividers: Dividers(h),
+		cdf:      make([]float64, h.NBins()+1),
+	}
+
+	for i := 1; i <= h.NBins(); i++ {
+		obj.cdf[i] = obj.cdf[i-1] + h.BinContent(i)
+	}
+	if total := obj.cdf[h.NBins()]; total > 0 {
+		for i := range obj.cdf {
+			obj.cdf[i] /= total
+		}
+	}
+
+	return &obj
+}
+
+// CDF computes the value of the cumulative distribution function at x
+func (d *Distribution) CDF(x float64) float64 {
+
+	n := len(d.cdf) - 1
+	if x <= d.dividers[0] {
+		return 0.
+	}
+	if x >= d.dividers[n] {
+		return 1.
+	}
+
+	i := sort.SearchFloat64s(d.dividers, x)
+	if d.dividers[i] > x {
+		i--
+	}
+	frac := (x - d.dividers[i]) / (d.dividers[i+1] - d.dividers[i])
+	return d.cdf[i] + frac*(d.cdf[i+1]-d.cdf[i])
+}
+
+// Prob computes the value of the probability density function at x
+func (d *Distribution) Prob(x float64) float64 {
+
+	n := len(d.cdf) - 1
+	if x < d.dividers[0] || x > d.dividers[n] {
+		return 0.
+	}
+
+	i := sort.SearchFloat64s(d.dividers, x)
+	if i == n || d.dividers[i] > x {
+		i--
+	}
+	return (d.cdf[i+1] - d.cdf[i]) / (d.dividers[i+1] - d.dividers[i])
+}
+
+// LogProb computes the natural logarithm of the value of the probability density
+// function at x
+func (d *Distribution) LogProb(x float64) float64 {
+	return math.Log(d.Prob(x))
+}
+
+// Quantile returns the inverse of the cumulative distribution function
+func (d *Distribution) Quantile(p float64) float64 {
+
+	if p < 0 || p > 1 {
+		panic("quantile out of bounds")
+	}
+
+	// Determine the first bin whose cumulative content reaches p (skipping empty bins)
+	n := len(d.cdf) - 1
+	i := sort.SearchFloat64s(d.cdf[1:], p)
+	if i >= n {
+		return d.dividers[n]
+	}
+	if d.cdf[i+1] == d.cdf[i] {
+		return d.dividers[i]
+	}
+
+	frac := (p - d.cdf[i]) / (d.cdf[i+1] - d.cdf[i])
+	return d.dividers[i] + frac*(d.dividers[i+1]-d.dividers[i])
+}
+
+// Rand returns a random sample drawn from the distribution
+func (d *Distribution) Rand() float64 {
+	var u float64
+	if d.Src == nil {
+		u = rand.Float64()
+	} else {
+		// Draw directly from the source (avoiding the allocation of a new generator per
+		// sample), using the upper 53 bits for a uniform value in [0, 1)
+		u = float64(d.Src.Uint64()>>11) / (1 << 53)
+	}
+
+	return d.Quantile(u)
+}
+
+// Mean returns the mean of the distribution
+func (d *Distribution) Mean() float64 {
+	mean := 0.
+	for i := 0; i < len(d.cdf)-1; i++ {
+		mean += (d.cdf[i+1] - d.cdf[i]) * 0.5 * (d.dividers[i] + d.dividers[i+1])
+	}
+	return mean
+}
+
+// Variance returns the variance of the distribution
+func (d *Distribution) Variance() float64 {
+	mean, sumSq := d.Mean(), 0.
+	for i := 0; i < len(d.cdf)-1; i++ {
+		center, width := 0.5*(d.dividers[i]+d.dividers[i+1]), d.dividers[i+1]-d.dividers[i]
+		sumSq += (d.cdf[i+1] - d.cdf[i]) * (center*center + width*width/12.)
+	}
+	return sumSq - mean*mean
+}
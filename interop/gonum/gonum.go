@@ -0,0 +1,85 @@
+// Package gonum provides conversion helpers between the histograms of this module and
+// the types of gonum (https://www.gonum.org), avoiding element-wise copying of data in
+// mixed code bases
+package gonum
+
+import (
+	"errors"
+
+	"github.com/fako1024/numerics/hist"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Dividers returns the bin edges of a histogram in the format used by stat.Histogram
+// (i.e. NBins()+1 ascending values)
+func Dividers[T hist.Number](h *hist.H1[T]) []float64 {
+	res := make([]float64, h.NBins()+1)
+	for i := 0; i < h.NBins(); i++ {
+		res[i] = float64(h.BinLowEdge(i + 1))
+	}
+	res[h.NBins()] = float64(h.BinUpEdge(h.NBins()))
+
+	return res
+}
+
+// Counts returns the contents of the regular bins of a histogram in the format used by
+// stat.Histogram (i.e. excluding under- / overflow)
+func Counts[T hist.Number](h *hist.H1[T]) []float64 {
+	res := make([]float64, h.NBins())
+	for i := range res {
+		res[i] = h.BinContent(i + 1)
+	}
+
+	return res
+}
+
+// FromHistogram instantiates a new histogram from the counts and (ascending) dividers
+// as used by stat.Histogram
+func FromHistogram(counts, dividers []float64) (*hist.H1D, error) {
+
+	n := len(counts)
+	if n == 0 || len(dividers) != n+1 {
+		return nil, errors.New("number of dividers must exceed number of counts by one")
+	}
+	for i := 0; i < n; i++ {
+		if !(dividers[i+1] > dividers[i]) {
+			return nil, errors.New("dividers must be strictly ascending")
+		}
+	}
+
+	h := hist.NewH1Edges(dividers)
+	for i, c := range counts {
+		h.SetBinContent(i+1, c)
+	}
+
+	return h, nil
+}
+
+// ContentVec returns the contents of the regular bins of a histogram as vector
+func ContentVec[T hist.Number](h *hist.H1[T]) *mat.VecDense {
+	return mat.NewVecDense(h.NBins(), Counts(h))
+}
+
+// VarianceVec returns the variances of the regular bins of a histogram as vector
+func VarianceVec[T hist.Number](h *hist.H1[T]) *mat.VecDense {
+	res := make([]float64, h.NBins())
+	for i := range res {
+		res[i] = h.BinVariance(i + 1)
+	}
+
+	return mat.NewVecDense(h.NBins(), res)
+}
+
+// SetContentVec sets the contents of the regular bins of a histogram from a vector
+// (whose length must match the number of bins)
+func SetContentVec[T hist.Number](h *hist.H1[T], v mat.Vector) error {
+	if v.Len() != h.NBins() {
+		return errors.New("vector length does not match number of bins")
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		h.SetBinContent(i+1, v.AtVec(i))
+	}
+
+	return nil
+}
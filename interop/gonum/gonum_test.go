@@ -0,0 +1,96 @@
+package gonum
+
+import (
+	"math"
+	"testing"
+
+	"github.com/fako1024/numerics/hist"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+const testEpsilon = 1e-9
+
+var (
+	_ distuv.Quantiler     = &Distribution{}
+	_ distuv.RandLogProber = &Distribution{}
+)
+
+func TestHistogramRoundTrip(t *testing.T) {
+
+	xs := []float64{0.1, 0.2, 0.2, 0.7, 1.5, 1.9, 2.5, 3.9}
+	h := hist.NewH1D(4, 0., 4.)
+	for _, x := range xs {
+		h.Fill(x)
+	}
+
+	counts := stat.Histogram(nil, Dividers(h), xs, nil)
+	for i, c := range Counts(h) {
+		if c != counts[i] {
+			t.Fatalf("Unexpected count in bin %d: want %v, have %v", i+1, counts[i], c)
+		}
+	}
+
+	restored, err := FromHistogram(counts, Dividers(h))
+	if err != nil {
+		t.Fatalf("Unexpected error converting from stat.Histogram: %s", err)
+	}
+	if restored.NBins() != h.NBins() || restored.Sum() != h.Sum() || restored.BinContent(2) != h.BinContent(2) {
+		t.Fatalf("Unexpected restored histogram")
+	}
+
+	varWidth, err := FromHistogram([]float64{1, 2}, []float64{0, 1, 3})
+	if err != nil || varWidth.BinWidth(2) != 2. || varWidth.FindBin(2.5) != 2 {
+		t.Fatalf("Unexpected histogram for non-equidistant dividers (error: %v)", err)
+	}
+	if _, err := FromHistogram([]float64{1, 2}, []float64{0, 1, 1}); err == nil {
+		t.Fatalf("Expected error for non-ascending dividers")
+	}
+
+	vec := ContentVec(h)
+	vec.ScaleVec(2., vec)
+	if err := SetContentVec(h, vec); err != nil || h.Sum() != 2.*float64(len(xs)) {
+		t.Fatalf("Unexpected result of SetContentVec: %v, sum %v", err, h.Sum())
+	}
+}
+
+func TestDistribution(t *testing.T) {
+
+	h := hist.NewH1D(100, -5., 5.)
+	FillExpected(h, distuv.UnitNormal, 1e6)
+	if math.Abs(h.Sum()-1e6) > 1e-6 {
+		t.Fatalf("Unexpected total of expected contents: %v", h.Sum())
+	}
+
+	dist := NewDistribution(h)
+	for _, x := range []float64{-2., -0.5, 0., 1.3} {
+		if cdf := dist.CDF(x); math.Abs(cdf-distuv.UnitNormal.CDF(x)) > 1e-3 {
+			t.Fatalf("Unexpected CDF at x=%.3f: want %.5f, have %.5f", x, distuv.UnitNormal.CDF(x), cdf)
+		}
+		if q := dist.Quantile(dist.CDF(x)); math.Abs(q-x) > testEpsilon {
+			t.Fatalf("Unexpected quantile: want %.5f, have %.5f", x, q)
+		}
+	}
+	if math.Abs(dist.Mean()) > 1e-6 || math.Abs(dist.Variance()-1.) > 1e-2 {
+		t.Fatalf("Unexpected moments: mean %v, variance %v", dist.Mean(), dist.Variance())
+	}
+
+	// Sampling from a dedicated source must be reproducible and allocation-free
+	dist.Src = rand.NewSource(42)
+	ref := NewDistribution(h)
+	ref.Src = rand.NewSource(42)
+	samples := make([]float64, 100000)
+	for i := range samples {
+		samples[i] = dist.Rand()
+		if x := ref.Rand(); x != samples[i] {
+			t.Fatalf("Unexpected sample for identical source: want %v, have %v", samples[i], x)
+		}
+	}
+	if mean, variance := stat.MeanVariance(samples, nil); math.Abs(mean) > 2e-2 || math.Abs(variance-1.) > 2e-2 {
+		t.Fatalf("Unexpected sample moments: mean %v, variance %v", mean, variance)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { dist.Rand() }); allocs != 0 {
+		t.Fatalf("Unexpected number of allocations per sample: %v", allocs)
+	}
+}
@@ -0,0 +1,151 @@
+package hbook
+
+import (
+	"errors"
+	"math"
+
+	"github.com/fako1024/numerics/hist"
+	"go-hep.org/x/hep/hbook"
+)
+
+// ToHBook2D converts a two-dimensional histogram to a go-hep histogram. The under- /
+// overflow bins are aggregated into the eight outflow regions of go-hep (N, NE, E, ...).
+// If no variance is recorded for a bin, unit weights (i.e. Poisson statistics) are assumed
+func ToHBook2D[T hist.Number](h *hist.H2[T]) *hbook.H2D {
+
+	nx, ny := h.NBinsX(), h.NBinsY()
+	res := hbook.NewH2D(nx, float64(h.XMin()), float64(h.XMax()), ny, float64(h.YMin()), float64(h.YMax()))
+	bng := &res.Binning
+
+	for ix := 0; ix <= nx+1; ix++ {
+		for iy := 0; iy <= ny+1; iy++ {
+			d := dist2D(h, ix, iy)
+			addDist2D(&bng.Dist, d)
+
+			switch region := outflowRegion(ix, iy, nx, ny); region {
+			case 0:
+				bng.Bins[(iy-1)*nx+ix-1].Dist = d
+			default:
+				addDist2D(&bng.Outflows[region-1], d)
+			}
+		}
+	}
+
+	return res
+}
+
+// FromHBook2D converts a go-hep histogram (with equidistant bins) to a two-dimensional
+// histogram. Since go-hep only tracks the aggregated outflow per region, only the corner
+// outflow regions (e.g. underflow in both x and y) can be restored, while the contents
+// of the outflow regions along the sides are not retained
+func FromHBook2D(h *hbook.H2D) (*hist.H2D, error) {
+
+	bng := &h.Binning
+	nx, ny := bng.Nx, bng.Ny
+	if nx == 0 || ny == 0 {
+		return nil, errors.New("histogram has no bins")
+	}
+	if !isEquidistant(bng.XEdges) || !isEquidistant(bng.YEdges) {
+		return nil, errors.New("histogram has non-equidistant bins")
+	}
+
+	res := hist.NewH2D(nx, bng.XRange.Min, bng.XRange.Max, ny, bng.YRange.Min, bng.YRange.Max)
+	for ix := 1; ix <= nx; ix++ {
+		for iy := 1; iy <= ny; iy++ {
+			d := bng.Bins[(iy-1)*nx+ix-1].Dist
+			res.SetBinContent(ix, iy, d.SumW())
+			res.SetBinVariance(ix, iy, d.SumW2())
+		}
+	}
+	for _, corner := range [][2]int{{0, 0}, {0, ny + 1}, {nx + 1, 0}, {nx + 1, ny + 1}} {
+		d := bng.Outflows[outflowRegion(corner[0], corner[1], nx, ny)-1]
+		res.SetBinContent(corner[0], corner[1], d.SumW())
+		res.SetBinVariance(corner[0], corner[1], d.SumW2())
+	}
+	res.SetNEntries(int(h.Entries()))
+
+	return res, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// outflowRegion returns the go-hep outflow region (BngNW, BngN, ...) of a bin, or zero for
+// a regular bin
+func outflowRegion(ix, iy, nx, ny int) int {
+	var (
+		west, east   = ix == 0, ix == nx+1
+		south, north = iy == 0, iy == ny+1
+	)
+
+	switch {
+	case north && west:
+		return hbook.BngNW
+	case north && east:
+		return hbook.BngNE
+	case south && west:
+		return hbook.BngSW
+	case south && east:
+		return hbook.BngSE
+	case north:
+		return hbook.BngN
+	case south:
+		return hbook.BngS
+	case west:
+		return hbook.BngW
+	case east:
+		return hbook.BngE
+	}
+
+	return 0
+}
+
+func dist2D[T hist.Number](h *hist.H2[T], ix, iy int) hbook.Dist2D {
+
+	// Flow bins are represented by the respective boundary of the axis
+	x := binCoord(float64(h.XMin()), float64(h.XMax()), h.NBinsX(), ix)
+	y := binCoord(float64(h.YMin()), float64(h.YMax()), h.NBinsY(), iy)
+
+	sumW, sumW2 := h.BinContent(ix, iy), h.BinVariance(ix, iy)
+	if sumW2 == 0 {
+		sumW2 = math.Abs(sumW)
+	}
+
+	var res hbook.Dist2D
+	for _, d := range []*hbook.Dist1D{&res.X, &res.Y} {
+		d.Dist.SumW = sumW
+		d.Dist.SumW2 = sumW2
+		if sumW2 > 0 {
+			d.Dist.N = int64(math.Round(sumW * sumW / sumW2))
+		}
+	}
+	res.X.Stats.SumWX = sumW * x
+	res.X.Stats.SumWX2 = sumW * x * x
+	res.Y.Stats.SumWX = sumW * y
+	res.Y.Stats.SumWX2 = sumW * y * y
+	res.Stats.SumWXY = sumW * x * y
+
+	return res
+}
+
+// binCoord returns the center of a bin on an equidistant axis (clamped to its boundaries
+// for the flow bins)
+func binCoord(min, max float64, n, bin int) float64 {
+	return math.Min(math.Max(min+(float64(bin)-0.5)*(max-min)/float64(n), min), max)
+}
+
+func addDist2D(dst *hbook.Dist2D, src hbook.Dist2D) {
+	addDist(&dst.X, src.X)
+	addDist(&dst.Y, src.Y)
+	dst.Stats.SumWXY += src.Stats.SumWXY
+}
+
+func isEquidistant(bins []hbook.Bin1D) bool {
+	width := bins[0].Range.Width()
+	for _, bin := range bins {
+		if math.Abs(bin.Range.Width()-width) > 1e-9*math.Abs(width) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,99 @@
+// Package hbook provides conversion helpers between the histograms of this module and
+// the histograms of go-hep (https://go-hep.org/x/hep/hbook), allowing to use its plotting
+// (hplot) and I/O (rio) ecosystems
+package hbook
+
+import (
+	"errors"
+	"math"
+
+	"github.com/fako1024/numerics/hist"
+	"go-hep.org/x/hep/hbook"
+)
+
+// ToHBook converts a histogram to a go-hep histogram, including under- / overflow. Since
+// the histogram only tracks the overall number of entries, the number of entries per bin
+// is estimated from its effective number of entries. If no variance is recorded for a bin,
+// unit weights (i.e. Poisson statistics) are assumed
+func ToHBook[T hist.Number](h *hist.H1[T]) *hbook.H1D {
+
+	n := h.NBins()
+	edges := make([]float64, n+1)
+	for i := 0; i < n; i++ {
+		edges[i] = float64(h.BinLowEdge(i + 1))
+	}
+	edges[n] = float64(h.BinUpEdge(n))
+
+	res := hbook.NewH1DFromEdges(edges)
+	bng := &res.Binning
+
+	for i := 0; i < n; i++ {
+		bng.Bins[i].Dist = dist(h, i+1, h.BinCenter(i+1))
+		addDist(&bng.Dist, bng.Bins[i].Dist)
+	}
+	bng.Outflows[0] = dist(h, 0, edges[0])
+	bng.Outflows[1] = dist(h, n+1, edges[n])
+	addDist(&bng.Dist, bng.Outflows[0])
+	addDist(&bng.Dist, bng.Outflows[1])
+
+	return res
+}
+
+// FromHBook converts a go-hep histogram to a histogram, including
+// under- / overflow
+func FromHBook(h *hbook.H1D) (*hist.H1D, error) {
+
+	bins := h.Binning.Bins
+	n := len(bins)
+	if n == 0 {
+		return nil, errors.New("histogram has no bins")
+	}
+
+	edges := make([]float64, n+1)
+	for i, bin := range bins {
+		edges[i] = bin.Range.Min
+	}
+	edges[n] = bins[n-1].Range.Max
+
+	res := hist.NewH1Edges(edges)
+	for i, bin := range bins {
+		res.SetBinContent(i+1, bin.Dist.Dist.SumW)
+		res.SetBinVariance(i+1, bin.Dist.Dist.SumW2)
+	}
+	res.SetBinContent(0, h.Binning.Outflows[0].Dist.SumW)
+	res.SetBinVariance(0, h.Binning.Outflows[0].Dist.SumW2)
+	res.SetBinContent(n+1, h.Binning.Outflows[1].Dist.SumW)
+	res.SetBinVariance(n+1, h.Binning.Outflows[1].Dist.SumW2)
+	res.SetNEntries(int(h.Entries()))
+
+	return res, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func dist[T hist.Number](h *hist.H1[T], bin int, x float64) hbook.Dist1D {
+
+	sumW, sumW2 := h.BinContent(bin), h.BinVariance(bin)
+	if sumW2 == 0 {
+		sumW2 = math.Abs(sumW)
+	}
+
+	var res hbook.Dist1D
+	res.Dist.SumW = sumW
+	res.Dist.SumW2 = sumW2
+	if sumW2 > 0 {
+		res.Dist.N = int64(math.Round(sumW * sumW / sumW2))
+	}
+	res.Stats.SumWX = sumW * x
+	res.Stats.SumWX2 = sumW * x * x
+
+	return res
+}
+
+func addDist(dst *hbook.Dist1D, src hbook.Dist1D) {
+	dst.Dist.N += src.Dist.N
+	dst.Dist.SumW += src.Dist.SumW
+	dst.Dist.SumW2 += src.Dist.SumW2
+	dst.Stats.SumWX += src.Stats.SumWX
+	dst.Stats.SumWX2 += src.Stats.SumWX2
+}
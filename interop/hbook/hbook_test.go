@@ -0,0 +1,119 @@
+package hbook
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/fako1024/numerics/hist"
+	"go-hep.org/x/hep/hbook"
+)
+
+func TestRoundTrip(t *testing.T) {
+
+	h := hist.NewH1D(10, 0., 5.)
+	for _, x := range []float64{-1., 0.2, 0.3, 1.1, 2.5, 2.6, 2.7, 4.9, 6.} {
+		h.Fill(x)
+	}
+
+	hb := ToHBook(h)
+	if hb.Len() != h.NBins() || hb.XMin() != h.XMin() || hb.XMax() != h.XMax() {
+		t.Fatalf("Unexpected binning of converted histogram")
+	}
+	if hb.SumW() != h.Sum() || hb.Entries() != int64(h.NEntries()) {
+		t.Fatalf("Unexpected totals of converted histogram: have %v / %d", hb.SumW(), hb.Entries())
+	}
+	for i := 0; i < h.NBins(); i++ {
+		if hb.Value(i) != h.BinContent(i+1) {
+			t.Fatalf("Unexpected content of converted bin %d: want %v, have %v", i+1, h.BinContent(i+1), hb.Value(i))
+		}
+	}
+
+	restored, err := FromHBook(hb)
+	if err != nil {
+		t.Fatalf("Unexpected error converting from go-hep histogram: %s", err)
+	}
+	for i := 0; i < h.NBins()+2; i++ {
+		if restored.BinContent(i) != h.BinContent(i) {
+			t.Fatalf("Unexpected content of restored bin %d: want %v, have %v", i, h.BinContent(i), restored.BinContent(i))
+		}
+	}
+	if restored.NEntries() != h.NEntries() || math.Abs(restored.Sum()-h.Sum()) > 1e-12 {
+		t.Fatalf("Unexpected totals of restored histogram")
+	}
+
+	hbVarWidth := hbook.NewH1DFromEdges([]float64{0, 1, 3})
+	hbVarWidth.Fill(2., 1.)
+	varWidth, err := FromHBook(hbVarWidth)
+	if err != nil || varWidth.BinWidth(2) != 2. || varWidth.BinContent(2) != 1. {
+		t.Fatalf("Unexpected histogram for non-equidistant bins (error: %v)", err)
+	}
+	if restored := ToHBook(varWidth); restored.Binning.Bins[1].Range.Max != 3. {
+		t.Fatalf("Unexpected bins after round trip of non-equidistant histogram")
+	}
+}
+
+func TestRoundTrip2D(t *testing.T) {
+
+	h := hist.NewH2D(4, 0., 4., 2, 0., 2.)
+	for _, p := range [][2]float64{{0.5, 0.5}, {1.5, 1.5}, {1.5, 1.5}, {3.5, 0.5}, {-1., -1.}, {5., 1.}, {5., 3.}} {
+		h.Fill(p[0], p[1])
+	}
+
+	hb := ToHBook2D(h)
+	if hb.Entries() != int64(h.NEntries()) || hb.SumW() != h.Sum() {
+		t.Fatalf("Unexpected totals of converted histogram: have %v / %d", hb.SumW(), hb.Entries())
+	}
+	if bin := hb.Bin(1.5, 1.5); bin == nil || bin.SumW() != 2. {
+		t.Fatalf("Unexpected content of converted bin")
+	}
+	if hb.Binning.Outflows[hbook.BngSW-1].SumW() != 1. || hb.Binning.Outflows[hbook.BngE-1].SumW() != 1. || hb.Binning.Outflows[hbook.BngNE-1].SumW() != 1. {
+		t.Fatalf("Unexpected outflows of converted histogram")
+	}
+
+	restored, err := FromHBook2D(hb)
+	if err != nil {
+		t.Fatalf("Unexpected error converting from go-hep histogram: %s", err)
+	}
+	for ix := 1; ix <= h.NBinsX(); ix++ {
+		for iy := 1; iy <= h.NBinsY(); iy++ {
+			if restored.BinContent(ix, iy) != h.BinContent(ix, iy) {
+				t.Fatalf("Unexpected content of restored bin %d / %d", ix, iy)
+			}
+		}
+	}
+	if restored.BinContent(0, 0) != 1. || restored.BinContent(5, 3) != 1. || restored.BinContent(5, 1) != 0. || restored.NEntries() != h.NEntries() {
+		t.Fatalf("Unexpected flow bins of restored histogram")
+	}
+
+	if _, err := FromHBook2D(hbook.NewH2DFromEdges([]float64{0, 1, 3}, []float64{0, 1})); err == nil {
+		t.Fatalf("Expected error for non-equidistant bins")
+	}
+}
+
+func TestYODA(t *testing.T) {
+
+	h := hist.NewH1D(5, 0., 5.)
+	for _, x := range []float64{-1., 0.5, 1.5, 1.5, 4.5, 10.} {
+		h.Fill(x)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := WriteYODA(buf, h, "test/h1"); err != nil {
+		t.Fatalf("Failed to write YODA: %s", err)
+	}
+	if !strings.Contains(buf.String(), "BEGIN YODA_HISTO1D_V2 /test/h1") {
+		t.Fatalf("Unexpected YODA output:\n%s", buf.String())
+	}
+
+	restored, name, err := ReadYODA(buf)
+	if err != nil || name != "test/h1" {
+		t.Fatalf("Failed to read YODA (name %q): %s", name, err)
+	}
+	for i := 0; i < h.NBins()+2; i++ {
+		if restored.BinContent(i) != h.BinContent(i) {
+			t.Fatalf("Unexpected content of restored bin %d: want %v, have %v", i, h.BinContent(i), restored.BinContent(i))
+		}
+	}
+}
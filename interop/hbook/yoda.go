@@ -0,0 +1,45 @@
+package hbook
+
+import (
+	"io"
+
+	"github.com/fako1024/numerics/hist"
+	"go-hep.org/x/hep/hbook"
+)
+
+// WriteYODA writes a histogram in the YODA text format (as used by e.g. Rivet) to any
+// io.Writer, using the provided name as path of the object
+func WriteYODA[T hist.Number](w io.Writer, h *hist.H1[T], name string) error {
+
+	hb := ToHBook(h)
+	hb.Annotation()["name"] = name
+
+	data, err := hb.MarshalYODA()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+
+	return err
+}
+
+// ReadYODA reads a histogram from a single object in the YODA text format, returning the
+// histogram and its name
+func ReadYODA(r io.Reader) (*hist.H1D, string, error) {
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var hb hbook.H1D
+	if err := hb.UnmarshalYODA(data); err != nil {
+		return nil, "", err
+	}
+	res, err := FromHBook(&hb)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return res, hb.Name(), nil
+}
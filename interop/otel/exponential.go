@@ -0,0 +1,155 @@
+package otel
+
+import (
+	"math"
+	"time"
+
+	"github.com/fako1024/numerics/hist"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+
+	// maxScale denotes the maximum scale of an exponential histogram supported by
+	// OpenTelemetry
+	maxScale = 20
+
+	// maxBuckets denotes the maximum number of exponential buckets per data point (matching
+	// the default of the OpenTelemetry SDK)
+	maxBuckets = 160
+)
+
+// exponentialData denotes the buckets of an exponential histogram in OpenTelemetry
+// semantics, i.e. (positive) bucket i covering (base^(offset+i), base^(offset+i+1)] with
+// base = 2^(2^-scale)
+type exponentialData struct {
+	scale     int32
+	offset    int32
+	counts    []uint64
+	zeroCount uint64
+
+	count    uint64
+	sum      float64
+	min, max float64
+	extrema  bool
+}
+
+// exponentialBuckets converts a high dynamic range histogram into OpenTelemetry exponential
+// buckets. The scale is chosen such that the relative width of the exponential buckets does
+// not exceed the relative precision of the histogram, reduced (as done by the OpenTelemetry
+// SDK) until the recorded range is covered by at most maxBuckets buckets. Each bucket of the
+// histogram is assigned to the exponential bucket containing its center (values recorded as
+// zero to the zero bucket and the overflow to the bucket containing the maximum recorded
+// duration)
+func exponentialBuckets(h *hist.HDR) exponentialData {
+
+	res := exponentialData{
+		scale: int32(min(maxScale, math.Ceil(float64(h.SignificantDigits())*math.Log2(10.))+1)),
+		count: h.Count(),
+		sum:   h.Sum(),
+	}
+	if res.count > 0 {
+		res.min, res.max, res.extrema = float64(h.Min()), float64(h.Max()), true
+	}
+
+	var indices []int32
+	var counts []uint64
+	add := func(v float64, count uint64) {
+		if v <= 0 {
+			res.zeroCount += count
+			return
+		}
+		indices, counts = append(indices, res.index(v)), append(counts, count)
+	}
+
+	h.VisitBuckets(func(low, width time.Duration, count uint64) {
+		add(float64(low+width/2), count)
+	})
+	if overflow := h.Overflow(); overflow > 0 {
+		add(float64(h.Max()), overflow)
+	}
+
+	if len(indices) > 0 {
+
+		// Downscale until the range fits, each step merging pairs of adjacent buckets
+		for res.scale > -10 && indices[len(indices)-1]-indices[0] >= maxBuckets {
+			res.scale--
+			for i := range indices {
+				indices[i] >>= 1
+			}
+		}
+
+		res.offset = indices[0]
+		res.counts = make([]uint64, indices[len(indices)-1]-res.offset+1)
+		for i, idx := range indices {
+			res.counts[idx-res.offset] += counts[i]
+		}
+	}
+
+	return res
+}
+
+// index returns the index of the exponential bucket containing a (positive) value
+func (e exponentialData) index(v float64) int32 {
+	return int32(math.Ceil(math.Log2(v)*math.Exp2(float64(e.scale)))) - 1
+}
+
+// aggregation converts the buckets into an OpenTelemetry exponential histogram
+func (e exponentialData) aggregation(attrs attribute.Set, startTime, now time.Time, temporality metricdata.Temporality) metricdata.Aggregation {
+
+	dp := metricdata.ExponentialHistogramDataPoint[float64]{
+		Attributes: attrs,
+		StartTime:  startTime,
+		Time:       now,
+		Count:      e.count,
+		Sum:        e.sum,
+		Scale:      e.scale,
+		ZeroCount:  e.zeroCount,
+		PositiveBucket: metricdata.ExponentialBucket{
+			Offset: e.offset,
+			Counts: e.counts,
+		},
+	}
+	if e.extrema {
+		dp.Min, dp.Max = metricdata.NewExtrema(e.min), metricdata.NewExtrema(e.max)
+	}
+
+	return metricdata.ExponentialHistogram[float64]{
+		Temporality: temporality,
+		DataPoints:  []metricdata.ExponentialHistogramDataPoint[float64]{dp},
+	}
+}
+
+// delta computes the change of the buckets since a previous collection. If any count
+// decreased (e.g. due to a reset of the histogram) or the scale changed, the full current
+// data is reported. Since the extrema of the change are unknown, they are omitted
+func (e exponentialData) delta(prevSnapshot snapshot) snapshot {
+
+	prev, ok := prevSnapshot.(exponentialData)
+	if !ok || prev.scale != e.scale || prev.count > e.count || prev.zeroCount > e.zeroCount {
+		return e
+	}
+
+	res := exponentialData{
+		scale:     e.scale,
+		offset:    e.offset,
+		counts:    make([]uint64, len(e.counts)),
+		zeroCount: e.zeroCount - prev.zeroCount,
+		count:     e.count - prev.count,
+		sum:       e.sum - prev.sum,
+	}
+	copy(res.counts, e.counts)
+	for i, count := range prev.counts {
+		j := int(prev.offset) + i - int(e.offset)
+		if count == 0 {
+			continue
+		}
+		if j < 0 || j >= len(res.counts) || res.counts[j] < count {
+			return e
+		}
+		res.counts[j] -= count
+	}
+
+	return res
+}
@@ -0,0 +1,230 @@
+// Package otel provides a bridge exposing the histograms of this module as OpenTelemetry
+// (https://opentelemetry.io) metrics, converting them into metric data points whenever a
+// reader collects metrics
+package otel
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/fako1024/numerics/hist"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Producer denotes a bridge between histograms and an OpenTelemetry metric reader. It
+// implements metric.Producer and must be registered via metric.WithProducer()
+type Producer struct {
+	scope       instrumentation.Scope
+	temporality metricdata.Temporality
+
+	registrations []*registration
+	mu            sync.Mutex
+}
+
+// registration denotes a single histogram registered with a Producer
+type registration struct {
+	name, description, unit string
+	attrs                   attribute.Set
+
+	collect func() snapshot
+
+	startTime time.Time
+	prev      snapshot
+}
+
+// snapshot denotes the state of a registered histogram at the time of a collection
+type snapshot interface {
+
+	// delta computes the change since a previous collection (if any)
+	delta(prev snapshot) snapshot
+
+	// aggregation converts the snapshot into an OpenTelemetry aggregation
+	aggregation(attrs attribute.Set, startTime, now time.Time, temporality metricdata.Temporality) metricdata.Aggregation
+}
+
+// bucketData denotes the bucket boundaries and counts of a histogram in OpenTelemetry
+// semantics, i.e. bucket i covering (bounds[i-1], bounds[i]]
+type bucketData struct {
+	bounds []float64
+	counts []uint64
+	sum    float64
+}
+
+var _ metric.Producer = &Producer{}
+
+// NewProducer instantiates a new bridge for the given instrumentation scope, reporting
+// all registered histograms with the given temporality
+func NewProducer(scope string, temporality metricdata.Temporality) *Producer {
+	return &Producer{
+		scope:       instrumentation.Scope{Name: scope},
+		temporality: temporality,
+	}
+}
+
+// Register registers a histogram with the bridge. Since the histogram is read on each
+// collection, the caller must ensure that it is not modified concurrently (e.g. by using
+// a hist.Var and RegisterVar instead)
+func Register[T hist.Number](p *Producer, name, description, unit string, h *hist.H1[T], attrs ...attribute.KeyValue) {
+	p.register(name, description, unit, attrs, func() snapshot {
+		return buckets(h)
+	})
+}
+
+// RegisterVar registers a histogram published via expvar with the bridge, reading it
+// under its lock on each collection
+func RegisterVar[T hist.Number](p *Producer, name, description, unit string, v *hist.Var[T], attrs ...attribute.KeyValue) {
+	p.register(name, description, unit, attrs, func() (res snapshot) {
+		v.View(func(h *hist.H1[T]) {
+			res = buckets(h)
+		})
+		return
+	})
+}
+
+// RegisterHDR registers a high dynamic range histogram with the bridge, reported as an
+// OpenTelemetry exponential histogram of durations in nanoseconds (see exponentialBuckets).
+// Since the histogram is read on each collection, the caller must ensure that it is not
+// modified concurrently
+func RegisterHDR(p *Producer, name, description, unit string, h *hist.HDR, attrs ...attribute.KeyValue) {
+	p.register(name, description, unit, attrs, func() snapshot {
+		return exponentialBuckets(h)
+	})
+}
+
+// Produce implements metric.Producer, converting all registered histograms into metric
+// data points
+func (p *Producer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	res := metricdata.ScopeMetrics{
+		Scope:   p.scope,
+		Metrics: make([]metricdata.Metrics, 0, len(p.registrations)),
+	}
+
+	for _, r := range p.registrations {
+		current := r.collect()
+		data, startTime := current, r.startTime
+
+		if p.temporality == metricdata.DeltaTemporality {
+			data = current.delta(r.prev)
+			r.prev, r.startTime = current, now
+		}
+
+		res.Metrics = append(res.Metrics, metricdata.Metrics{
+			Name:        r.name,
+			Description: r.description,
+			Unit:        r.unit,
+			Data:        data.aggregation(r.attrs, startTime, now, p.temporality),
+		})
+	}
+
+	return []metricdata.ScopeMetrics{res}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (p *Producer) register(name, description, unit string, attrs []attribute.KeyValue, collect func() snapshot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.registrations = append(p.registrations, &registration{
+		name:        name,
+		description: description,
+		unit:        unit,
+		attrs:       attribute.NewSet(attrs...),
+		collect:     collect,
+		startTime:   time.Now(),
+	})
+}
+
+// buckets converts a histogram into OpenTelemetry buckets. The underflow and overflow bins
+// map onto the first / last (unbounded) bucket, respectively. Since OpenTelemetry requires
+// integer counts, bin contents are rounded and the sum is estimated from the bin centers
+func buckets[T hist.Number](h *hist.H1[T]) bucketData {
+
+	n := h.NBins()
+	res := bucketData{
+		bounds: make([]float64, n+1),
+		counts: make([]uint64, n+2),
+	}
+
+	for bin := 0; bin <= n+1; bin++ {
+		content := math.Max(0., h.BinContent(bin))
+		res.counts[bin] = uint64(math.Round(content))
+
+		switch bin {
+		case 0:
+			res.bounds[0] = float64(h.BinLowEdge(1))
+			res.sum += content * res.bounds[0]
+		case n + 1:
+			res.sum += content * float64(h.BinUpEdge(n))
+		default:
+			res.bounds[bin] = float64(h.BinUpEdge(bin))
+			res.sum += content * h.BinCenter(bin)
+		}
+	}
+
+	return res
+}
+
+// aggregation converts the buckets into an OpenTelemetry histogram
+func (b bucketData) aggregation(attrs attribute.Set, startTime, now time.Time, temporality metricdata.Temporality) metricdata.Aggregation {
+
+	count := uint64(0)
+	for _, c := range b.counts {
+		count += c
+	}
+
+	return metricdata.Histogram[float64]{
+		Temporality: temporality,
+		DataPoints: []metricdata.HistogramDataPoint[float64]{{
+			Attributes:   attrs,
+			StartTime:    startTime,
+			Time:         now,
+			Count:        count,
+			Bounds:       b.bounds,
+			BucketCounts: b.counts,
+			Sum:          b.sum,
+		}},
+	}
+}
+
+// delta computes the change of the buckets since a previous collection. If the binning
+// changed or any count decreased (e.g. due to a reset of the histogram), the full current
+// data is reported
+func (b bucketData) delta(prevSnapshot snapshot) snapshot {
+
+	prev, ok := prevSnapshot.(bucketData)
+	if !ok || len(prev.bounds) != len(b.bounds) {
+		return b
+	}
+	for i := range b.bounds {
+		if b.bounds[i] != prev.bounds[i] {
+			return b
+		}
+	}
+	for i := range b.counts {
+		if b.counts[i] < prev.counts[i] {
+			return b
+		}
+	}
+
+	res := bucketData{
+		bounds: b.bounds,
+		counts: make([]uint64, len(b.counts)),
+		sum:    b.sum - prev.sum,
+	}
+	for i := range b.counts {
+		res.counts[i] = b.counts[i] - prev.counts[i]
+	}
+
+	return res
+}
@@ -0,0 +1,137 @@
+package otel
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/fako1024/numerics/hist"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestProducer(t *testing.T) {
+
+	for _, temporality := range []metricdata.Temporality{metricdata.CumulativeTemporality, metricdata.DeltaTemporality} {
+		t.Run(temporality.String(), func(t *testing.T) {
+
+			h := hist.NewH1D(4, 0., 4.)
+			producer := NewProducer("test", temporality)
+			Register(producer, "latency", "test histogram", "s", h, attribute.String("key", "value"))
+			reader := metric.NewManualReader(metric.WithProducer(producer))
+			_ = metric.NewMeterProvider(metric.WithReader(reader))
+
+			for _, x := range []float64{-1., 0.5, 1.5, 1.7, 5.} {
+				h.Fill(x)
+			}
+			dp := collect(t, reader)
+			if dp.Count != 5 || len(dp.Bounds) != 5 || len(dp.BucketCounts) != 6 {
+				t.Fatalf("Unexpected data point: %+v", dp)
+			}
+			if dp.BucketCounts[0] != 1 || dp.BucketCounts[2] != 2 || dp.BucketCounts[5] != 1 {
+				t.Fatalf("Unexpected bucket counts: %v", dp.BucketCounts)
+			}
+
+			h.Fill(2.5)
+			dp = collect(t, reader)
+			expectedCount := uint64(6)
+			if temporality == metricdata.DeltaTemporality {
+				expectedCount = 1
+			}
+			if dp.Count != expectedCount || dp.BucketCounts[3] != 1 {
+				t.Fatalf("Unexpected data point after second collection: %+v", dp)
+			}
+		})
+	}
+}
+
+func TestProducerHDR(t *testing.T) {
+
+	for _, temporality := range []metricdata.Temporality{metricdata.CumulativeTemporality, metricdata.DeltaTemporality} {
+		t.Run(temporality.String(), func(t *testing.T) {
+
+			h := hist.NewHDR(time.Minute, 3)
+			producer := NewProducer("test", temporality)
+			RegisterHDR(producer, "latency", "test histogram", "ns", h)
+			reader := metric.NewManualReader(metric.WithProducer(producer))
+			_ = metric.NewMeterProvider(metric.WithReader(reader))
+
+			h.Record(0)
+			h.RecordN(time.Millisecond, 3)
+			h.Record(7 * time.Microsecond)
+			h.Record(time.Hour)
+			dp := collectExponential(t, reader)
+			if dp.Count != 6 || dp.ZeroCount != 1 || dp.Scale > 11 || len(dp.PositiveBucket.Counts) > 160 || dp.Sum != h.Sum() {
+				t.Fatalf("Unexpected data point: %+v", dp)
+			}
+			if v, ok := dp.Max.Value(); !ok || v != float64(time.Hour) {
+				t.Fatalf("Unexpected maximum: %v", v)
+			}
+
+			// Verify that each value is contained in its exponential bucket
+			base := math.Exp2(math.Exp2(-float64(dp.Scale)))
+			contained := func(v float64) uint64 {
+				idx := int(math.Ceil(math.Log(v)/math.Log(base))) - 1 - int(dp.PositiveBucket.Offset)
+				if idx < 0 || idx >= len(dp.PositiveBucket.Counts) {
+					return 0
+				}
+				return dp.PositiveBucket.Counts[idx]
+			}
+			var total uint64
+			for _, c := range dp.PositiveBucket.Counts {
+				total += c
+			}
+			if total+dp.ZeroCount != dp.Count || contained(float64(time.Millisecond)) != 3 || contained(float64(7*time.Microsecond)) != 1 || contained(float64(time.Hour)) != 1 {
+				t.Fatalf("Unexpected bucket counts: %+v", dp.PositiveBucket)
+			}
+
+			h.Record(time.Second)
+			dp = collectExponential(t, reader)
+			expectedCount := uint64(7)
+			if temporality == metricdata.DeltaTemporality {
+				expectedCount = 1
+			}
+			if dp.Count != expectedCount || contained(float64(time.Second)) != 1 {
+				t.Fatalf("Unexpected data point after second collection: %+v", dp)
+			}
+		})
+	}
+}
+
+func collectExponential(t *testing.T, reader *metric.ManualReader) metricdata.ExponentialHistogramDataPoint[float64] {
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Unexpected error collecting metrics: %s", err)
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "latency" {
+				return m.Data.(metricdata.ExponentialHistogram[float64]).DataPoints[0]
+			}
+		}
+	}
+
+	t.Fatalf("Histogram not found in collected metrics")
+	return metricdata.ExponentialHistogramDataPoint[float64]{}
+}
+
+func collect(t *testing.T, reader *metric.ManualReader) metricdata.HistogramDataPoint[float64] {
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Unexpected error collecting metrics: %s", err)
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "latency" {
+				return m.Data.(metricdata.Histogram[float64]).DataPoints[0]
+			}
+		}
+	}
+
+	t.Fatalf("Histogram not found in collected metrics")
+	return metricdata.HistogramDataPoint[float64]{}
+}
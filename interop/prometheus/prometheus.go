@@ -0,0 +1,204 @@
+// Package prometheus provides a bridge exposing the histograms of this module in the
+// Prometheus (https://prometheus.io) text exposition format, i.e. as cumulative buckets
+// (with `le` semantics) plus `_sum` and `_count`, retaining the full binning of the
+// histograms instead of a fixed set of buckets
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fako1024/numerics/hist"
+)
+
+// ContentType denotes the content type of the text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Label denotes a label (name / value pair) attached to a histogram
+type Label struct {
+	Name, Value string
+}
+
+// Exporter denotes a bridge between histograms and a Prometheus scraper. It implements
+// http.Handler, serving all registered histograms on each scrape
+type Exporter struct {
+	registrations []*registration
+	mu            sync.Mutex
+}
+
+// registration denotes a single histogram registered with an Exporter
+type registration struct {
+	name, help string
+	labels     []Label
+
+	collect func() bucketData
+}
+
+// bucketData denotes the cumulative buckets of a histogram in Prometheus semantics, i.e.
+// bucket i counting all values <= bounds[i]
+type bucketData struct {
+	bounds []float64
+	counts []float64
+	sum    float64
+}
+
+// NewExporter instantiates a new (empty) bridge
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+// Register registers a histogram with the bridge. Since the histogram is read on each
+// scrape, the caller must ensure that it is not modified concurrently (e.g. by using a
+// hist.Var and RegisterVar instead)
+func Register[T hist.Number](e *Exporter, name, help string, h *hist.H1[T], labels ...Label) {
+	e.register(name, help, labels, func() bucketData {
+		return buckets(h)
+	})
+}
+
+// RegisterVar registers a histogram published via expvar with the bridge, reading it
+// under its lock on each scrape
+func RegisterVar[T hist.Number](e *Exporter, name, help string, v *hist.Var[T], labels ...Label) {
+	e.register(name, help, labels, func() (res bucketData) {
+		v.View(func(h *hist.H1[T]) {
+			res = buckets(h)
+		})
+		return
+	})
+}
+
+// Write writes all registered histograms in the text exposition format to any io.Writer,
+// grouped by metric name (in the order of their first registration)
+func (e *Exporter) Write(w io.Writer) error {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	described := make(map[string]struct{})
+	for _, r := range e.registrations {
+
+		// Metadata must only be written once per metric name
+		if _, exists := described[r.name]; !exists {
+			described[r.name] = struct{}{}
+			fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s histogram\n", r.name, escape(r.help, false), r.name)
+		}
+
+		data := r.collect()
+		for i, bound := range data.bounds {
+			fmt.Fprintf(bw, "%s_bucket%s %s\n", r.name, fmtLabels(r.labels, fmtFloat(bound)), fmtFloat(data.counts[i]))
+		}
+		fmt.Fprintf(bw, "%s_sum%s %s\n", r.name, fmtLabels(r.labels, ""), fmtFloat(data.sum))
+		fmt.Fprintf(bw, "%s_count%s %s\n", r.name, fmtLabels(r.labels, ""), fmtFloat(data.counts[len(data.counts)-1]))
+	}
+
+	return bw.Flush()
+}
+
+// ServeHTTP implements http.Handler, serving all registered histograms
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	if err := e.Write(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (e *Exporter) register(name, help string, labels []Label, collect func() bucketData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Keep registrations of the same metric name adjacent, since the exposition format
+	// requires all samples of a metric family to be written as a single group
+	idx := len(e.registrations)
+	for i, r := range e.registrations {
+		if r.name == name {
+			idx = i + 1
+		}
+	}
+	e.registrations = slices.Insert(e.registrations, idx, &registration{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		collect: collect,
+	})
+}
+
+// buckets converts a histogram into cumulative Prometheus buckets. The underflow bin maps
+// onto a bucket bounded by the lower edge of the x axis, the overflow bin onto the +Inf
+// bucket. Since the histogram does not track the sum of values, it is estimated from the
+// bin centers
+func buckets[T hist.Number](h *hist.H1[T]) bucketData {
+
+	n := h.NBins()
+	res := bucketData{
+		bounds: make([]float64, n+2),
+		counts: make([]float64, n+2),
+	}
+
+	cumulative := 0.
+	for bin := 0; bin <= n+1; bin++ {
+		content := math.Max(0., h.BinContent(bin))
+		cumulative += content
+		res.counts[bin] = cumulative
+
+		switch bin {
+		case 0:
+			res.bounds[0] = float64(h.BinLowEdge(1))
+			res.sum += content * res.bounds[0]
+		case n + 1:
+			res.bounds[n+1] = math.Inf(1)
+			res.sum += content * float64(h.BinUpEdge(n))
+		default:
+			res.bounds[bin] = float64(h.BinUpEdge(bin))
+			res.sum += content * h.BinCenter(bin)
+		}
+	}
+
+	return res
+}
+
+// fmtLabels formats the labels (plus the `le` label, if not empty) of a sample
+func fmtLabels(labels []Label, le string) string {
+	if len(labels) == 0 && le == "" {
+		return ""
+	}
+
+	parts := make([]string, 0, len(labels)+1)
+	for _, label := range labels {
+		parts = append(parts, label.Name+`="`+escape(label.Value, true)+`"`)
+	}
+	if le != "" {
+		parts = append(parts, `le="`+le+`"`)
+	}
+
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func fmtFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// escape escapes backslashes and line feeds (and double quotes in label values)
+func escape(s string, quotes bool) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	if quotes {
+		s = strings.ReplaceAll(s, `"`, `\"`)
+	}
+	return s
+}
@@ -0,0 +1,57 @@
+package prometheus
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fako1024/numerics/hist"
+)
+
+func TestExporter(t *testing.T) {
+
+	h := hist.NewH1D(4, 0., 4.)
+	for _, x := range []float64{-1., 0.5, 1.5, 1.7, 5.} {
+		h.Fill(x)
+	}
+	v := hist.Publish("prometheus_test", hist.NewH1D(2, 0., 1.))
+	v.Fill(0.25)
+
+	e := NewExporter()
+	Register(e, "latency", "test histogram", h, Label{"path", `/a"b`})
+	Register(e, "latency", "test histogram", h, Label{"path", "/c"})
+	RegisterVar(e, "size", "test var\nhistogram", v)
+	Register(e, "latency", "test histogram", h, Label{"path", "/d"})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Fatalf("Unexpected content type: %s", ct)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# HELP latency test histogram\n# TYPE latency histogram\n",
+		`latency_bucket{path="/a\"b",le="0"} 1` + "\n",
+		`latency_bucket{path="/a\"b",le="2"} 4` + "\n",
+		`latency_bucket{path="/c",le="+Inf"} 5` + "\n",
+		`latency_sum{path="/c"} 7.5` + "\n",
+		`latency_count{path="/c"} 5` + "\n",
+		"# HELP size test var\\nhistogram\n",
+		`size_bucket{le="0.5"} 1` + "\n",
+		"size_count 1\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("Missing %q in output:\n%s", want, body)
+		}
+	}
+	if strings.Count(body, "# TYPE latency") != 1 {
+		t.Fatalf("Expected metadata to be written once per metric name:\n%s", body)
+	}
+
+	// All samples of a metric family must be written as a single group
+	latency, size := strings.Index(body, `latency_count{path="/d"}`), strings.Index(body, "# HELP size")
+	if latency < 0 || size < 0 || latency > size || strings.Contains(body[size:], "latency_") {
+		t.Fatalf("Expected samples to be grouped by metric name:\n%s", body)
+	}
+}
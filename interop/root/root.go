@@ -0,0 +1,56 @@
+// Package root provides exporters writing the histograms of this module in formats that
+// can be imported by ROOT (https://root.cern), preserving bin edges, contents and errors
+package root
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/fako1024/numerics/hist"
+)
+
+// WriteMacro writes a histogram as (unnamed) ROOT macro creating an equivalent TH1D with the
+// provided name and title, including under- / overflow, bin errors and the number of
+// entries. The macro can be executed via e.g. `root histogram.C` or `.x histogram.C`
+func WriteMacro[T hist.Number](w io.Writer, h *hist.H1[T], name, title string) error {
+
+	bw := bufio.NewWriter(w)
+	n := h.NBins()
+
+	fmt.Fprintln(bw, "{")
+	fmt.Fprint(bw, "   Double_t edges[] = {")
+	for i := 1; i <= n+1; i++ {
+		if i > 1 {
+			fmt.Fprint(bw, ", ")
+		}
+		if i <= n {
+			fmt.Fprint(bw, fmtDouble(float64(h.BinLowEdge(i))))
+		} else {
+			fmt.Fprint(bw, fmtDouble(float64(h.BinUpEdge(n))))
+		}
+	}
+	fmt.Fprintln(bw, "};")
+	fmt.Fprintf(bw, "   TH1D *h = new TH1D(%s, %s, %d, edges);\n", strconv.QuoteToASCII(name), strconv.QuoteToASCII(title), n)
+
+	// Setting the bin errors implicitly enables the tracking of the sum of squared weights
+	for i := 0; i <= n+1; i++ {
+		if h.BinContent(i) == 0 && h.BinVariance(i) == 0 {
+			continue
+		}
+		fmt.Fprintf(bw, "   h->SetBinContent(%d, %s);\n", i, fmtDouble(h.BinContent(i)))
+		fmt.Fprintf(bw, "   h->SetBinError(%d, %s);\n", i, fmtDouble(h.BinError(i)))
+	}
+	fmt.Fprintf(bw, "   h->SetEntries(%d);\n", h.NEntries())
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// fmtDouble formats a value as (lossless) C++ double literal
+func fmtDouble(v float64) string {
+	return strconv.FormatFloat(v, 'g', 17, 64)
+}
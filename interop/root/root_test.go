@@ -0,0 +1,44 @@
+package root
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fako1024/numerics/hist"
+)
+
+func TestWriteMacro(t *testing.T) {
+
+	h := hist.NewH1Edges([]float64{0., 1., 10.})
+	h.EnableSumw2()
+	for _, x := range []float64{-1., 0.5, 5., 5., 20.} {
+		h.Fill(x, 2.)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := WriteMacro(buf, h, "h1", `latency "p99"`); err != nil {
+		t.Fatalf("Failed to write macro: %s", err)
+	}
+
+	want := `{
+   Double_t edges[] = {0, 1, 10};
+   TH1D *h = new TH1D("h1", "latency \"p99\"", 2, edges);
+   h->SetBinContent(0, 2);
+   h->SetBinError(0, 2);
+   h->SetBinContent(1, 2);
+   h->SetBinError(1, 2);
+   h->SetBinContent(2, 4);
+   h->SetBinError(2, 2.8284271247461903);
+   h->SetBinContent(3, 2);
+   h->SetBinError(3, 2);
+   h->SetEntries(5);
+}
+`
+	if buf.String() != want {
+		t.Fatalf("Unexpected macro, want:\n%s\nhave:\n%s", want, buf.String())
+	}
+	if strings.Count(buf.String(), "{") != strings.Count(buf.String(), "}") {
+		t.Fatalf("Unbalanced braces in macro")
+	}
+}
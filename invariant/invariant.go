@@ -0,0 +1,116 @@
+// Package invariant provides checkers asserting mathematical invariants of the functions and
+// types of this module, returning an error upon violation. They are intended to be used by
+// fuzz targets and property-based tests, e.g.
+//
+//	f.Fuzz(func(t *testing.T, x, a, b float64) {
+//		err := invariant.BetaIncompleteSymmetry(x, a, b, 1e-9)
+//		if errors.Is(err, numerics.ErrDomain) || errors.Is(err, numerics.ErrNoConvergence) {
+//			t.Skip()
+//		}
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//	})
+package invariant
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/fako1024/numerics"
+	"github.com/fako1024/numerics/hist"
+)
+
+// ErrViolation denotes that a mathematical invariant does not hold
+var ErrViolation = errors.New("invariant violated")
+
+// BetaIncompleteMonotone asserts that the regularized incomplete beta function Iₓ(a, b) is
+// monotonically non-decreasing in x, i.e. that Iₓ₁(a, b) <= Iₓ₂(a, b) + tol for x1 <= x2
+func BetaIncompleteMonotone(x1, x2, a, b, tol float64) error {
+	if x1 > x2 {
+		x1, x2 = x2, x1
+	}
+	if err := checkBetaDomain(x1, a, b); err != nil {
+		return err
+	}
+	if err := checkBetaDomain(x2, a, b); err != nil {
+		return err
+	}
+
+	i1, err := numerics.BetaIncompleteRegularErr(x1, a, b)
+	if err != nil {
+		return err
+	}
+	i2, err := numerics.BetaIncompleteRegularErr(x2, a, b)
+	if err != nil {
+		return err
+	}
+
+	if i1 > i2+tol {
+		return fmt.Errorf("%w: I(%v, %v, %v) = %v > I(%v, %v, %v) = %v", ErrViolation, x1, a, b, i1, x2, a, b, i2)
+	}
+
+	return nil
+}
+
+// BetaIncompleteSymmetry asserts the symmetry relation Iₓ(a, b) + I₁₋ₓ(b, a) = 1 of the
+// regularized incomplete beta function (within an absolute tolerance)
+func BetaIncompleteSymmetry(x, a, b, tol float64) error {
+	if err := checkBetaDomain(x, a, b); err != nil {
+		return err
+	}
+
+	ix, err := numerics.BetaIncompleteRegularErr(x, a, b)
+	if err != nil {
+		return err
+	}
+	ixc, err := numerics.BetaIncompleteRegularErr(1.-x, b, a)
+	if err != nil {
+		return err
+	}
+
+	if math.Abs(ix+ixc-1.) > tol {
+		return fmt.Errorf("%w: I(%v, %v, %v) + I(%v, %v, %v) = %v != 1", ErrViolation, x, a, b, 1.-x, b, a, ix+ixc)
+	}
+
+	return nil
+}
+
+// HistogramSum asserts that the sum of weights of a histogram equals the sum of all bin
+// contents (including underflow and overflow), within a relative tolerance
+func HistogramSum[T hist.Number](h *hist.H1[T], tol float64) error {
+
+	var sum float64
+	for i := 0; i <= h.NBins()+1; i++ {
+		sum += h.BinContent(i)
+	}
+
+	if diff := math.Abs(sum - h.Sum()); !(diff <= tol*math.Max(1., math.Abs(h.Sum()))) {
+		return fmt.Errorf("%w: sum of weights %v != sum of bin contents %v", ErrViolation, h.Sum(), sum)
+	}
+
+	return nil
+}
+
+// RootResidual asserts that the residual |f(x)| at a root x found by any of the root finding
+// methods is below an absolute tolerance
+func RootResidual(f func(x float64) float64, x, tol float64) error {
+	if math.IsNaN(x) {
+		return fmt.Errorf("%w: root is NaN", numerics.ErrNoConvergence)
+	}
+
+	if res := f(x); !(math.Abs(res) <= tol) {
+		return fmt.Errorf("%w: residual |f(%v)| = %v exceeds tolerance %v", ErrViolation, x, math.Abs(res), tol)
+	}
+
+	return nil
+}
+
+func checkBetaDomain(x, a, b float64) error {
+	if !(x >= 0. && x <= 1.) || !(a > 0.) || !(b > 0.) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return fmt.Errorf("%w: x=%v, a=%v, b=%v", numerics.ErrDomain, x, a, b)
+	}
+
+	return nil
+}
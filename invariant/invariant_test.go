@@ -0,0 +1,92 @@
+package invariant
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/fako1024/numerics"
+	"github.com/fako1024/numerics/hist"
+	"github.com/fako1024/numerics/root"
+)
+
+func TestBetaIncomplete(t *testing.T) {
+
+	for _, a := range []float64{0.5, 1., 2.5, 10., 40.} {
+		for _, b := range []float64{0.5, 1., 3., 20.} {
+			for x := 0.; x <= 1.; x += 0.05 {
+				if err := BetaIncompleteMonotone(x, math.Min(1., x+0.05), a, b, 1e-12); err != nil {
+					t.Fatalf("Unexpected monotonicity violation: %s", err)
+				}
+				if err := BetaIncompleteSymmetry(x, a, b, 1e-9); err != nil {
+					t.Fatalf("Unexpected symmetry violation: %s", err)
+				}
+			}
+		}
+	}
+
+	if err := BetaIncompleteSymmetry(1.5, 1., 1., 1e-9); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected error for invalid argument: %v", err)
+	}
+	if err := BetaIncompleteMonotone(0.1, 0.2, -1., 1., 1e-9); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected error for invalid argument: %v", err)
+	}
+}
+
+func TestHistogramSum(t *testing.T) {
+
+	h := hist.NewH1D(10, 0., 1.)
+	for _, val := range []float64{-1., 0.1, 0.5, 0.5, 1., 2.} {
+		h.Fill(val, 0.5)
+	}
+	h.SetBinContent(3, 7.)
+	h.Scale(0.1)
+	if err := HistogramSum(h, 1e-12); err != nil {
+		t.Fatalf("Unexpected histogram sum violation: %s", err)
+	}
+
+	// Corrupt the sum of weights stored in the serialized histogram
+	data, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Failed to serialize histogram: %s", err)
+	}
+	binary.LittleEndian.PutUint64(data[5:], math.Float64bits(h.Sum()+1.))
+	if err := h.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Failed to deserialize histogram: %s", err)
+	}
+	if err := HistogramSum(h, 1e-12); !errors.Is(err, ErrViolation) {
+		t.Fatalf("Expected histogram sum violation, have %v", err)
+	}
+}
+
+func TestRootResidual(t *testing.T) {
+
+	f := func(x float64) float64 {
+		return x*x - 2.
+	}
+	if err := RootResidual(f, root.Bisect(f, 0., 2.), 1e-9); err != nil {
+		t.Fatalf("Unexpected root residual violation: %s", err)
+	}
+	if err := RootResidual(f, 1.5, 1e-9); !errors.Is(err, ErrViolation) {
+		t.Fatalf("Expected root residual violation, have %v", err)
+	}
+	if err := RootResidual(f, math.NaN(), 1e-9); !errors.Is(err, numerics.ErrNoConvergence) {
+		t.Fatalf("Unexpected error for NaN root: %v", err)
+	}
+}
+
+func FuzzBetaIncompleteSymmetry(f *testing.F) {
+	f.Add(0.3, 2., 5.)
+	f.Add(0.99, 0.5, 40.)
+
+	f.Fuzz(func(t *testing.T, x, a, b float64) {
+		err := BetaIncompleteSymmetry(x, a, b, 1e-9)
+		if errors.Is(err, numerics.ErrDomain) || errors.Is(err, numerics.ErrNoConvergence) {
+			t.Skip()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package linalg
+
+import (
+	"math"
+)
+
+// Banded denotes a square band matrix with kl sub-diagonals and ku super-diagonals
+type Banded struct {
+	n, kl, ku int
+
+	// Each row stores the elements from column i-kl to i+ku
+	data []float64
+}
+
+// NewBanded instantiates a new (zero) band matrix of dimension n with kl sub-diagonals
+// and ku super-diagonals
+func NewBanded(n, kl, ku int) *Banded {
+	return &Banded{
+		n:    n,
+		kl:   kl,
+		ku:   ku,
+		data: make([]float64, n*(kl+ku+1)),
+	}
+}
+
+// Dims returns the dimension and the number of sub- / super-diagonals of the matrix
+func (m *Banded) Dims() (int, int, int) {
+	return m.n, m.kl, m.ku
+}
+
+// At returns the element at row i and column j (zero outside of the band)
+func (m *Banded) At(i, j int) float64 {
+	if j < i-m.kl || j > i+m.ku {
+		return 0.
+	}
+	return m.data[i*(m.kl+m.ku+1)+j-i+m.kl]
+}
+
+// Set sets the element at row i and column j, which must lie within the band
+func (m *Banded) Set(i, j int, v float64) {
+	if j < i-m.kl || j > i+m.ku {
+		panic("element outside of band")
+	}
+	m.data[i*(m.kl+m.ku+1)+j-i+m.kl] = v
+}
+
+// Solve solves the system of linear equations A*x = b via Gaussian elimination with
+// partial pivoting, requiring O(n*kl*(kl+ku)) operations
+func (m *Banded) Solve(b []float64) ([]float64, error) {
+
+	if len(b) != m.n {
+		return nil, ErrDimensionMismatch
+	}
+
+	// Pivoting may fill in up to kl additional super-diagonals, so the elimination is
+	// performed on an extended copy with kl sub- and kl+ku super-diagonals
+	width := 2*m.kl + m.ku + 1
+	work := make([]float64, m.n*width)
+	at := func(i, j int) *float64 {
+		return &work[i*width+j-i+m.kl]
+	}
+	for i := 0; i < m.n; i++ {
+		for j := max(0, i-m.kl); j <= min(m.n-1, i+m.ku); j++ {
+			*at(i, j) = m.At(i, j)
+		}
+	}
+	x := make([]float64, m.n)
+	copy(x, b)
+
+	for k := 0; k < m.n; k++ {
+		last := min(m.n-1, k+m.kl)
+		lastCol := min(m.n-1, k+m.kl+m.ku)
+
+		// Determine pivot row among the rows within the band
+		pivot := k
+		for i := k + 1; i <= last; i++ {
+			if math.Abs(*at(i, k)) > math.Abs(*at(pivot, k)) {
+				pivot = i
+			}
+		}
+		if *at(pivot, k) == 0 {
+			return nil, ErrSingular
+		}
+		if pivot != k {
+			for j := k; j <= lastCol; j++ {
+				*at(k, j), *at(pivot, j) = *at(pivot, j), *at(k, j)
+			}
+			x[k], x[pivot] = x[pivot], x[k]
+		}
+
+		// Eliminate elements below the pivot
+		for i := k + 1; i <= last; i++ {
+			f := *at(i, k) / *at(k, k)
+			if f == 0 {
+				continue
+			}
+			for j := k; j <= lastCol; j++ {
+				*at(i, j) -= f * *at(k, j)
+			}
+			x[i] -= f * x[k]
+		}
+	}
+
+	// Back substitution
+	for i := m.n - 1; i >= 0; i-- {
+		for j := i + 1; j <= min(m.n-1, i+m.kl+m.ku); j++ {
+			x[i] -= *at(i, j) * x[j]
+		}
+		x[i] /= *at(i, i)
+	}
+
+	return x, nil
+}
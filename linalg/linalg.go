@@ -0,0 +1,77 @@
+package linalg
+
+import (
+	"errors"
+)
+
+var (
+	// ErrSingular denotes that a matrix is (numerically) singular
+	ErrSingular = errors.New("matrix is singular")
+
+	// ErrDimensionMismatch denotes that the dimensions of the operands do not match
+	ErrDimensionMismatch = errors.New("dimension mismatch")
+)
+
+// Dense denotes a dense matrix stored in row-major order
+type Dense struct {
+	rows, cols int
+	data       []float64
+}
+
+// NewDense instantiates a new dense matrix of the given dimensions. If data is nil,
+// a zero matrix is allocated, otherwise data is used as (row-major) backing storage
+func NewDense(rows, cols int, data []float64) *Dense {
+
+	if data == nil {
+		data = make([]float64, rows*cols)
+	}
+	if len(data) != rows*cols {
+		panic("data length does not match matrix dimensions")
+	}
+
+	return &Dense{
+		rows: rows,
+		cols: cols,
+		data: data,
+	}
+}
+
+// Dims returns the number of rows and columns of the matrix
+func (m *Dense) Dims() (int, int) {
+	return m.rows, m.cols
+}
+
+// At returns the element at row i and column j
+func (m *Dense) At(i, j int) float64 {
+	return m.data[i*m.cols+j]
+}
+
+// Set sets the element at row i and column j
+func (m *Dense) Set(i, j int, v float64) {
+	m.data[i*m.cols+j] = v
+}
+
+// MulVec returns the product of the matrix and the vector x
+func (m *Dense) MulVec(x []float64) []float64 {
+
+	if len(x) != m.cols {
+		panic("vector length does not match number of columns")
+	}
+
+	res := make([]float64, m.rows)
+	for i := range res {
+		row := m.data[i*m.cols : (i+1)*m.cols]
+		for j, v := range row {
+			res[i] += v * x[j]
+		}
+	}
+
+	return res
+}
+
+// Clone returns a deep copy of the matrix
+func (m *Dense) Clone() *Dense {
+	data := make([]float64, len(m.data))
+	copy(data, m.data)
+	return NewDense(m.rows, m.cols, data)
+}
@@ -0,0 +1,126 @@
+package linalg
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+const testEpsilon = 1e-9
+
+func TestSolveTridiagonal(t *testing.T) {
+
+	n := 50
+	a, b, c := make([]float64, n-1), make([]float64, n), make([]float64, n-1)
+	dense := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		b[i] = 4. + rand.Float64()
+		dense.Set(i, i, b[i])
+		if i < n-1 {
+			a[i], c[i] = rand.Float64(), rand.Float64()
+			dense.Set(i+1, i, a[i])
+			dense.Set(i, i+1, c[i])
+		}
+	}
+	x := random(n)
+
+	res, err := SolveTridiagonal(a, b, c, dense.MulVec(x))
+	if err != nil {
+		t.Fatalf("Unexpected error solving tridiagonal system: %s", err)
+	}
+	compare(t, x, res)
+
+	if _, err := SolveTridiagonal(a, b[:n-1], c, x); !errors.Is(err, ErrDimensionMismatch) {
+		t.Fatalf("Unexpected error for mismatching dimensions: %v", err)
+	}
+}
+
+func TestBandedSolve(t *testing.T) {
+
+	n, kl, ku := 40, 2, 3
+	band := NewBanded(n, kl, ku)
+	dense := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+			v := rand.Float64() - 0.5
+			band.Set(i, j, v)
+			dense.Set(i, j, v)
+		}
+	}
+	x := random(n)
+
+	res, err := band.Solve(dense.MulVec(x))
+	if err != nil {
+		t.Fatalf("Unexpected error solving banded system: %s", err)
+	}
+	compare(t, x, res)
+}
+
+func TestLU(t *testing.T) {
+
+	a := NewDense(3, 3, []float64{
+		2, 1, 1,
+		4, -6, 0,
+		-2, 7, 2,
+	})
+	x := []float64{1, 2, 3}
+
+	lu, err := NewLU(a)
+	if err != nil {
+		t.Fatalf("Unexpected error computing LU decomposition: %s", err)
+	}
+	res, err := lu.Solve(a.MulVec(x))
+	if err != nil {
+		t.Fatalf("Unexpected error solving system: %s", err)
+	}
+	compare(t, x, res)
+
+	if det := lu.Det(); math.Abs(det+16.) > testEpsilon {
+		t.Fatalf("Unexpected determinant: want -16, have %v", det)
+	}
+
+	if _, err := NewLU(NewDense(2, 2, []float64{1, 2, 2, 4})); !errors.Is(err, ErrSingular) {
+		t.Fatalf("Unexpected error for singular matrix: %v", err)
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+
+	// Fit a straight line to exact data
+	xs := []float64{0, 1, 2, 3, 4}
+	a := NewDense(len(xs), 2, nil)
+	b := make([]float64, len(xs))
+	for i, x := range xs {
+		a.Set(i, 0, 1.)
+		a.Set(i, 1, x)
+		b[i] = 3. - 2.*x
+	}
+
+	res, err := LeastSquares(a, b)
+	if err != nil {
+		t.Fatalf("Unexpected error computing least squares solution: %s", err)
+	}
+	compare(t, []float64{3., -2.}, res)
+}
+
+func compare(t *testing.T, expected, have []float64) {
+	t.Helper()
+
+	if len(expected) != len(have) {
+		t.Fatalf("Unexpected result length: want %d, have %d", len(expected), len(have))
+	}
+	for i := range expected {
+		if math.Abs(expected[i]-have[i]) > testEpsilon {
+			t.Fatalf("Unexpected result at index %d: want %v, have %v", i, expected[i], have[i])
+		}
+	}
+}
+
+func random(n int) []float64 {
+	res := make([]float64, n)
+	for i := range res {
+		res[i] = rand.Float64() - 0.5
+	}
+	return res
+}
@@ -0,0 +1,130 @@
+package linalg
+
+import (
+	"math"
+)
+
+// LU denotes the LU decomposition (with partial pivoting) of a square matrix,
+// i.e. P*A = L*U
+type LU struct {
+	n     int
+	lu    *Dense
+	pivot []int
+	sign  float64
+}
+
+// NewLU computes the LU decomposition of the square matrix a
+func NewLU(a *Dense) (*LU, error) {
+
+	n, cols := a.Dims()
+	if n != cols {
+		return nil, ErrDimensionMismatch
+	}
+
+	obj := LU{
+		n:     n,
+		lu:    a.Clone(),
+		pivot: make([]int, n),
+		sign:  1.,
+	}
+	for i := range obj.pivot {
+		obj.pivot[i] = i
+	}
+
+	lu := obj.lu
+	for k := 0; k < n; k++ {
+
+		// Determine pivot row
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu.At(i, k)) > math.Abs(lu.At(p, k)) {
+				p = i
+			}
+		}
+		if lu.At(p, k) == 0 {
+			return nil, ErrSingular
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				vk, vp := lu.At(k, j), lu.At(p, j)
+				lu.Set(k, j, vp)
+				lu.Set(p, j, vk)
+			}
+			obj.pivot[k], obj.pivot[p] = obj.pivot[p], obj.pivot[k]
+			obj.sign = -obj.sign
+		}
+
+		// Eliminate elements below the pivot, storing the multipliers in L
+		for i := k + 1; i < n; i++ {
+			f := lu.At(i, k) / lu.At(k, k)
+			lu.Set(i, k, f)
+			for j := k + 1; j < n; j++ {
+				lu.Set(i, j, lu.At(i, j)-f*lu.At(k, j))
+			}
+		}
+	}
+
+	return &obj, nil
+}
+
+// Solve solves the system of linear equations A*x = b
+func (d *LU) Solve(b []float64) ([]float64, error) {
+
+	if len(b) != d.n {
+		return nil, ErrDimensionMismatch
+	}
+
+	// Forward substitution (L*y = P*b)
+	x := make([]float64, d.n)
+	for i := 0; i < d.n; i++ {
+		x[i] = b[d.pivot[i]]
+		for j := 0; j < i; j++ {
+			x[i] -= d.lu.At(i, j) * x[j]
+		}
+	}
+
+	// Back substitution (U*x = y)
+	for i := d.n - 1; i >= 0; i-- {
+		for j := i + 1; j < d.n; j++ {
+			x[i] -= d.lu.At(i, j) * x[j]
+		}
+		x[i] /= d.lu.At(i, i)
+	}
+
+	return x, nil
+}
+
+// Det returns the determinant of the decomposed matrix
+func (d *LU) Det() float64 {
+	det := d.sign
+	for i := 0; i < d.n; i++ {
+		det *= d.lu.At(i, i)
+	}
+	return det
+}
+
+// Inverse returns the inverse of the decomposed matrix
+func (d *LU) Inverse() *Dense {
+
+	res := NewDense(d.n, d.n, nil)
+	e := make([]float64, d.n)
+	for j := 0; j < d.n; j++ {
+		e[j] = 1.
+		col, _ := d.Solve(e)
+		for i := range col {
+			res.Set(i, j, col[i])
+		}
+		e[j] = 0.
+	}
+
+	return res
+}
+
+// Solve solves the square system of linear equations A*x = b via LU decomposition
+func Solve(a *Dense, b []float64) ([]float64, error) {
+	lu, err := NewLU(a)
+	if err != nil {
+		return nil, err
+	}
+	return lu.Solve(b)
+}
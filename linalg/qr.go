@@ -0,0 +1,123 @@
+package linalg
+
+import (
+	"math"
+)
+
+// QR denotes the QR decomposition of a matrix with at least as many rows as columns,
+// computed via Householder reflections
+type QR struct {
+	rows, cols int
+
+	// qr stores R in its upper triangle and the Householder vectors below
+	qr    *Dense
+	rDiag []float64
+}
+
+// NewQR computes the QR decomposition of the matrix a (with rows >= cols)
+func NewQR(a *Dense) (*QR, error) {
+
+	rows, cols := a.Dims()
+	if rows < cols {
+		return nil, ErrDimensionMismatch
+	}
+
+	obj := QR{
+		rows:  rows,
+		cols:  cols,
+		qr:    a.Clone(),
+		rDiag: make([]float64, cols),
+	}
+
+	qr := obj.qr
+	for k := 0; k < cols; k++ {
+
+		// Compute norm of the k-th column below the diagonal
+		norm := 0.
+		for i := k; i < rows; i++ {
+			norm = math.Hypot(norm, qr.At(i, k))
+		}
+
+		if norm != 0 {
+			if qr.At(k, k) < 0 {
+				norm = -norm
+			}
+			for i := k; i < rows; i++ {
+				qr.Set(i, k, qr.At(i, k)/norm)
+			}
+			qr.Set(k, k, qr.At(k, k)+1.)
+
+			// Apply reflection to the remaining columns
+			for j := k + 1; j < cols; j++ {
+				s := 0.
+				for i := k; i < rows; i++ {
+					s += qr.At(i, k) * qr.At(i, j)
+				}
+				s = -s / qr.At(k, k)
+				for i := k; i < rows; i++ {
+					qr.Set(i, j, qr.At(i, j)+s*qr.At(i, k))
+				}
+			}
+		}
+		obj.rDiag[k] = -norm
+	}
+
+	return &obj, nil
+}
+
+// IsFullRank determines if the decomposed matrix has full column rank
+func (d *QR) IsFullRank() bool {
+	for _, v := range d.rDiag {
+		if v == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Solve computes the least squares solution x minimizing ||A*x - b||
+func (d *QR) Solve(b []float64) ([]float64, error) {
+
+	if len(b) != d.rows {
+		return nil, ErrDimensionMismatch
+	}
+	if !d.IsFullRank() {
+		return nil, ErrSingular
+	}
+
+	// Compute Qᵀ*b
+	y := make([]float64, d.rows)
+	copy(y, b)
+	for k := 0; k < d.cols; k++ {
+		s := 0.
+		for i := k; i < d.rows; i++ {
+			s += d.qr.At(i, k) * y[i]
+		}
+		s = -s / d.qr.At(k, k)
+		for i := k; i < d.rows; i++ {
+			y[i] += s * d.qr.At(i, k)
+		}
+	}
+
+	// Back substitution (R*x = Qᵀ*b)
+	x := make([]float64, d.cols)
+	for k := d.cols - 1; k >= 0; k-- {
+		x[k] = y[k]
+		for j := k + 1; j < d.cols; j++ {
+			x[k] -= d.qr.At(k, j) * x[j]
+		}
+		x[k] /= d.rDiag[k]
+	}
+
+	return x, nil
+}
+
+// LeastSquares computes the least squares solution x minimizing ||A*x - b|| via QR
+// decomposition
+func LeastSquares(a *Dense, b []float64) ([]float64, error) {
+	qr, err := NewQR(a)
+	if err != nil {
+		return nil, err
+	}
+	return qr.Solve(b)
+}
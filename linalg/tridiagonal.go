@@ -0,0 +1,41 @@
+package linalg
+
+// SolveTridiagonal solves the tridiagonal system of linear equations A*x = d using
+// the Thomas algorithm, where a denotes the sub-diagonal (length n-1), b the diagonal
+// (length n) and c the super-diagonal (length n-1) of A. No pivoting is performed,
+// hence the method is only stable for diagonally dominant or positive definite systems
+func SolveTridiagonal(a, b, c, d []float64) ([]float64, error) {
+
+	n := len(b)
+	if n == 0 || len(d) != n || len(a) != n-1 || len(c) != n-1 {
+		return nil, ErrDimensionMismatch
+	}
+
+	cPrime, x := make([]float64, n), make([]float64, n)
+
+	// Forward sweep
+	if b[0] == 0 {
+		return nil, ErrSingular
+	}
+	cPrime[0], x[0] = 0, d[0]/b[0]
+	if n > 1 {
+		cPrime[0] = c[0] / b[0]
+	}
+	for i := 1; i < n; i++ {
+		denom := b[i] - a[i-1]*cPrime[i-1]
+		if denom == 0 {
+			return nil, ErrSingular
+		}
+		if i < n-1 {
+			cPrime[i] = c[i] / denom
+		}
+		x[i] = (d[i] - a[i-1]*x[i-1]) / denom
+	}
+
+	// Back substitution
+	for i := n - 2; i >= 0; i-- {
+		x[i] -= cPrime[i] * x[i+1]
+	}
+
+	return x, nil
+}
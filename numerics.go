@@ -36,39 +36,14 @@ func Beta(a, b float64) float64 {
 //
 // If x < 0 or x > 1, returns NaN.
 func BetaIncompleteRegular(x, a, b float64) float64 {
-
-	// Based on Numerical Recipes in C, section 6.4. This uses the
-	// continued fraction definition of I:
-	//
-	//  (xᵃ*(1-x)ᵇ)/(a*B(a,b)) * (1/(1+(d₁/(1+(d₂/(1+...))))))
-	//
-	// where B(a,b) is the beta function and
-	//
-	//  d_{2m+1} = -(a+m)(a+b+m)x/((a+2m)(a+2m+1))
-	//  d_{2m}   = m(b-m)x/((a+2m-1)(a+2m))
-	if x < 0 || x > 1 {
-		return math.NaN()
-	}
-	bt := 0.0
-	if 0 < x && x < 1 {
-
-		// Compute the coefficient before the continued
-		// fraction.
-		bt = math.Exp(Lgamma(a+b) - Lgamma(a) - Lgamma(b) +
-			a*math.Log(x) + b*math.Log(1-x))
-	}
-	if x < (a+1)/(a+b+2) {
-		// Compute continued fraction directly.
-		return bt * betacf(x, a, b) / a
-	}
-
-	// Compute continued fraction after symmetry transform.
-	return 1 - bt*betacf(1-x, b, a)/b
+	res, _ := DefaultConfig.BetaIncompleteRegular(x, a, b)
+	return res
 }
 
 // BetaIncomplete returns the value of the (non-regularized) incomplete beta function
 func BetaIncomplete(x, a, b float64) float64 {
-	return BetaIncompleteRegular(x, a, b) * Beta(a, b)
+	res, _ := DefaultConfig.BetaIncomplete(x, a, b)
+	return res
 }
 
 // Binomial returns the value of the probability distribution for a Bernoulli experiment.
@@ -80,12 +55,28 @@ func Binomial(x, k, n float64) float64 {
 	return math.Exp((n-k)*math.Log(1.-x) + k*math.Log(x))
 }
 
-////////////////////////////////////////////////////////////////////////////////
+// KolmogorovQ returns the complementary Kolmogorov distribution function Q(λ), i.e. the
+// asymptotic p-value of the (scaled) Kolmogorov-Smirnov statistic λ = √n·D
+func KolmogorovQ(lambda float64) float64 {
+	if lambda < 0.2 {
+		return 1.
+	}
 
-const (
-	betaEpsilon       = 3e-14
-	betaMaxIterations = 200
-)
+	// Q(λ) = 2 Σ (-1)ᵏ⁻¹ exp(-2k²λ²)
+	var sum, sign float64 = 0., 1.
+	for k := 1; k <= 100; k++ {
+		term := sign * math.Exp(-2.*float64(k*k)*lambda*lambda)
+		sum += term
+		if math.Abs(term) < 1e-12*math.Abs(sum) {
+			break
+		}
+		sign = -sign
+	}
+
+	return math.Max(0., math.Min(1., 2.*sum))
+}
+
+////////////////////////////////////////////////////////////////////////////////
 
 // smallestNonZero return the smalles non-zero value to avoid creating division
 // by zero situations due to numeric fluctuations
@@ -100,12 +91,12 @@ func smallestNonZero(val float64) float64 {
 // betacf is the continued fraction component of the regularized
 // incomplete beta function Iₓ(a, b).
 // Based on Numerical Recipes in C, Second Edition, Section 6.4
-func betacf(x, a, b float64) float64 {
+func betacf(x, a, b, epsilon float64, maxIterations int) float64 {
 
 	c := 1.0
 	d := 1.0 / smallestNonZero(1.0-(a+b)*x/(a+1.0))
 	h := d
-	for m := 1; m <= betaMaxIterations; m++ {
+	for m := 1; m <= maxIterations; m++ {
 		mf := float64(m)
 
 		// One step (the even one) of the recurrence
@@ -122,7 +113,7 @@ func betacf(x, a, b float64) float64 {
 		h *= hfac
 
 		// If sufficient precision is reached, return
-		if math.Abs(hfac-1.0) < betaEpsilon {
+		if math.Abs(hfac-1.0) < epsilon {
 			return h
 		}
 	}
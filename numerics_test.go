@@ -1,8 +1,12 @@
 package numerics
 
 import (
+	"context"
+	"errors"
 	"math"
 	"testing"
+
+	"github.com/fako1024/numerics/testutil"
 )
 
 const (
@@ -183,6 +187,61 @@ func TestBinomial(t *testing.T) {
 	}
 }
 
+func TestGammaIncomplete(t *testing.T) {
+
+	for _, cs := range []struct {
+		a, x     float64
+		expected float64
+	}{
+		{1., 0., 0.},
+		{1., 1., 1. - math.Exp(-1.)},
+		{1., 10., 1. - math.Exp(-10.)},
+		{0.5, 2., math.Erf(math.Sqrt(2.))},
+		{3., 2.5, 0.4561868841166703},
+		{10., 5., 0.03182805730620481},
+		{10., 20., 0.9950045876916924},
+	} {
+		if p := GammaIncompleteRegular(cs.a, cs.x); math.Abs(p-cs.expected) > testEpsilon {
+			t.Fatalf("Test driven call to GammaIncompleteRegular failed (a=%.3f, x=%.3f), want %v, have %v", cs.a, cs.x, cs.expected, p)
+		}
+		if q := GammaIncompleteRegularComp(cs.a, cs.x); math.Abs(q-(1.-cs.expected)) > testEpsilon {
+			t.Fatalf("Test driven call to GammaIncompleteRegularComp failed (a=%.3f, x=%.3f), want %v, have %v", cs.a, cs.x, 1.-cs.expected, q)
+		}
+	}
+
+	// Tail of the complement retains its relative precision
+	if q := GammaIncompleteRegularComp(1., 100.); math.Abs(q/math.Exp(-100.)-1.) > 1e-12 {
+		t.Fatalf("Unexpected precision of GammaIncompleteRegularComp in the tail: %v", q)
+	}
+	if _, err := GammaIncompleteRegularErr(-1., 1.); !errors.Is(err, ErrDomain) {
+		t.Fatalf("Expected ErrDomain, have %v", err)
+	}
+	if _, err := GammaIncompleteRegularCompErr(1., -1.); !errors.Is(err, ErrDomain) {
+		t.Fatalf("Expected ErrDomain, have %v", err)
+	}
+	if !math.IsNaN(GammaIncompleteRegular(0., 1.)) {
+		t.Fatalf("Expected NaN for a=0")
+	}
+}
+
+func TestKolmogorovQ(t *testing.T) {
+
+	for _, cs := range []struct {
+		lambda   float64
+		expected float64
+	}{
+		{0., 1.},
+		{0.5, 0.9639452436648751},
+		{1., 0.26999967167735456},
+		{2., 0.0006709252557796953},
+		{10., 0.},
+	} {
+		if q := KolmogorovQ(cs.lambda); math.Abs(q-cs.expected) > testEpsilon {
+			t.Fatalf("Test driven call to KolmogorovQ failed (lambda=%.3f), want %v, have %v", cs.lambda, cs.expected, q)
+		}
+	}
+}
+
 func TestSign(t *testing.T) {
 
 	type testCaseSign struct {
@@ -210,3 +269,157 @@ func TestSign(t *testing.T) {
 	}
 
 }
+
+func TestChebyshev(t *testing.T) {
+
+	cheb := ChebFit(math.Sin, 0., 7., 30)
+	for x := 0.; x <= 7.; x += 0.1 {
+		if val := cheb.Eval(x); math.Abs(val-math.Sin(x)) > testEpsilon {
+			t.Fatalf("Unexpected Chebyshev approximation at x=%.3f: want %.19f, have %.19f", x, math.Sin(x), val)
+		}
+		if val := cheb.Derivative().Eval(x); math.Abs(val-math.Cos(x)) > testEpsilon {
+			t.Fatalf("Unexpected Chebyshev derivative at x=%.3f: want %.19f, have %.19f", x, math.Cos(x), val)
+		}
+		if val := cheb.Integral().Eval(x); math.Abs(val-(1.-math.Cos(x))) > testEpsilon {
+			t.Fatalf("Unexpected Chebyshev integral at x=%.3f: want %.19f, have %.19f", x, 1.-math.Cos(x), val)
+		}
+	}
+
+	// The integral of a constant series is linear (and must not vanish)
+	constant := ChebFit(func(float64) float64 { return 3. }, 1., 5., 1).Integral()
+	for x := 1.; x <= 5.; x += 0.5 {
+		if val := constant.Eval(x); math.Abs(val-3.*(x-1.)) > testEpsilon {
+			t.Fatalf("Unexpected Chebyshev integral of constant at x=%.3f: want %.19f, have %.19f", x, 3.*(x-1.), val)
+		}
+	}
+
+	roots := ChebFit(math.Sin, 0.5, 7., 30).Roots()
+	if len(roots) != 2 || math.Abs(roots[0]-math.Pi) > testEpsilon || math.Abs(roots[1]-2.*math.Pi) > testEpsilon {
+		t.Fatalf("Unexpected Chebyshev roots: have %v", roots)
+	}
+
+	beta := ChebFit(func(x float64) float64 {
+		return BetaIncompleteRegular(x, 2.5, 4.)
+	}, 0., 1., 40)
+	for x := 0.; x <= 1.; x += 0.01 {
+		if val := beta.Eval(x); math.Abs(val-BetaIncompleteRegular(x, 2.5, 4.)) > 1e-6 {
+			t.Fatalf("Unexpected Chebyshev approximation of BetaIncompleteRegular at x=%.3f: want %.19f, have %.19f", x, BetaIncompleteRegular(x, 2.5, 4.), val)
+		}
+	}
+}
+
+func TestConfig(t *testing.T) {
+
+	cfg := Config{
+		Epsilon:       1e-10,
+		MaxIterations: 100,
+		NaNPolicy:     ReturnError,
+	}
+
+	if val, err := cfg.BetaIncompleteRegular(0.23, 1.31, 11.76); err != nil || math.Abs(val-0.9234481429287121346) > testEpsilon {
+		t.Fatalf("Unexpected result for valid arguments: %v / %v", val, err)
+	}
+	if val, err := cfg.BetaIncompleteRegular(-1., 0.5, 0.5); !math.IsNaN(val) || !errors.Is(err, ErrDomain) {
+		t.Fatalf("Unexpected result for argument outside of domain: %v / %v", val, err)
+	}
+
+	cfg.MaxIterations = 1
+	if val, err := cfg.BetaIncomplete(0.6, 20., 20.); !math.IsNaN(val) || !errors.Is(err, ErrNoConvergence) {
+		t.Fatalf("Unexpected result for insufficient number of iterations: %v / %v", val, err)
+	}
+
+	cfg.NaNPolicy = ReturnNaN
+	if val, err := cfg.BetaIncomplete(0.6, 20., 20.); !math.IsNaN(val) || err != nil {
+		t.Fatalf("Unexpected result for insufficient number of iterations (NaN policy): %v / %v", val, err)
+	}
+}
+
+func TestErrorVariants(t *testing.T) {
+
+	if _, err := BetaIncompleteRegularErr(1.5, 1., 1.); !errors.Is(err, ErrDomain) {
+		t.Fatalf("Unexpected error for argument outside of domain: %v", err)
+	}
+	if val, err := BetaIncompleteErr(0.5, 2., 2.); err != nil || math.Abs(val-0.5/6.) > testEpsilon {
+		t.Fatalf("Unexpected result for valid arguments: %v / %v", val, err)
+	}
+	if _, err := BetaIncompleteErr(0.5, 1000., 1000.); !errors.Is(err, ErrPrecisionLoss) {
+		t.Fatalf("Unexpected error for underflowing result: %v", err)
+	}
+	if _, err := BinomialErr(-0.1, 1., 2.); !errors.Is(err, ErrDomain) {
+		t.Fatalf("Unexpected error for argument outside of domain: %v", err)
+	}
+	if _, err := BinomialErr(0.8, 30., 10.); err != nil {
+		t.Fatalf("Unexpected error for valid arguments: %v", err)
+	}
+	if _, err := BinomialErr(1., 10., 5.); !errors.Is(err, ErrPrecisionLoss) {
+		t.Fatalf("Unexpected error for overflowing result: %v", err)
+	}
+}
+
+func TestParallelMap(t *testing.T) {
+
+	xs := make([]float64, 1000)
+	for i := range xs {
+		xs[i] = float64(i)
+	}
+
+	for _, workers := range []int{0, 1, 3, 2000} {
+		res, err := ParallelMap(context.Background(), math.Sqrt, xs, workers)
+		if err != nil {
+			t.Fatalf("Unexpected error for %d workers: %s", workers, err)
+		}
+		for i := range res {
+			if res[i] != math.Sqrt(xs[i]) {
+				t.Fatalf("Unexpected result at index %d for %d workers: %v", i, workers, res[i])
+			}
+		}
+	}
+
+	ys := []float64{1, 4, 9}
+	if err := ParallelApply(context.Background(), math.Sqrt, ys, 2); err != nil || ys[2] != 3 {
+		t.Fatalf("Unexpected in-place result: %v (error: %v)", ys, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ParallelMap(ctx, math.Sqrt, xs, 2); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Unexpected error for cancelled context: %v", err)
+	}
+}
+
+func TestReferenceTables(t *testing.T) {
+
+	// Maximum tolerated errors (in ULP), allowing for platform-specific differences in the
+	// underlying math functions (e.g. FMA usage on arm64)
+	var testTableReference = map[string]struct {
+		f      func(args ...float64) float64
+		maxULP uint64
+	}{
+		"beta": {func(args ...float64) float64 {
+			return Beta(args[0], args[1])
+		}, 1024},
+		"beta_incomplete_regular": {func(args ...float64) float64 {
+			return BetaIncompleteRegular(args[0], args[1], args[2])
+		}, 2048},
+		"binomial": {func(args ...float64) float64 {
+			return Binomial(args[0], args[1], args[2])
+		}, 1024},
+	}
+
+	for _, name := range testutil.Tables() {
+		cs, ok := testTableReference[name]
+		if !ok {
+			t.Fatalf("No function under test for reference table %s", name)
+		}
+		table, err := testutil.LoadTable(name)
+		if err != nil {
+			t.Fatalf("Failed to load reference table %s: %s", name, err)
+		}
+
+		res := testutil.Verify(table, cs.f)
+		t.Log(res)
+		if res.MaxULP > cs.maxULP {
+			t.Fatalf("Accuracy regression, max. tolerated error is %d ULP: %s", cs.maxULP, res)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package numerics
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// ParallelMap evaluates f for all xs using the provided number of concurrent workers (or
+// GOMAXPROCS workers if workers <= 0) and returns the results in the order of xs. If the
+// context is cancelled before all values have been processed, the remaining evaluations
+// are skipped and the context error is returned
+func ParallelMap[T, R any](ctx context.Context, f func(T) R, xs []T, workers int) ([]R, error) {
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(xs) {
+		workers = len(xs)
+	}
+
+	res := make([]R, len(xs))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for idx := range indices {
+				res[idx] = f(xs[idx])
+			}
+		}()
+	}
+
+	var err error
+	for i := 0; i < len(xs) && err == nil; i++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ParallelApply evaluates f for all xs using the provided number of concurrent workers (or
+// GOMAXPROCS workers if workers <= 0), storing the results in xs in-place
+func ParallelApply[T any](ctx context.Context, f func(T) T, xs []T, workers int) error {
+	res, err := ParallelMap(ctx, f, xs, workers)
+	if err != nil {
+		return err
+	}
+	copy(xs, res)
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package root
+
+import (
+	"context"
+
+	"github.com/fako1024/numerics"
+	"golang.org/x/exp/constraints"
+)
+
+// FindBatch performs a non-linear iterative root-finding method (see FindContext) for each
+// of the provided initial values concurrently (using GOMAXPROCS workers), returning the
+// roots in the order of the initial values (NaN for searches that failed to converge).
+// Since the function, its derivative and the options are shared between the concurrent
+// searches, they must be safe for concurrent use (i.e. stateful methods such as Secant
+// must not be used). If the context is cancelled, the remaining searches are skipped and
+// the context error is returned
+func FindBatch[T constraints.Float](ctx context.Context, fx, dfx func(x T) T, xInits []T, options ...func(*Finder)) ([]T, error) {
+	fx64, dfx64 := asFloat64(fx), asFloat64(dfx)
+	options = precisionOptions[T](options)
+
+	roots, err := numerics.ParallelMap(ctx, func(xInit T) T {
+		res, _ := solve(ctx, fx64, dfx64, float64(xInit), options...).rootErr()
+		return T(res)
+	}, xInits, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Searches already in progress upon cancellation are aborted (yielding NaN)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return roots, nil
+}
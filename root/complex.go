@@ -0,0 +1,67 @@
+package root
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// FindComplex performs Muller's method to find a (potentially complex) root of a complex
+// function, starting from three points around the provided initial value. Since Muller's
+// method interpolates by a parabola (whose roots may be complex even for real-valued
+// points), it also locates roots of functions without any real zeros, e.g. complex-conjugate
+// pairs of roots of real polynomials. Only the iteration and precision options (see
+// WithMinIterations, WithMaxIterations, WithTargetPrecision, WithXTolerance and WithConfig,
+// applied to the absolute values) are considered
+func FindComplex(fx func(z complex128) complex128, zInit complex128, options ...func(*Finder)) complex128 {
+	return newFinder(options...).loopMuller(fx, zInit)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// loopMuller executes the root finding loop of Muller's method
+func (n *Finder) loopMuller(fx func(z complex128) complex128, zInit complex128) complex128 {
+
+	// Initialize the three points around the initial value
+	h := complex(0.5*math.Max(cmplx.Abs(zInit), 1.), 0)
+	z0, z1, z2 := zInit-h, zInit+h, zInit
+	f0, f1, f2 := fx(z0), fx(z1), fx(z2)
+
+	for nIter := 1; ; nIter++ {
+		if f2 == 0 {
+			return z2
+		}
+
+		// Determine the parabola through the three points (in Newton form around z2)
+		h1, h2 := z1-z0, z2-z1
+		d1, d2 := (f1-f0)/h1, (f2-f1)/h2
+		a := (d2 - d1) / (h2 + h1)
+		b := a*h2 + d2
+
+		// Choose the root of the parabola closest to z2 (i.e. the larger denominator), falling
+		// back to a fixed step if the parabola degenerates to a constant
+		disc := cmplx.Sqrt(b*b - 4.*a*f2)
+		den := b + disc
+		if cmplx.Abs(b-disc) > cmplx.Abs(den) {
+			den = b - disc
+		}
+		dz := complex(1.+cmplx.Abs(z2), 0)
+		if den != 0 {
+			dz = -2. * f2 / den
+		}
+
+		z3 := z2 + dz
+		z0, z1, z2 = z1, z2, z3
+		f0, f1, f2 = f1, f2, fx(z3)
+
+		// If the current value is NaN, return it
+		if cmplx.IsNaN(z2) || cmplx.IsNaN(f2) {
+			return cmplx.NaN()
+		}
+
+		// If the minimum number of iterations has been performed and the convergence criteria
+		// are fulfilled or the maximum number of iterations has been performed, break
+		if nIter >= n.minIterations && (n.converged(cmplx.Abs(z2), cmplx.Abs(f2), cmplx.Abs(dz)) || nIter >= n.maxIterations) {
+			return z2
+		}
+	}
+}
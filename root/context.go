@@ -0,0 +1,24 @@
+package root
+
+import (
+	"context"
+
+	"golang.org/x/exp/constraints"
+)
+
+// FindContext perform a non-linear iterative root-finding method using the provided
+// parameters / options (see FindErr), aborting and returning the context error if the
+// context is cancelled (checked before each iteration, e.g. to bound searches involving
+// expensive function evaluations by a deadline)
+func FindContext[T constraints.Float](ctx context.Context, fx, dfx func(x T) T, xInit T, options ...func(*Finder)) (T, error) {
+	res, err := solve(ctx, asFloat64(fx), asFloat64(dfx), float64(xInit), precisionOptions[T](options)...).rootErr()
+	return T(res), err
+}
+
+// BisectContext performs a simple bisection of a function within a lower and an upper limit
+// (see BisectErr), aborting and returning the context error if the context is cancelled
+// (checked before each iteration)
+func BisectContext[T constraints.Float](ctx context.Context, fx func(x T) T, aInit, bInit T) (T, error) {
+	res, err := bisectErr(ctx, asFloat64(fx), float64(aInit), float64(bInit))
+	return T(res), err
+}
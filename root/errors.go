@@ -0,0 +1,75 @@
+package root
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/fako1024/numerics"
+	"golang.org/x/exp/constraints"
+)
+
+// The following errors are returned (wrapped to provide additional context) by the
+// error-returning variants of the root finding methods, in addition to errors wrapping
+// numerics.ErrNoConvergence. Both wrap numerics.ErrDomain
+var (
+	// ErrInvalidBracket denotes that the function values at the limits of a bracketing
+	// method do not differ in sign, i.e. the limits do not bracket a root
+	ErrInvalidBracket = fmt.Errorf("%w: limits do not bracket a root", numerics.ErrDomain)
+
+	// ErrDerivativeZero denotes that an iterative method encountered a vanishing derivative
+	// (resulting in an infinite step)
+	ErrDerivativeZero = fmt.Errorf("%w: derivative is zero", numerics.ErrDomain)
+)
+
+// FindErr perform a non-linear iterative root-finding method using the provided
+// parameters / options (see Find), returning an error (wrapping ErrDerivativeZero,
+// numerics.ErrDomain or numerics.ErrNoConvergence) instead of NaN or an inaccurate root if
+// the target precision is not reached
+func FindErr[T constraints.Float](fx, dfx func(x T) T, xInit T, options ...func(*Finder)) (T, error) {
+	return FindContext(context.Background(), fx, dfx, xInit, options...)
+}
+
+// BisectErr performs a simple bisection of a function within a lower and an upper limit
+// (see Bisect), returning an error (wrapping ErrInvalidBracket, numerics.ErrDomain or
+// numerics.ErrNoConvergence) instead of NaN if no root can be determined
+func BisectErr[T constraints.Float](fx func(x T) T, aInit, bInit T) (T, error) {
+	return BisectContext(context.Background(), fx, aInit, bInit)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// rootErr returns the root or the error of a root finding process
+func (r FindResult) rootErr() (float64, error) {
+	if r.err != nil {
+		return math.NaN(), r.err
+	}
+
+	return r.Root, nil
+}
+
+// bisectErr performs a bisection (see BisectErr), aborting if the context is cancelled
+func bisectErr(ctx context.Context, fx func(x float64) float64, aInit, bInit float64) (float64, error) {
+	if err := checkBracket(fx, aInit, bInit); err != nil {
+		return math.NaN(), err
+	}
+
+	cfg := DefaultBisectConfig
+	cfg.NaNPolicy = numerics.ReturnError
+
+	return bisect(ctx, fx, math.Min(aInit, bInit), math.Max(aInit, bInit), cfg)
+}
+
+// checkBracket ensures that the function values at the limits are valid and differ in sign
+// (or vanish), wrapping ErrInvalidBracket or numerics.ErrDomain otherwise
+func checkBracket(fx func(x float64) float64, a, b float64) error {
+	fa, fb := fx(a), fx(b)
+	if math.IsNaN(fa) || math.IsNaN(fb) {
+		return fmt.Errorf("%w: f(x) is NaN at limits [%v, %v]", numerics.ErrDomain, a, b)
+	}
+	if numerics.Sign(fa)*numerics.Sign(fb) > 0 {
+		return fmt.Errorf("%w: [%v, %v]", ErrInvalidBracket, a, b)
+	}
+
+	return nil
+}
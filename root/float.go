@@ -0,0 +1,36 @@
+package root
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// float32Epsilon denotes the relative spacing of float32 values around 1
+const float32Epsilon = 0x1p-23
+
+// asFloat64 converts a function of an arbitrary floating point type to a function of
+// float64 (retaining nil functions)
+func asFloat64[T constraints.Float](fx func(x T) T) func(x float64) float64 {
+	if fx == nil {
+		return nil
+	}
+	if f, ok := any(fx).(func(float64) float64); ok {
+		return f
+	}
+
+	return func(x float64) float64 {
+		return float64(fx(T(x)))
+	}
+}
+
+// precisionOptions prepends default options adapting the convergence criteria to the
+// precision of T: For types with a lower precision than float64 (i.e. float32), the target
+// precision on |f(x)| may be unattainable, hence a relative tolerance on x corresponding to
+// the machine epsilon of float32 is added (which can be overridden via WithXTolerance)
+func precisionOptions[T constraints.Float](options []func(*Finder)) []func(*Finder) {
+	eps := T(machineEpsilon)
+	if T(1+eps) != 1 {
+		return options
+	}
+
+	return append([]func(*Finder){WithXTolerance(0, 4*float32Epsilon)}, options...)
+}
@@ -1,27 +1,49 @@
 package root
 
 import (
+	"context"
+	"fmt"
 	"math"
 
 	"github.com/fako1024/numerics"
+	"golang.org/x/exp/constraints"
 )
 
-const (
-	bisectTolerance = 1e-11
-	bisectMaxIter   = 100
-)
+// DefaultBisectConfig denotes the configuration used by Bisect(), with Epsilon denoting
+// the relative tolerance on the root (see WithConfig)
+var DefaultBisectConfig = numerics.Config{
+	Epsilon:       1e-13,
+	MaxIterations: 100,
+	NaNPolicy:     numerics.ReturnNaN,
+}
 
 // Linear root finding methods
 
 // Bisect performs a simple bisection of a function within a lower and an upper
-// limit
-func Bisect(fx func(x float64) float64, aInit, bInit float64) float64 {
+// limit (performing all computations except for the function evaluations in float64)
+func Bisect[T constraints.Float](fx func(x T) T, aInit, bInit T) T {
+	res, _ := BisectWithConfig(asFloat64(fx), float64(aInit), float64(bInit), DefaultBisectConfig)
+	return T(res)
+}
+
+// BisectWithConfig performs a simple bisection of a function within a lower and an
+// upper limit using the provided configuration, with Epsilon denoting the relative
+// tolerance on the root (see WithConfig)
+func BisectWithConfig(fx func(x float64) float64, aInit, bInit float64, cfg numerics.Config) (float64, error) {
+	return bisect(context.Background(), fx, aInit, bInit, cfg)
+}
+
+// bisect performs the bisection (see BisectWithConfig), aborting if the context is cancelled
+func bisect(ctx context.Context, fx func(x float64) float64, aInit, bInit float64, cfg numerics.Config) (float64, error) {
 
 	// Define current lower / upper limits based on input parameters
 	a, b := aInit, bInit
 
 	// Bisection loop
-	for i := 0; i < bisectMaxIter; i++ {
+	for i := 0; i < cfg.MaxIterations; i++ {
+		if err := ctx.Err(); err != nil {
+			return math.NaN(), err
+		}
 
 		// Split the current interval in half
 		c := (a + b) / 2.
@@ -30,10 +52,10 @@ func Bisect(fx func(x float64) float64, aInit, bInit float64) float64 {
 		// expectation
 		fxVal := fx(c)
 		if math.IsNaN(fxVal) {
-			return fxVal
+			return fail(cfg, fmt.Errorf("%w: f(%v) is NaN", numerics.ErrDomain, c))
 		}
-		if fxVal == 0 || (b-a)/2. < bisectTolerance {
-			return c
+		if fxVal == 0 || (b-a)/2. < xTolerance(cfg, c) {
+			return c, nil
 		}
 
 		// Otherwise follow sign of function value vs. sign of expectation
@@ -45,7 +67,299 @@ func Bisect(fx func(x float64) float64, aInit, bInit float64) float64 {
 	}
 
 	// If bisection failed, return NaN
-	return math.NaN()
+	return fail(cfg, fmt.Errorf("%w: bisection within [%v, %v] after %d iterations", numerics.ErrNoConvergence, aInit, bInit, cfg.MaxIterations))
+}
+
+// DefaultITPConfig denotes the configuration used by ITP(), with Epsilon denoting the
+// relative tolerance on the root (see WithConfig)
+var DefaultITPConfig = numerics.Config{
+	Epsilon:       1e-13,
+	MaxIterations: 100,
+	NaNPolicy:     numerics.ReturnNaN,
+}
+
+// ITP performs the Interpolate-Truncate-Project method within a lower and an upper limit
+// bracketing a root, requiring at most one more function evaluation than bisection while
+// converging superlinearly for well-behaved functions, as introduced in "An Enhancement of
+// the Bisection Method Average Performance Preserving Minmax Optimality", ACM Transactions
+// on Mathematical Software 47 (2020) 5:1-5:24
+// doi:10.1145/3423597
+func ITP(fx func(x float64) float64, aInit, bInit float64) float64 {
+	res, _ := ITPWithConfig(fx, aInit, bInit, DefaultITPConfig)
+	return res
+}
+
+// ITPWithConfig performs the Interpolate-Truncate-Project method within a lower and an
+// upper limit bracketing a root using the provided configuration, with Epsilon denoting the
+// relative tolerance on the root (see WithConfig). Fails with an error wrapping
+// ErrInvalidBracket if the function values at the limits do not differ in sign
+func ITPWithConfig(fx func(x float64) float64, aInit, bInit float64, cfg numerics.Config) (float64, error) {
+
+	a, b := math.Min(aInit, bInit), math.Max(aInit, bInit)
+	ya, yb := fx(a), fx(b)
+	if math.IsNaN(ya) || math.IsNaN(yb) {
+		return fail(cfg, fmt.Errorf("%w: f(x) is NaN at limits [%v, %v]", numerics.ErrDomain, a, b))
+	}
+	if ya == 0 {
+		return a, nil
+	}
+	if yb == 0 {
+		return b, nil
+	}
+	if numerics.Sign(ya) == numerics.Sign(yb) {
+		return fail(cfg, fmt.Errorf("%w: [%v, %v]", ErrInvalidBracket, a, b))
+	}
+
+	// Orient the function such that it is increasing across the bracket
+	sign := 1.
+	if ya > 0 {
+		sign, ya, yb = -1., -ya, -yb
+	}
+
+	// Truncation parameters κ₁, κ₂ and slack n₀ as suggested by the authors
+	const (
+		kappa2 = 2.
+		n0     = 1
+	)
+	// The minmax property requires a fixed tolerance, hence the one at the point of the
+	// bracket closest to zero is used (which does not exceed the one at the root)
+	eps := xTolerance(cfg, math.Max(0., math.Max(a, -b)))
+	kappa1 := 0.2 / (b - a)
+	nMax := int(math.Ceil(math.Log2((b-a)/(2.*eps)))) + n0
+
+	for j := 0; j < cfg.MaxIterations; j++ {
+		if (b-a)/2. < eps {
+			return (a + b) / 2., nil
+		}
+
+		// Interpolate (regula falsi), truncate towards the midpoint and project onto the
+		// minmax interval around it
+		xHalf := (a + b) / 2.
+		r := eps*math.Exp2(float64(nMax-j)) - (b-a)/2.
+		delta := kappa1 * math.Pow(b-a, kappa2)
+
+		xf := (yb*a - ya*b) / (yb - ya)
+		sigma := numerics.Sign(xHalf - xf)
+		xt := xHalf
+		if delta <= math.Abs(xHalf-xf) {
+			xt = xf + float64(sigma)*delta
+		}
+		x := xHalf - float64(sigma)*r
+		if math.Abs(xt-xHalf) <= r {
+			x = xt
+		}
+
+		y := sign * fx(x)
+		switch {
+		case math.IsNaN(y):
+			return fail(cfg, fmt.Errorf("%w: f(%v) is NaN", numerics.ErrDomain, x))
+		case y > 0:
+			b, yb = x, y
+		case y < 0:
+			a, ya = x, y
+		default:
+			return x, nil
+		}
+	}
+
+	return fail(cfg, fmt.Errorf("%w: ITP within [%v, %v] after %d iterations", numerics.ErrNoConvergence, aInit, bInit, cfg.MaxIterations))
+}
+
+// machineEpsilon denotes the relative spacing of float64 values around 1
+const machineEpsilon = 0x1p-52
+
+// DefaultChandrupatlaConfig denotes the configuration used by Chandrupatla(), with Epsilon
+// denoting the relative tolerance on the root (see WithConfig)
+var DefaultChandrupatlaConfig = numerics.Config{
+	Epsilon:       1e-13,
+	MaxIterations: 100,
+	NaNPolicy:     numerics.ReturnNaN,
+}
+
+// Chandrupatla performs the bracketing method by Chandrupatla within a lower and an upper
+// limit bracketing a root, combining bisection and inverse quadratic interpolation (the
+// latter only if the function is sufficiently well-behaved across the bracket) and hence
+// avoiding the slow convergence of Brent's method for functions with flat regions near the
+// root, as introduced in "A new hybrid quadratic/bisection algorithm for finding the zero
+// of a nonlinear function without using derivatives", Advances in Engineering Software 28
+// (1997) 145–149
+// doi:10.1016/S0965-9978(96)00051-8
+func Chandrupatla(fx func(x float64) float64, aInit, bInit float64) float64 {
+	res, _ := ChandrupatlaWithConfig(fx, aInit, bInit, DefaultChandrupatlaConfig)
+	return res
+}
+
+// ChandrupatlaWithConfig performs the bracketing method by Chandrupatla within a lower and
+// an upper limit bracketing a root using the provided configuration, with Epsilon denoting
+// the relative tolerance on the root (see WithConfig). Fails with an error wrapping ErrInvalidBracket if
+// the function values at the limits do not differ in sign
+func ChandrupatlaWithConfig(fx func(x float64) float64, aInit, bInit float64, cfg numerics.Config) (float64, error) {
+
+	a, b := aInit, bInit
+	fa, fb := fx(a), fx(b)
+	if math.IsNaN(fa) || math.IsNaN(fb) {
+		return fail(cfg, fmt.Errorf("%w: f(x) is NaN at limits [%v, %v]", numerics.ErrDomain, aInit, bInit))
+	}
+	if fa == 0 {
+		return a, nil
+	}
+	if fb == 0 {
+		return b, nil
+	}
+	if numerics.Sign(fa) == numerics.Sign(fb) {
+		return fail(cfg, fmt.Errorf("%w: [%v, %v]", ErrInvalidBracket, aInit, bInit))
+	}
+
+	var c, fc float64
+	t := 0.5
+	for i := 0; i < cfg.MaxIterations; i++ {
+
+		// Evaluate the next estimate, retaining the bracket [a, b] and the previous point c
+		xt := a + t*(b-a)
+		ft := fx(xt)
+		if math.IsNaN(ft) {
+			return fail(cfg, fmt.Errorf("%w: f(%v) is NaN", numerics.ErrDomain, xt))
+		}
+		if numerics.Sign(ft) == numerics.Sign(fa) {
+			c, fc = a, fa
+		} else {
+			c, fc = b, fb
+			b, fb = a, fa
+		}
+		a, fa = xt, ft
+
+		// Terminate if the bracket has become sufficiently narrow around the best estimate
+		xm, fm := b, fb
+		if math.Abs(fa) < math.Abs(fb) {
+			xm, fm = a, fa
+		}
+		tl := (2.*machineEpsilon*math.Abs(xm) + xTolerance(cfg, xm)) / math.Abs(b-c)
+		if tl > 0.5 || fm == 0 {
+			return xm, nil
+		}
+
+		// Use inverse quadratic interpolation if it is expected to be well-behaved (i.e. the
+		// function is monotonic across the three points), otherwise bisect
+		xi := (a - b) / (c - b)
+		phi := (fa - fb) / (fc - fb)
+		if 1.-math.Sqrt(1.-xi) < phi && phi < math.Sqrt(xi) {
+			t = fa/(fb-fa)*fc/(fb-fc) + (c-a)/(b-a)*fa/(fc-fa)*fb/(fc-fb)
+		} else {
+			t = 0.5
+		}
+		t = math.Max(tl, math.Min(1.-tl, t))
+	}
+
+	return fail(cfg, fmt.Errorf("%w: Chandrupatla within [%v, %v] after %d iterations", numerics.ErrNoConvergence, aInit, bInit, cfg.MaxIterations))
+}
+
+// DefaultNewtonBisectConfig denotes the configuration used by NewtonBisect(), with Epsilon
+// denoting the relative tolerance on the root (see WithConfig)
+var DefaultNewtonBisectConfig = numerics.Config{
+	Epsilon:       1e-13,
+	MaxIterations: 100,
+	NaNPolicy:     numerics.ReturnNaN,
+}
+
+// NewtonBisect performs a safeguarded Newton-Raphson method within a lower and an upper
+// limit bracketing a root (also known as rtsafe), falling back to bisection whenever a Newton
+// step would leave the bracket or does not reduce its size sufficiently, hence combining
+// the quadratic convergence of the former with the guaranteed convergence of the latter.
+// If the derivative dfx is nil, it is approximated numerically via central differences
+func NewtonBisect(fx, dfx func(x float64) float64, aInit, bInit float64) float64 {
+	res, _ := NewtonBisectWithConfig(fx, dfx, aInit, bInit, DefaultNewtonBisectConfig)
+	return res
+}
+
+// NewtonBisectWithConfig performs a safeguarded Newton-Raphson method within a lower and an
+// upper limit bracketing a root (see NewtonBisect) using the provided configuration, with
+// Epsilon denoting the relative tolerance on the root (see WithConfig). Fails with an error
+// wrapping ErrInvalidBracket if the function values at the limits do not differ in sign
+func NewtonBisectWithConfig(fx, dfx func(x float64) float64, aInit, bInit float64, cfg numerics.Config) (float64, error) {
+
+	if dfx == nil {
+		dfx = centralDifference(fx)
+	}
+
+	fa, fb := fx(aInit), fx(bInit)
+	if math.IsNaN(fa) || math.IsNaN(fb) {
+		return fail(cfg, fmt.Errorf("%w: f(x) is NaN at limits [%v, %v]", numerics.ErrDomain, aInit, bInit))
+	}
+	if fa == 0 {
+		return aInit, nil
+	}
+	if fb == 0 {
+		return bInit, nil
+	}
+	if numerics.Sign(fa) == numerics.Sign(fb) {
+		return fail(cfg, fmt.Errorf("%w: [%v, %v]", ErrInvalidBracket, aInit, bInit))
+	}
+
+	// Orient the bracket such that f(xLow) < 0 < f(xHigh)
+	xLow, xHigh := aInit, bInit
+	if fa > 0 {
+		xLow, xHigh = bInit, aInit
+	}
+
+	x := 0.5 * (aInit + bInit)
+	dxOld := math.Abs(bInit - aInit)
+	dx := dxOld
+	fxVal, dfxVal := fx(x), dfx(x)
+
+	for i := 0; i < cfg.MaxIterations; i++ {
+		if math.IsNaN(fxVal) || math.IsNaN(dfxVal) {
+			return fail(cfg, fmt.Errorf("%w: f(%v) is NaN", numerics.ErrDomain, x))
+		}
+		if fxVal == 0 {
+			return x, nil
+		}
+
+		// Bisect if the Newton step leaves the bracket or the step size does not decrease
+		// sufficiently (i.e. by at least half compared to the step before the last one)
+		useBisection := ((x-xHigh)*dfxVal-fxVal)*((x-xLow)*dfxVal-fxVal) > 0 || math.Abs(2.*fxVal) > math.Abs(dxOld*dfxVal)
+		dxOld = dx
+		if useBisection {
+			dx = 0.5 * (xHigh - xLow)
+			x = xLow + dx
+			if x == xLow {
+				return x, nil
+			}
+		} else {
+			xPrev := x
+			dx = fxVal / dfxVal
+			x -= dx
+			if x == xPrev {
+				return x, nil
+			}
+		}
+		if math.Abs(dx) < xTolerance(cfg, x) {
+			return x, nil
+		}
+
+		// Maintain the bracket
+		fxVal, dfxVal = fx(x), dfx(x)
+		if fxVal < 0 {
+			xLow = x
+		} else {
+			xHigh = x
+		}
+	}
+
+	return fail(cfg, fmt.Errorf("%w: Newton-bisection within [%v, %v] after %d iterations", numerics.ErrNoConvergence, aInit, bInit, cfg.MaxIterations))
+}
+
+// xTolerance returns the tolerance on a root at x, with Epsilon of the configuration being
+// relative to 1 + |x| (see WithConfig)
+func xTolerance(cfg numerics.Config, x float64) float64 {
+	return cfg.Epsilon * (1. + math.Abs(x))
+}
+
+// fail reports a failure according to the NaN policy of the configuration
+func fail(cfg numerics.Config, err error) (float64, error) {
+	if cfg.NaNPolicy == numerics.ReturnError {
+		return math.NaN(), err
+	}
+	return math.NaN(), nil
 }
 
 // Non-linear root finding methods
@@ -67,3 +381,48 @@ func Homeier(x float64, fx, dfx func(float64) float64) float64 {
 	fxVal := fx(x)
 	return x - fxVal/dfx(x-0.5*fxVal/dfx(x))
 }
+
+// Steffensen performs the derivative-free method by Steffensen (ignoring dfx, which may
+// hence be nil when calling Find), approximating the derivative by the difference quotient
+// (f(x + f(x)) - f(x)) / f(x) and attaining quadratic convergence close to the root. Far
+// from the root (i.e. for large |f(x)|) convergence may be slow, hence the initial value
+// should be chosen accordingly
+func Steffensen(x float64, fx, _ func(float64) float64) float64 {
+	fxVal := fx(x)
+	if fxVal == 0 {
+		return x
+	}
+	return x - fxVal*fxVal/(fx(x+fxVal)-fxVal)
+}
+
+// secantStep denotes the relative step used to initialize the secant method
+const secantStep = 1e-4
+
+// Secant returns a derivative-free method approximating the derivative by the slope of the
+// secant through the current and the previous iterate (ignoring dfx, which may hence be nil
+// when calling Find). Initially, or if the provided x does not continue the sequence of
+// iterates (e.g. after an adjustment by the Finder or when used in a subsequent call to
+// Find), the previous iterate is replaced by a small finite step from x. Since the returned
+// Method retains state between calls it must not be used concurrently
+func Secant() Method {
+	var xPrev, fxPrev, xLast float64
+	restart := true
+
+	return func(x float64, fx, _ func(float64) float64) float64 {
+		fxVal := fx(x)
+		if fxVal == 0 {
+			return x
+		}
+
+		if restart || x != xLast || x == xPrev {
+			xPrev = x + secantStep*math.Max(math.Abs(x), 1.)
+			fxPrev = fx(xPrev)
+		}
+
+		xNew := x - fxVal*(x-xPrev)/(fxVal-fxPrev)
+		xPrev, fxPrev, xLast = x, fxVal, xNew
+		restart = false
+
+		return xNew
+	}
+}
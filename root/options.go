@@ -1,5 +1,9 @@
 package root
 
+import (
+	"github.com/fako1024/numerics"
+)
+
 // WithMinIterations sets a minimum number of iterations to perform
 func WithMinIterations(nIterations int) func(*Finder) {
 	return func(n *Finder) {
@@ -22,6 +26,25 @@ func WithTargetPrecision(targetPrecision float64) func(*Finder) {
 	}
 }
 
+// WithFTolerance sets the tolerance on the residual, i.e. the process is considered to be
+// converged once |f(x)| < tol (equivalent to WithTargetPrecision). A tolerance of zero
+// disables this criterion, e.g. in favor of a tolerance on x (see WithXTolerance)
+func WithFTolerance(tol float64) func(*Finder) {
+	return func(n *Finder) {
+		n.targetPrecision = tol
+	}
+}
+
+// WithXTolerance sets an absolute and relative tolerance on the step size, i.e. the process
+// is (additionally to the tolerance on the residual) considered to be converged once the
+// change of x in an iteration does not exceed abs + rel·|x|. This is more robust than a
+// tolerance on the residual if the slope of f at the root is very small or very large
+func WithXTolerance(abs, rel float64) func(*Finder) {
+	return func(n *Finder) {
+		n.xTolAbs, n.xTolRel = abs, rel
+	}
+}
+
 // WithMethod sets a specific method to be used to perform the iterative process
 func WithMethod(method Method) func(*Finder) {
 	return func(n *Finder) {
@@ -43,3 +66,26 @@ func WithHeuristics() func(*Finder) {
 		n.useHeuristics = true
 	}
 }
+
+// WithIterationCallback sets a function invoked after each iteration with the number of
+// the iteration, the current estimate of the root and its function value (e.g. for logging
+// or plotting). If the callback returns false, the process is stopped and the current
+// estimate is returned (without error, allowing for custom stopping rules)
+func WithIterationCallback(fn func(iter int, x, fx float64) bool) func(*Finder) {
+	return func(n *Finder) {
+		n.callback = fn
+	}
+}
+
+// WithConfig sets the maximum number of iterations and the convergence criterion from a
+// shared configuration. As for all methods of this package taking a configuration, Epsilon
+// denotes the tolerance on x relative to 1 + |x| (i.e. relative for large and absolute for
+// small roots), hence it is equivalent to WithXTolerance(Epsilon, Epsilon), replacing the
+// tolerance on the residual
+func WithConfig(cfg numerics.Config) func(*Finder) {
+	return func(n *Finder) {
+		n.maxIterations = cfg.MaxIterations
+		n.targetPrecision = 0
+		n.xTolAbs, n.xTolRel = cfg.Epsilon, cfg.Epsilon
+	}
+}
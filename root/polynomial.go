@@ -0,0 +1,207 @@
+package root
+
+import (
+	"cmp"
+	"math"
+	"math/cmplx"
+	"slices"
+)
+
+const (
+	polynomialMaxIterations = 1000
+	polynomialTolerance     = 1e-15
+
+	// Relative distance below which root estimates are considered a cluster (and hence
+	// candidates for a multiple root, which the Durand-Kerner method only resolves to
+	// within ~ε^(1/m) for multiplicity m)
+	polynomialClusterTolerance = 1e-2
+)
+
+// Polynomial returns all (real and complex) roots of the polynomial with the provided
+// coefficients (in ascending order, i.e. c[0] + c[1]·x + c[2]·x² + ...) via the Durand-Kerner
+// (Weierstrass) method, repeating multiple roots (refined from the respective clusters of
+// estimates) according to their multiplicity. Roots are
+// sorted by their real and imaginary part, with the imaginary part of real roots (within
+// numerical precision) set to zero. Returns nil for constant polynomials
+func Polynomial(coeffs []float64) []complex128 {
+
+	// Strip vanishing leading coefficients (i.e. determine the actual degree)
+	n := len(coeffs)
+	for n > 0 && coeffs[n-1] == 0 {
+		n--
+	}
+	if n <= 1 {
+		return nil
+	}
+
+	// Factor out roots at zero exactly and normalize the remaining polynomial
+	roots := make([]complex128, 0, n-1)
+	low := 0
+	for coeffs[low] == 0 {
+		roots = append(roots, 0)
+		low++
+	}
+	monic := make([]complex128, n-low)
+	for i := range monic {
+		monic[i] = complex(coeffs[low+i]/coeffs[n-1], 0)
+	}
+
+	roots = append(roots, refineClusters(monic, durandKerner(monic))...)
+	for i, z := range roots {
+		if math.Abs(imag(z)) <= 1e3*polynomialTolerance*math.Max(cmplx.Abs(z), 1.) {
+			roots[i] = complex(real(z), 0)
+		}
+	}
+	slices.SortFunc(roots, func(a, b complex128) int {
+		if c := cmp.Compare(real(a), real(b)); c != 0 {
+			return c
+		}
+		return cmp.Compare(imag(a), imag(b))
+	})
+
+	return roots
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// durandKerner determines the roots of a monic polynomial (with coefficients in ascending
+// order) by simultaneously refining all root estimates
+func durandKerner(monic []complex128) []complex128 {
+
+	degree := len(monic) - 1
+	if degree == 1 {
+		return []complex128{-monic[0]}
+	}
+
+	// Start from points on a circle (rotated by a non-rational angle to avoid symmetries)
+	// with a radius given by the Cauchy bound of the roots
+	var bound float64
+	for _, c := range monic[:degree] {
+		bound = math.Max(bound, cmplx.Abs(c))
+	}
+	zs := make([]complex128, degree)
+	for i := range zs {
+		zs[i] = complex(1.+bound, 0) * cmplx.Pow(0.4+0.9i, complex(float64(i), 0))
+	}
+
+	for iter := 0; iter < polynomialMaxIterations; iter++ {
+		converged := true
+		for i, z := range zs {
+			den := complex(1, 0)
+			for j, other := range zs {
+				if j != i {
+					den *= z - other
+				}
+			}
+			if den == 0 {
+				continue
+			}
+
+			delta := horner(monic, z) / den
+			zs[i] = z - delta
+			if cmplx.Abs(delta) > polynomialTolerance*math.Max(cmplx.Abs(z), 1.) {
+				converged = false
+			}
+		}
+		if converged {
+			break
+		}
+	}
+
+	return zs
+}
+
+// refineClusters replaces clusters of root estimates by a single multiple root (repeated
+// according to its multiplicity m), determined via Newton iterations on the (m-1)-th
+// derivative of the polynomial (for which it is a simple root). Clusters that turn out
+// not to be consistent with a multiple root (i.e. distinct, but close roots) are retained
+func refineClusters(monic []complex128, zs []complex128) []complex128 {
+
+	assigned := make([]bool, len(zs))
+	for i := range zs {
+		if assigned[i] {
+			continue
+		}
+
+		// Collect all estimates (transitively) close to the current one
+		cluster := []int{i}
+		assigned[i] = true
+		for k := 0; k < len(cluster); k++ {
+			z := zs[cluster[k]]
+			for j := range zs {
+				if !assigned[j] && cmplx.Abs(zs[j]-z) <= polynomialClusterTolerance*math.Max(cmplx.Abs(z), 1.) {
+					assigned[j] = true
+					cluster = append(cluster, j)
+				}
+			}
+		}
+		if len(cluster) == 1 {
+			continue
+		}
+
+		// The perturbations of the individual estimates largely cancel in the center of
+		// the cluster, providing an accurate starting point for the refinement
+		var center complex128
+		for _, j := range cluster {
+			center += zs[j]
+		}
+		center /= complex(float64(len(cluster)), 0)
+
+		if z, ok := multipleRoot(monic, center, len(cluster)); ok {
+			for _, j := range cluster {
+				zs[j] = z
+			}
+		}
+	}
+
+	return zs
+}
+
+// multipleRoot refines a root of multiplicity m of a polynomial starting from z and
+// verifies that it is indeed a root of all derivatives of order < m
+func multipleRoot(monic []complex128, z complex128, m int) (complex128, bool) {
+
+	derivs := make([][]complex128, m+1)
+	derivs[0] = monic
+	for k := 1; k <= m; k++ {
+		derivs[k] = make([]complex128, len(derivs[k-1])-1)
+		for i := range derivs[k] {
+			derivs[k][i] = complex(float64(i+1), 0) * derivs[k-1][i+1]
+		}
+	}
+
+	for iter := 0; iter < polynomialMaxIterations; iter++ {
+		d := horner(derivs[m], z)
+		if d == 0 {
+			break
+		}
+		delta := horner(derivs[m-1], z) / d
+		z -= delta
+		if cmplx.Abs(delta) <= polynomialTolerance*math.Max(cmplx.Abs(z), 1.) {
+			break
+		}
+	}
+
+	// Compare the values against the scale of their rounding errors
+	absZ := cmplx.Abs(z)
+	for _, deriv := range derivs[:m] {
+		var scale float64
+		for i := len(deriv) - 1; i >= 0; i-- {
+			scale = scale*absZ + cmplx.Abs(deriv[i])
+		}
+		if cmplx.Abs(horner(deriv, z)) > 1e3*polynomialTolerance*scale {
+			return z, false
+		}
+	}
+
+	return z, true
+}
+
+// horner evaluates a polynomial (with coefficients in ascending order) at z
+func horner(coeffs []complex128, z complex128) complex128 {
+	res := coeffs[len(coeffs)-1]
+	for i := len(coeffs) - 2; i >= 0; i-- {
+		res = res*z + coeffs[i]
+	}
+	return res
+}
@@ -1,7 +1,12 @@
 package root
 
 import (
+	"context"
+	"fmt"
 	"math"
+
+	"github.com/fako1024/numerics"
+	"golang.org/x/exp/constraints"
 )
 
 // Finder defines a non-linear approach to root finding
@@ -14,16 +19,84 @@ type Finder struct {
 	minIterations   int
 	maxIterations   int
 	targetPrecision float64
+	xTolAbs         float64
+	xTolRel         float64
 	useHeuristics   bool
+	callback        func(iter int, x, fx float64) bool
+
+	ctx    context.Context
+	nEvals int
 }
 
 // Find perform a non-linear iterative root-finding method using the
-// provided parameters / options
-func Find(fx, dfx func(x float64) float64, xInit float64, options ...func(*Finder)) float64 {
+// provided parameters / options. If the derivative dfx is nil, it is approximated
+// numerically via central differences (unless not required by the method, e.g. Secant).
+// All computations except for the function evaluations are performed in float64, with
+// the convergence criteria adapted to the precision of T (i.e. for float32 a relative
+// tolerance on x corresponding to its precision is added, see WithXTolerance)
+func Find[T constraints.Float](fx, dfx func(x T) T, xInit T, options ...func(*Finder)) T {
+	return T(Solve(asFloat64(fx), asFloat64(dfx), float64(xInit), precisionOptions[T](options)...).Root)
+}
+
+// FindResult denotes the result of a non-linear root-finding process, including diagnostics
+// of the iterative process
+type FindResult struct {
+
+	// Root denotes the estimated root and FValue the function value at the root
+	Root   float64
+	FValue float64
+
+	// Iterations denotes the number of iterations performed and FuncEvals the total number
+	// of evaluations of the function and its derivative
+	Iterations int
+	FuncEvals  int
+
+	// Converged denotes if the target precision (i.e. |f(x)| below the target precision or,
+	// if set, the step size below the tolerance on x) was reached, as opposed to the maximum
+	// number of iterations being exhausted or the process running into a NaN
+	Converged bool
+
+	// Precision denotes the absolute change of the root estimate in the last iteration
+	Precision float64
+
+	err error
+}
+
+// Solve perform a non-linear iterative root-finding method using the provided
+// parameters / options (see Find), returning the root along with diagnostics of the
+// iterative process
+func Solve(fx, dfx func(x float64) float64, xInit float64, options ...func(*Finder)) FindResult {
+	return solve(context.Background(), fx, dfx, xInit, options...)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// solve performs the root finding process (see Solve), aborting if the context is cancelled
+func solve(ctx context.Context, fx, dfx func(x float64) float64, xInit float64, options ...func(*Finder)) FindResult {
+
+	obj := newFinder(options...)
+	obj.ctx = ctx
+
+	// Count all function evaluations (including those of a numerical derivative)
+	obj.fx = func(x float64) float64 {
+		obj.nEvals++
+		return fx(x)
+	}
+	obj.dfx = centralDifference(obj.fx)
+	if dfx != nil {
+		obj.dfx = func(x float64) float64 {
+			obj.nEvals++
+			return dfx(x)
+		}
+	}
+
+	return obj.loop(xInit)
+}
+
+// newFinder instantiates a Finder with default parameters, applying the provided options
+func newFinder(options ...func(*Finder)) *Finder {
 
 	obj := &Finder{
-		fx:     fx,
-		dfx:    dfx,
 		method: NewtonRaphson,
 
 		xMin: -math.MaxFloat64,
@@ -39,13 +112,27 @@ func Find(fx, dfx func(x float64) float64, xInit float64, options ...func(*Finde
 		option(obj)
 	}
 
-	return obj.loop(xInit)
+	return obj
 }
 
-////////////////////////////////////////////////////////////////////////////////
+// centralDifference returns a numerical approximation of the derivative of fx via central
+// differences, with the step scaled to x and to the cube root of the machine epsilon (which
+// balances truncation and rounding errors)
+func centralDifference(fx func(x float64) float64) func(x float64) float64 {
+	return func(x float64) float64 {
+		h := centralDifferenceStep * math.Max(math.Abs(x), 1.)
+
+		// Ensure that the step is exactly representable
+		xUp, xDown := x+h, x-h
+		return (fx(xUp) - fx(xDown)) / (xUp - xDown)
+	}
+}
+
+// centralDifferenceStep denotes the relative step used by centralDifference, i.e. ∛ε
+var centralDifferenceStep = math.Cbrt(machineEpsilon)
 
 // loop executed the actual root finding loop
-func (n *Finder) loop(xInit float64) float64 {
+func (n *Finder) loop(xInit float64) FindResult {
 
 	// Initialize loop variables
 	x := xInit
@@ -54,6 +141,11 @@ func (n *Finder) loop(xInit float64) float64 {
 
 	for {
 
+		// Abort if the context has been cancelled
+		if err := n.ctx.Err(); err != nil {
+			return n.failure(nIter, err)
+		}
+
 		// Determine new value for x according to the defined root-finding method
 		xNew := n.method(x, n.fx, n.dfx)
 
@@ -72,9 +164,13 @@ func (n *Finder) loop(xInit float64) float64 {
 			}
 		}
 
-		// If the current value is NaN, return it
+		// If the current value is NaN (or infinite without heuristics, i.e. due to a vanishing
+		// derivative), return NaN
 		if math.IsNaN(xNew) {
-			return math.NaN()
+			return n.failure(nIter, fmt.Errorf("%w: iteration from x = %v yields NaN", numerics.ErrDomain, x))
+		}
+		if math.IsInf(xNew, 0) && !n.useHeuristics {
+			return n.failure(nIter, fmt.Errorf("%w: at x = %v", ErrDerivativeZero, x))
 		}
 
 		// If enabled, perform heuristic approach to circumvent known limitations of the
@@ -109,20 +205,56 @@ func (n *Finder) loop(xInit float64) float64 {
 			}
 		}
 
+		precision := math.Abs(xNew - x)
 		x = xNew
 		nIter++
 
-		// If the minimum number of iterations has been performed...
-		if nIter >= n.minIterations {
+		// Unless required for the termination criteria or the iteration callback, skip the
+		// evaluation of the function
+		terminate := nIter >= n.minIterations
+		if !terminate && n.callback == nil {
+			continue
+		}
+		fxVal := n.fx(x)
+		aborted := n.callback != nil && !n.callback(nIter, x, fxVal)
 
-			// ... and target precision has been reached or the maximum number of iterations
-			// has been performed, break
-			if math.Abs(n.fx(x)) < n.targetPrecision || nIter >= n.maxIterations {
-				break
+		// If the minimum number of iterations has been performed and target precision has
+		// been reached or the maximum number of iterations has been performed (or if the
+		// callback requested to stop), return value from latest successful iteration
+		if converged := n.converged(x, fxVal, precision); aborted || (terminate && (converged || nIter >= n.maxIterations)) {
+			res := FindResult{
+				Root:       x,
+				FValue:     fxVal,
+				Iterations: nIter,
+				FuncEvals:  n.nEvals,
+				Converged:  converged,
+				Precision:  precision,
+			}
+			if !converged && !aborted {
+				res.err = fmt.Errorf("%w: |f(x)| = %v after %d iterations", numerics.ErrNoConvergence, math.Abs(fxVal), nIter)
 			}
+			return res
 		}
 	}
+}
 
-	// Return value from latest successful iteration
-	return x
+// converged determines if the convergence criteria on the residual |f(x)| or (if set) on the
+// step size are fulfilled
+func (n *Finder) converged(x, fxVal, step float64) bool {
+	if math.Abs(fxVal) < n.targetPrecision {
+		return true
+	}
+	return (n.xTolAbs > 0 || n.xTolRel > 0) && step <= n.xTolAbs+n.xTolRel*math.Abs(x)
+}
+
+// failure returns the result of a failed root finding process
+func (n *Finder) failure(nIter int, err error) FindResult {
+	return FindResult{
+		Root:       math.NaN(),
+		FValue:     math.NaN(),
+		Iterations: nIter,
+		FuncEvals:  n.nEvals,
+		Precision:  math.NaN(),
+		err:        err,
+	}
 }
@@ -1,12 +1,18 @@
 package root
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
+	"math/cmplx"
 	"path"
 	"reflect"
 	"runtime"
 	"testing"
+
+	"github.com/fako1024/numerics"
+	"github.com/fako1024/numerics/linalg"
 )
 
 const expectedPrecision = 1e-9
@@ -14,6 +20,7 @@ const expectedPrecision = 1e-9
 var methods = []Method{
 	NewtonRaphson,
 	Homeier,
+	Secant(),
 }
 
 type testCaseBisect struct {
@@ -37,10 +44,34 @@ func TestOptions(t *testing.T) {
 		WithMinIterations(5),
 		WithMaxIterations(25),
 		WithTargetPrecision(1e-9),
+		WithFTolerance(1e-9),
+		WithXTolerance(1e-12, 1e-12),
 		WithLimits(-1e9, 1e9),
+		WithConfig(numerics.Config{Epsilon: 1e-9, MaxIterations: 25}),
 	)
 }
 
+func TestBisectWithConfig(t *testing.T) {
+	cfg := numerics.Config{
+		Epsilon:       1e-6,
+		MaxIterations: 5,
+		NaNPolicy:     numerics.ReturnError,
+	}
+
+	if root, err := BisectWithConfig(func(x float64) float64 {
+		return x*x - 612
+	}, 1., 50., cfg); !math.IsNaN(root) || !errors.Is(err, numerics.ErrNoConvergence) {
+		t.Fatalf("Unexpected result for insufficient number of iterations: %v / %v", root, err)
+	}
+
+	cfg.MaxIterations = 100
+	if root, err := BisectWithConfig(func(x float64) float64 {
+		return x*x - 612
+	}, 1., 50., cfg); err != nil || math.Abs(root-math.Sqrt(612)) > 1e-6*(1.+math.Sqrt(612)) {
+		t.Fatalf("Unexpected result: %v / %v", root, err)
+	}
+}
+
 func TestBisectNaN(t *testing.T) {
 	if root := Bisect(func(x float64) float64 {
 		return math.NaN()
@@ -84,6 +115,148 @@ func TestBisectTable(t *testing.T) {
 
 }
 
+func TestITP(t *testing.T) {
+
+	cases := []testCaseBisect{
+		{fx: func(x float64) float64 { return x*x - 612 }, xMin: 1., xMax: 50.},
+		{fx: func(x float64) float64 { return math.Cos(x) - x*x*x }, xMin: 0.1, xMax: 1.},
+		{fx: func(x float64) float64 { return math.Exp(-x) - 0.5 }, xMin: -1., xMax: 3.},
+		{fx: func(x float64) float64 { return math.Tanh(20. * (x - 0.3)) }, xMin: -2., xMax: 2.},
+	}
+
+	for _, cs := range cases {
+		var nITP, nBisect int
+		root := ITP(func(x float64) float64 { nITP++; return cs.fx(x) }, cs.xMin, cs.xMax)
+		if math.IsNaN(root) || math.Abs(cs.fx(root)) > expectedPrecision {
+			t.Fatalf("Estimated value of f(x) within [%v, %v] deviates significantly from expectation: have %v, want 0", cs.xMin, cs.xMax, cs.fx(root))
+		}
+
+		// ITP never requires (significantly) more function evaluations than bisection
+		_ = Bisect(func(x float64) float64 { nBisect++; return cs.fx(x) }, cs.xMin, cs.xMax)
+		if nITP > nBisect+2 {
+			t.Fatalf("Unexpected number of function evaluations within [%v, %v]: %d (ITP) vs. %d (bisection)", cs.xMin, cs.xMax, nITP, nBisect)
+		}
+	}
+
+	// Limits may be provided in any order
+	if root := ITP(func(x float64) float64 { return math.Exp(-x) - 0.5 }, 3., -1.); math.Abs(root-math.Ln2) > expectedPrecision {
+		t.Fatalf("Unexpected root for reversed limits: %v", root)
+	}
+
+	cfg := DefaultITPConfig
+	cfg.NaNPolicy = numerics.ReturnError
+	if root, err := ITPWithConfig(func(x float64) float64 {
+		return x*x + 1.
+	}, -1., 1., cfg); !math.IsNaN(root) || !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected result for non-bracketing limits: %v / %v", root, err)
+	}
+	cfg.MaxIterations = 3
+	if root, err := ITPWithConfig(func(x float64) float64 {
+		return math.Tanh(20. * (x - 0.3))
+	}, -2., 2., cfg); !math.IsNaN(root) || !errors.Is(err, numerics.ErrNoConvergence) {
+		t.Fatalf("Unexpected result for insufficient number of iterations: %v / %v", root, err)
+	}
+}
+
+func TestChandrupatla(t *testing.T) {
+
+	cases := []testCaseBisect{
+		{fx: func(x float64) float64 { return x*x - 612 }, xMin: 1., xMax: 50.},
+		{fx: func(x float64) float64 { return math.Cos(x) - x*x*x }, xMin: 1., xMax: 0.1},
+		{fx: func(x float64) float64 { return math.Tanh(20. * (x - 0.3)) }, xMin: -2., xMax: 2.},
+
+		// Inversion of a CDF with flat regions (for x → 0 and x → 1)
+		{fx: func(x float64) float64 { return numerics.BetaIncompleteRegular(x, 50., 0.5) - 0.05 }, xMin: 0., xMax: 1.},
+		{fx: func(x float64) float64 { return numerics.BetaIncompleteRegular(x, 0.2, 30.) - 0.999 }, xMin: 0., xMax: 1.},
+	}
+
+	for _, cs := range cases {
+		var nEval int
+		root := Chandrupatla(func(x float64) float64 { nEval++; return cs.fx(x) }, cs.xMin, cs.xMax)
+		if math.IsNaN(root) || math.Abs(cs.fx(root)) > expectedPrecision {
+			t.Fatalf("Estimated value of f(x) within [%v, %v] deviates significantly from expectation: have %v, want 0", cs.xMin, cs.xMax, cs.fx(root))
+		}
+		if nEval > 20 {
+			t.Fatalf("Unexpected number of function evaluations within [%v, %v]: %d", cs.xMin, cs.xMax, nEval)
+		}
+	}
+
+	cfg := DefaultChandrupatlaConfig
+	cfg.NaNPolicy = numerics.ReturnError
+	if root, err := ChandrupatlaWithConfig(func(x float64) float64 {
+		return x*x + 1.
+	}, -1., 1., cfg); !math.IsNaN(root) || !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected result for non-bracketing limits: %v / %v", root, err)
+	}
+	if root, err := ChandrupatlaWithConfig(func(x float64) float64 {
+		return math.Log(x)
+	}, -1., 2., cfg); !math.IsNaN(root) || !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected result for NaN function value: %v / %v", root, err)
+	}
+	cfg.MaxIterations = 3
+	if root, err := ChandrupatlaWithConfig(func(x float64) float64 {
+		return math.Tanh(20. * (x - 0.3))
+	}, -2., 2., cfg); !math.IsNaN(root) || !errors.Is(err, numerics.ErrNoConvergence) {
+		t.Fatalf("Unexpected result for insufficient number of iterations: %v / %v", root, err)
+	}
+}
+
+func TestNewtonBisect(t *testing.T) {
+
+	testCases := map[string]testCaseNewton{
+		"SquareRoot2": {
+			fx: func(x float64) float64 {
+				return x*x - 612
+			},
+			dfx: func(x float64) float64 {
+				return 2 * x
+			},
+		},
+
+		// Plain Newton iterations diverge / cycle for these from the center of the bracket
+		"Arctan": {
+			fx:  math.Atan,
+			dfx: func(x float64) float64 { return 1. / (1. + x*x) },
+		},
+		"Cycle": {
+			fx: func(x float64) float64 {
+				return x*x*x - 2.*x + 2.
+			},
+			dfx: func(x float64) float64 {
+				return 3*x*x - 2.
+			},
+		},
+	}
+	brackets := map[string][2]float64{
+		"SquareRoot2": {1., 50.},
+		"Arctan":      {-3., 10.},
+		"Cycle":       {-3., 1.},
+	}
+
+	for testName, cs := range testCases {
+		for _, dfx := range []func(float64) float64{cs.dfx, nil} {
+			nEvals := 0
+			root := NewtonBisect(func(x float64) float64 { nEvals++; return cs.fx(x) }, dfx, brackets[testName][0], brackets[testName][1])
+			if math.IsNaN(root) || math.Abs(cs.fx(root)) > expectedPrecision {
+				t.Fatalf("Estimated value of f(x) for %s deviates significantly from expectation: have %v, want 0", testName, cs.fx(root))
+			}
+			if dfx != nil && nEvals > 30 {
+				t.Fatalf("Unexpected number of function evaluations for %s: %d", testName, nEvals)
+			}
+		}
+	}
+
+	cfg := DefaultNewtonBisectConfig
+	cfg.NaNPolicy = numerics.ReturnError
+	if root, err := NewtonBisectWithConfig(math.Atan, nil, 1., 2., cfg); !math.IsNaN(root) || !errors.Is(err, ErrInvalidBracket) {
+		t.Fatalf("Unexpected result for invalid bracket: %v / %v", root, err)
+	}
+	cfg.MaxIterations = 2
+	if root, err := NewtonBisectWithConfig(math.Atan, nil, -3., 10., cfg); !math.IsNaN(root) || !errors.Is(err, numerics.ErrNoConvergence) {
+		t.Fatalf("Unexpected result for insufficient number of iterations: %v / %v", root, err)
+	}
+}
+
 func TestNewtonTable(t *testing.T) {
 
 	testCases := map[string]testCaseNewton{
@@ -124,6 +297,520 @@ func TestNewtonTable(t *testing.T) {
 	}
 }
 
+func TestNumericalDerivative(t *testing.T) {
+
+	testCases := map[string]testCaseNewton{
+		"SquareRoot2": {
+			fx: func(x float64) float64 {
+				return x*x - 612
+			},
+			xInit: 10.,
+		},
+		"CosineEquation": {
+			fx: func(x float64) float64 {
+				return math.Cos(x) - x*x*x
+			},
+			xInit: 0.5,
+		},
+		"LargeScale": {
+			fx: func(x float64) float64 {
+				return math.Log(x) - 20.
+			},
+			xInit: 1e8,
+		},
+	}
+
+	for testName, cs := range testCases {
+		for _, method := range methods {
+			t.Run(caseName(method, testName), func(t *testing.T) {
+				root := Find(cs.fx, nil, cs.xInit, WithHeuristics(), WithMethod(method))
+
+				if math.IsNaN(root) || math.IsInf(root, 0) {
+					t.Fatalf("Unexpected non-numerical result for %s: %v", testName, root)
+				}
+
+				if math.Abs(cs.fx(root)) > expectedPrecision {
+					t.Fatalf("Estimated value of f(x) for %s deviates significantly from expectation: have %.5f, want 0", testName, cs.fx(root))
+				}
+			})
+		}
+	}
+
+	dfx := centralDifference(math.Sin)
+	for _, x := range []float64{0., 1., -3., 10.} {
+		if math.Abs(dfx(x)-math.Cos(x)) > 1e-8 {
+			t.Fatalf("Unexpected numerical derivative at %v: have %v, want %v", x, dfx(x), math.Cos(x))
+		}
+	}
+}
+
+func TestSolve(t *testing.T) {
+
+	fx := func(x float64) float64 {
+		return x*x - 612
+	}
+	dfx := func(x float64) float64 {
+		return 2 * x
+	}
+
+	res := Solve(fx, dfx, 10.)
+	if !res.Converged || math.Abs(res.Root-math.Sqrt(612)) > expectedPrecision || res.FValue != fx(res.Root) {
+		t.Fatalf("Unexpected result: %+v", res)
+	}
+	if res.Iterations < 5 || res.Iterations >= 25 || res.FuncEvals != 2*res.Iterations+(res.Iterations-4) || res.Precision > 1e-6 {
+		t.Fatalf("Unexpected diagnostics: %+v", res)
+	}
+
+	// Without derivative, each iteration requires three function evaluations
+	if res := Solve(fx, nil, 10.); !res.Converged || res.FuncEvals != 3*res.Iterations+(res.Iterations-4) {
+		t.Fatalf("Unexpected diagnostics for numerical derivative: %+v", res)
+	}
+
+	// Exhausting the maximum number of iterations
+	if res := Solve(fx, dfx, 1e6, WithMaxIterations(5)); res.Converged || res.Iterations != 5 || math.Abs(res.FValue) < 1e-9 {
+		t.Fatalf("Unexpected result for insufficient number of iterations: %+v", res)
+	}
+
+	if res := Solve(func(x float64) float64 {
+		return math.NaN()
+	}, dfx, 1.); res.Converged || !math.IsNaN(res.Root) || !math.IsNaN(res.FValue) {
+		t.Fatalf("Unexpected result for NaN function value: %+v", res)
+	}
+}
+
+func TestErrorVariants(t *testing.T) {
+
+	fx := func(x float64) float64 {
+		return x*x - 612
+	}
+	if root, err := FindErr(fx, nil, 10.); err != nil || math.Abs(root-math.Sqrt(612)) > expectedPrecision {
+		t.Fatalf("Unexpected result: %v / %v", root, err)
+	}
+	if root, err := FindErr(fx, nil, 1e6, WithMaxIterations(5)); !math.IsNaN(root) || !errors.Is(err, numerics.ErrNoConvergence) {
+		t.Fatalf("Unexpected result for insufficient number of iterations: %v / %v", root, err)
+	}
+	if root, err := FindErr(func(x float64) float64 {
+		return 1. - x*x
+	}, func(x float64) float64 {
+		return -2. * x
+	}, 0.); !math.IsNaN(root) || !errors.Is(err, ErrDerivativeZero) || !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected result for stationary point: %v / %v", root, err)
+	}
+	if root, err := FindErr(math.Log, nil, -1.); !math.IsNaN(root) || !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected result for NaN function value: %v / %v", root, err)
+	}
+
+	if root, err := BisectErr(fx, 50., 1.); err != nil || math.Abs(root-math.Sqrt(612)) > 1e-9 {
+		t.Fatalf("Unexpected result: %v / %v", root, err)
+	}
+	if root, err := BisectErr(fx, 30., 50.); !math.IsNaN(root) || !errors.Is(err, ErrInvalidBracket) {
+		t.Fatalf("Unexpected result for invalid bracket: %v / %v", root, err)
+	}
+	if root, err := BisectErr(math.Log, -1., 2.); !math.IsNaN(root) || !errors.Is(err, numerics.ErrDomain) || errors.Is(err, ErrInvalidBracket) {
+		t.Fatalf("Unexpected result for NaN function value: %v / %v", root, err)
+	}
+	if _, err := ITPWithConfig(fx, 30., 50., numerics.Config{Epsilon: 1e-9, MaxIterations: 10, NaNPolicy: numerics.ReturnError}); !errors.Is(err, ErrInvalidBracket) {
+		t.Fatalf("Unexpected error for invalid bracket: %v", err)
+	}
+}
+
+func TestContext(t *testing.T) {
+
+	fx := func(x float64) float64 {
+		return x*x - 612
+	}
+	if root, err := FindContext(context.Background(), fx, nil, 10.); err != nil || math.Abs(root-math.Sqrt(612)) > expectedPrecision {
+		t.Fatalf("Unexpected result: %v / %v", root, err)
+	}
+	if root, err := BisectContext(context.Background(), fx, 1., 50.); err != nil || math.Abs(root-math.Sqrt(612)) > 1e-9 {
+		t.Fatalf("Unexpected result: %v / %v", root, err)
+	}
+
+	// Cancel the context from within the (expensive) function after a few evaluations
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	nEvals := 0
+	slowFx := func(x float64) float64 {
+		if nEvals++; nEvals == 5 {
+			cancel()
+		}
+		return fx(x)
+	}
+	if root, err := FindContext(ctx, slowFx, nil, 10.); !math.IsNaN(root) || !errors.Is(err, context.Canceled) || nEvals > 6 {
+		t.Fatalf("Unexpected result for cancelled context: %v / %v (%d evaluations)", root, err, nEvals)
+	}
+	if root, err := BisectContext(ctx, fx, 1., 50.); !math.IsNaN(root) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Unexpected result for cancelled context: %v / %v", root, err)
+	}
+}
+
+func TestFindBatch(t *testing.T) {
+
+	// Roots of cos(x), depending on the initial value
+	xInits := []float64{0.5, 3., 6., 9., 12.}
+	roots, err := FindBatch(context.Background(), math.Cos, nil, xInits)
+	if err != nil || len(roots) != len(xInits) {
+		t.Fatalf("Unexpected result: %v / %v", roots, err)
+	}
+	for i, root := range roots {
+		if expected := Find(math.Cos, nil, xInits[i]); root != expected {
+			t.Fatalf("Unexpected root for initial value %v: have %v, want %v", xInits[i], root, expected)
+		}
+	}
+
+	if roots, err := FindBatch(context.Background(), func(x float32) float32 { return x*x - 612 }, nil, []float32{10., -10.}); err != nil || roots[0] != float32(math.Sqrt(612)) || roots[1] != -float32(math.Sqrt(612)) {
+		t.Fatalf("Unexpected result for float32: %v / %v", roots, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if roots, err := FindBatch(ctx, math.Cos, nil, xInits); roots != nil || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Unexpected result for cancelled context: %v / %v", roots, err)
+	}
+}
+
+func TestIterationCallback(t *testing.T) {
+
+	fx := func(x float64) float64 {
+		return x*x - 612
+	}
+
+	var xs []float64
+	res := Solve(fx, nil, 10., WithIterationCallback(func(iter int, x, fxVal float64) bool {
+		if iter != len(xs)+1 || fxVal != fx(x) {
+			t.Fatalf("Unexpected callback arguments: %d / %v / %v", iter, x, fxVal)
+		}
+		xs = append(xs, x)
+		return true
+	}))
+	if !res.Converged || len(xs) != res.Iterations || xs[len(xs)-1] != res.Root {
+		t.Fatalf("Unexpected trace for %+v: %v", res, xs)
+	}
+
+	// Custom stopping rule (based on the relative change of the root estimate)
+	xPrev := 10.
+	root, err := FindErr(fx, nil, xPrev, WithTargetPrecision(1e-15), WithIterationCallback(func(iter int, x, fxVal float64) bool {
+		done := math.Abs(x-xPrev) < 1e-3*math.Abs(x)
+		xPrev = x
+		return !done
+	}))
+	if err != nil || math.Abs(root-math.Sqrt(612)) > 1e-3 {
+		t.Fatalf("Unexpected result for custom stopping rule: %v / %v", root, err)
+	}
+}
+
+func TestTolerances(t *testing.T) {
+
+	// Very large slope at the root, i.e. |f(x)| cannot fall below the residual tolerance
+	// even for the closest floating point number to the root
+	steep := func(x float64) float64 {
+		return 1e12 * math.Sin(x)
+	}
+	if res := Solve(steep, nil, 3.); res.Converged || res.Iterations != 25 {
+		t.Fatalf("Unexpected convergence for residual tolerance: %+v", res)
+	}
+	if res := Solve(steep, nil, 3., WithXTolerance(1e-12, 0.)); !res.Converged || res.Iterations >= 25 || math.Abs(res.Root-math.Pi) > 1e-12 {
+		t.Fatalf("Unexpected result for absolute tolerance on x: %+v", res)
+	}
+
+	// A shared configuration denotes a (relative) tolerance on x, as for the bracketing methods
+	cfg := numerics.Config{Epsilon: 1e-12, MaxIterations: 100, NaNPolicy: numerics.ReturnError}
+	if res := Solve(steep, nil, 3., WithConfig(cfg)); !res.Converged || math.Abs(res.Root-math.Pi) > 1e-12*(1.+math.Pi) {
+		t.Fatalf("Unexpected result for shared configuration: %+v", res)
+	}
+	if root, err := BisectWithConfig(steep, 3., 4., cfg); err != nil || math.Abs(root-math.Pi) > 1e-12*(1.+math.Pi) {
+		t.Fatalf("Unexpected result for shared configuration: %v / %v", root, err)
+	}
+
+	// Very small slope at the root, i.e. |f(x)| falls below the residual tolerance far from
+	// the root
+	flat := func(x float64) float64 {
+		return 1e-9 * (x - 10.) * (x - 10.) * (x - 10.)
+	}
+	if res := Solve(flat, nil, 0.); !res.Converged || math.Abs(res.Root-10.) < 0.1 {
+		t.Fatalf("Unexpected result: %+v", res)
+	}
+	if res := Solve(flat, nil, 0., WithFTolerance(0.), WithXTolerance(0., 1e-6), WithMaxIterations(100)); !res.Converged || math.Abs(res.Root-10.) > 1e-4 {
+		t.Fatalf("Unexpected result for relative tolerance on x: %+v", res)
+	}
+	if res := Solve(func(x float64) float64 { return math.Cos(x) - x*x*x }, nil, 10., WithFTolerance(0.)); res.Converged || res.Iterations != 25 {
+		t.Fatalf("Unexpected convergence without criteria: %+v", res)
+	}
+}
+
+type celsius float32
+
+func TestFloat32(t *testing.T) {
+
+	fx := func(x float32) float32 {
+		return x*x - 612
+	}
+	dfx := func(x float32) float32 {
+		return 2 * x
+	}
+	expected := float32(math.Sqrt(612))
+
+	for _, method := range methods {
+		if root, err := FindErr(fx, dfx, 10., WithMethod(method)); err != nil || math.Abs(float64(root-expected)) > 4e-6 {
+			t.Fatalf("Unexpected result for %s: %v / %v", caseName(method, "float32"), root, err)
+		}
+	}
+	if root, err := FindErr(fx, nil, 10.); err != nil || math.Abs(float64(root-expected)) > 4e-6 {
+		t.Fatalf("Unexpected result for numerical derivative: %v / %v", root, err)
+	}
+	if root := Bisect(fx, 1., 50.); math.Abs(float64(root-expected)) > 4e-6 {
+		t.Fatalf("Unexpected result for bisection: %v", root)
+	}
+	if root, err := BisectErr(fx, 30., 50.); !math.IsNaN(float64(root)) || !errors.Is(err, ErrInvalidBracket) {
+		t.Fatalf("Unexpected result for invalid bracket: %v / %v", root, err)
+	}
+
+	// Named floating point types
+	if root := Find(func(x celsius) celsius {
+		return celsius(math.Cos(float64(x))) - x*x*x
+	}, nil, 0.5); math.Abs(math.Cos(float64(root))-float64(root*root*root)) > 1e-6 {
+		t.Fatalf("Unexpected result for named type: %v", root)
+	}
+}
+
+func TestSecant(t *testing.T) {
+
+	// The same (stateful) method is reused for subsequent calls without derivative
+	method := Secant()
+	for _, xInit := range []float64{10., 30., -5.} {
+		root := Find(func(x float64) float64 {
+			return x*x - 612
+		}, nil, xInit, WithMethod(method))
+
+		if math.Abs(math.Abs(root)-math.Sqrt(612)) > expectedPrecision {
+			t.Fatalf("Unexpected root for initial value %v: %v", xInit, root)
+		}
+	}
+
+	root := Find(func(x float64) float64 {
+		return math.Cos(x) - x*x*x
+	}, nil, 0.5, WithMethod(Secant()), WithLimits(0., 1.))
+	if math.Abs(math.Cos(root)-root*root*root) > expectedPrecision {
+		t.Fatalf("Unexpected root within limits: %v", root)
+	}
+}
+
+func TestSteffensen(t *testing.T) {
+
+	// Starting close to the root, few iterations suffice due to the quadratic convergence
+	for _, cs := range []testCaseNewton{
+		{fx: func(x float64) float64 { return x*x - 612 }, xInit: 25.},
+		{fx: func(x float64) float64 { return math.Cos(x) - x*x*x }, xInit: 0.8},
+		{fx: func(x float64) float64 { return math.Exp(x) - 3. }, xInit: 1.},
+	} {
+		root := Find(cs.fx, nil, cs.xInit, WithMethod(Steffensen), WithMinIterations(1), WithMaxIterations(6))
+		if math.Abs(cs.fx(root)) > expectedPrecision {
+			t.Fatalf("Estimated value of f(x) deviates significantly from expectation for initial value %v: have %v, want 0", cs.xInit, cs.fx(root))
+		}
+	}
+}
+
+func TestFindComplex(t *testing.T) {
+
+	testCases := map[string]struct {
+		fx    func(complex128) complex128
+		zInit complex128
+	}{
+		"NoRealRoots": {
+			fx: func(z complex128) complex128 {
+				return z*z + 1.
+			},
+			zInit: 1.,
+		},
+		"ConjugatePair": {
+			fx: func(z complex128) complex128 {
+				return (z - 1.) * (z*z + 2.*z + 5.)
+			},
+			zInit: -2. + 1i,
+		},
+		"RootsOfUnity": {
+			fx: func(z complex128) complex128 {
+				return z*z*z*z*z - 1.
+			},
+			zInit: 1i,
+		},
+		"Exponential": {
+			fx: func(z complex128) complex128 {
+				return cmplx.Exp(z) + 1.
+			},
+			zInit: 0.5 + 2i,
+		},
+	}
+
+	for testName, cs := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			root := FindComplex(cs.fx, cs.zInit)
+
+			if cmplx.IsNaN(root) || cmplx.IsInf(root) {
+				t.Fatalf("Unexpected non-numerical result for %s: %v", testName, root)
+			}
+
+			if cmplx.Abs(cs.fx(root)) > expectedPrecision {
+				t.Fatalf("Estimated value of f(z) for %s deviates significantly from expectation: have %v, want 0", testName, cs.fx(root))
+			}
+		})
+	}
+
+	if root := FindComplex(func(z complex128) complex128 {
+		return z*z + 1.
+	}, -1i, WithMaxIterations(50), WithTargetPrecision(1e-12)); cmplx.Abs(root+1i) > 1e-12 {
+		t.Fatalf("Unexpected root: %v", root)
+	}
+}
+
+func TestPolynomial(t *testing.T) {
+
+	testCases := map[string]struct {
+		coeffs   []float64
+		expected []complex128
+		tol      float64
+	}{
+		"Constant":  {coeffs: []float64{3., 0.}},
+		"Linear":    {coeffs: []float64{3., -2.}, expected: []complex128{1.5}, tol: 1e-15},
+		"RealRoots": {coeffs: []float64{-6., 11., -6., 1.}, expected: []complex128{1., 2., 3.}, tol: 1e-12},
+		"NoRealRoots": {
+			coeffs:   []float64{1., 0., 1.},
+			expected: []complex128{-1i, 1i},
+			tol:      1e-12,
+		},
+		"ConjugatePair": {
+			coeffs:   []float64{-5., 3., 1., 1., 0.},
+			expected: []complex128{-1 - 2i, -1 + 2i, 1},
+			tol:      1e-12,
+		},
+		"ZeroRoots": {
+			coeffs:   []float64{0., 0., -2., 2.},
+			expected: []complex128{0., 0., 1.},
+			tol:      1e-12,
+		},
+		"DoubleRoot": {
+			coeffs:   []float64{1., -2., 1.},
+			expected: []complex128{1., 1.},
+			tol:      1e-12,
+		},
+		"MultipleRoot": {
+			coeffs:   []float64{-1., 3., -3., 1.},
+			expected: []complex128{1., 1., 1.},
+			tol:      1e-12,
+		},
+		"MixedMultipleRoots": {
+			coeffs:   []float64{4., 0., -3., 1.},
+			expected: []complex128{-1., 2., 2.},
+			tol:      1e-12,
+		},
+		"MultipleComplexRoots": {
+			coeffs:   []float64{1., 0., 2., 0., 1.},
+			expected: []complex128{-1i, -1i, 1i, 1i},
+			tol:      1e-12,
+		},
+		"CloseRoots": {
+			coeffs:   []float64{1.001, -2.001, 1.},
+			expected: []complex128{1., 1.001},
+			tol:      1e-12,
+		},
+	}
+
+	for testName, cs := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			roots := Polynomial(cs.coeffs)
+			if len(roots) != len(cs.expected) {
+				t.Fatalf("Unexpected number of roots for %s: have %v, want %v", testName, roots, cs.expected)
+			}
+			for i, root := range roots {
+				if cmplx.Abs(root-cs.expected[i]) > cs.tol || (imag(cs.expected[i]) == 0 && imag(root) != 0) {
+					t.Fatalf("Unexpected roots for %s: have %v, want %v", testName, roots, cs.expected)
+				}
+			}
+		})
+	}
+
+	// Roots of unity
+	roots := Polynomial([]float64{-1., 0., 0., 0., 0., 0., 0., 1.})
+	if len(roots) != 7 {
+		t.Fatalf("Unexpected number of roots of unity: %v", roots)
+	}
+	for _, root := range roots {
+		if cmplx.Abs(cmplx.Pow(root, 7)-1.) > 1e-12 {
+			t.Fatalf("Unexpected root of unity: %v", root)
+		}
+	}
+}
+
+func TestFindSystem(t *testing.T) {
+
+	// Intersection of the circle x² + y² = 4 and the hyperbola x·y = 1
+	fx := func(x []float64) []float64 {
+		return []float64{x[0]*x[0] + x[1]*x[1] - 4., x[0]*x[1] - 1.}
+	}
+	jacobian := func(x []float64) *linalg.Dense {
+		return linalg.NewDense(2, 2, []float64{2. * x[0], 2. * x[1], x[1], x[0]})
+	}
+	check := func(name string, x []float64) {
+		if res := fx(x); math.Hypot(res[0], res[1]) > expectedPrecision {
+			t.Fatalf("Unexpected solution for %s: %v (F(x) = %v)", name, x, res)
+		}
+	}
+
+	xInit := []float64{2., 0.5}
+	check("analytic Jacobian", FindSystem(fx, jacobian, xInit))
+	check("numerical Jacobian", FindSystem(fx, nil, xInit))
+	if xInit[0] != 2. || xInit[1] != 0.5 {
+		t.Fatalf("Unexpected modification of initial value: %v", xInit)
+	}
+
+	// Restricting the solution to the second branch via limits
+	x := FindSystem(fx, nil, []float64{0.8, 0.8}, WithLimits(0., 1.))
+	check("limits", x)
+	if x[0] > 1. || x[1] < 1. {
+		t.Fatalf("Unexpected solution within limits: %v", x)
+	}
+
+	// Plain Newton iterations diverge for arctan(x) far from the root, requiring damping
+	atan := func(x []float64) []float64 {
+		return []float64{math.Atan(x[0]), x[1] - 3.}
+	}
+	if x := FindSystem(atan, nil, []float64{2., 0.}); !math.IsNaN(x[0]) && math.Abs(x[0]) < expectedPrecision {
+		t.Fatalf("Unexpected convergence without heuristics: %v", x)
+	}
+	if x := FindSystem(atan, nil, []float64{2., 0.}, WithHeuristics()); math.Abs(x[0]) > expectedPrecision || math.Abs(x[1]-3.) > expectedPrecision {
+		t.Fatalf("Unexpected solution with heuristics: %v", x)
+	}
+
+	// Singular Jacobian
+	if x := FindSystem(fx, jacobian, []float64{0., 0.}); !math.IsNaN(x[0]) || !math.IsNaN(x[1]) {
+		t.Fatalf("Unexpected solution for singular Jacobian: %v", x)
+	}
+
+	// Error-returning variant
+	if x, err := FindSystemErr(fx, nil, xInit); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	} else {
+		check("error variant", x)
+	}
+	if x, err := FindSystemErr(fx, jacobian, []float64{0., 0.}); !math.IsNaN(x[0]) || !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected result for singular Jacobian: %v / %v", x, err)
+	}
+	if x, err := FindSystemErr(atan, nil, []float64{2., 0.}, WithMaxIterations(3)); !math.IsNaN(x[0]) || !errors.Is(err, numerics.ErrNoConvergence) {
+		t.Fatalf("Unexpected result for insufficient number of iterations: %v / %v", x, err)
+	}
+	if x, err := FindSystemErr(func(x []float64) []float64 { return x[:1] }, nil, []float64{1., 2.}); !math.IsNaN(x[0]) || !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected result for mismatching dimensions: %v / %v", x, err)
+	}
+
+	// Empty initial value
+	if x, err := FindSystemErr(fx, nil, nil); len(x) != 0 || !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected result for empty initial value: %v / %v", x, err)
+	}
+	if x := FindSystem(fx, nil, []float64{}); x != nil {
+		t.Fatalf("Unexpected solution for empty initial value: %v", x)
+	}
+}
+
 func TestTableHeuristics(t *testing.T) {
 
 	testCases := map[string]testCaseNewton{
@@ -0,0 +1,174 @@
+package root
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/fako1024/numerics"
+	"github.com/fako1024/numerics/linalg"
+)
+
+// FindSystem performs the multidimensional Newton method to solve the system of equations
+// F(x) = 0 for x ∈ ℝⁿ (with F: ℝⁿ → ℝⁿ) starting from the provided initial value, using the
+// provided Jacobian J(x) (with J[i][j] = ∂Fᵢ/∂xⱼ) or, if nil, a numerical approximation via
+// central differences. The limits (see WithLimits) are applied to each component, the target
+// precision to the Euclidean norm of F(x) and the tolerance on x (see WithXTolerance) to the
+// Euclidean norms of x and the step. If heuristics are enabled (see WithHeuristics),
+// Newton steps not reducing the norm of F(x) are successively halved (damped Newton method),
+// extending the region of convergence. Returns NaN for all components if the Jacobian
+// becomes singular or F(x) cannot be evaluated (and nil for an empty initial value)
+func FindSystem(fx func(x []float64) []float64, jacobian func(x []float64) *linalg.Dense, xInit []float64, options ...func(*Finder)) []float64 {
+	x, _ := findSystem(fx, jacobian, xInit, options...)
+	return x
+}
+
+// FindSystemErr performs the multidimensional Newton method (see FindSystem), returning an
+// error (wrapping numerics.ErrDomain or numerics.ErrNoConvergence) along with NaN for all
+// components instead of NaN only or an inaccurate solution if the target precision is not
+// reached. An empty initial value or a system whose dimensions do not match the initial
+// value result in an error wrapping numerics.ErrDomain
+func FindSystemErr(fx func(x []float64) []float64, jacobian func(x []float64) *linalg.Dense, xInit []float64, options ...func(*Finder)) ([]float64, error) {
+	x, err := findSystem(fx, jacobian, xInit, options...)
+	if err != nil {
+		return nanVector(len(xInit)), err
+	}
+
+	return x, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// findSystem performs the multidimensional Newton method (see FindSystem), returning the
+// solution (or NaN for all components) along with the reason for a failure
+func findSystem(fx func(x []float64) []float64, jacobian func(x []float64) *linalg.Dense, xInit []float64, options ...func(*Finder)) ([]float64, error) {
+
+	if len(xInit) == 0 {
+		return nil, fmt.Errorf("%w: empty initial value", numerics.ErrDomain)
+	}
+	if jacobian == nil {
+		jacobian = numericalJacobian(fx)
+	}
+
+	return newFinder(options...).loopSystem(fx, jacobian, slices.Clone(xInit))
+}
+
+// loopSystem executes the root finding loop of the multidimensional Newton method
+func (n *Finder) loopSystem(fx func(x []float64) []float64, jacobian func(x []float64) *linalg.Dense, x []float64) ([]float64, error) {
+
+	fxVal := fx(x)
+	residual := euclideanNorm(fxVal)
+	if len(fxVal) != len(x) {
+		return nanVector(len(x)), fmt.Errorf("%w: system of %d equations for %d variables", numerics.ErrDomain, len(fxVal), len(x))
+	}
+	if math.IsNaN(residual) {
+		return nanVector(len(x)), fmt.Errorf("%w: F(x) is NaN at %v", numerics.ErrDomain, x)
+	}
+
+	xNew := make([]float64, len(x))
+	for nIter := 1; ; nIter++ {
+
+		// Determine the Newton step by solving J(x)·Δ = -F(x)
+		rhs := make([]float64, len(fxVal))
+		for i, v := range fxVal {
+			rhs[i] = -v
+		}
+		step, err := linalg.Solve(jacobian(x), rhs)
+		if err != nil {
+			return nanVector(len(x)), fmt.Errorf("%w: Jacobian at %v: %w", numerics.ErrDomain, x, err)
+		}
+
+		// Perform the (potentially damped) step, guarding against excess situations by
+		// moving halfway towards the respective limit instead
+		var fxNew []float64
+		for lambda := 1.; ; lambda /= 2. {
+			for i := range x {
+				xNew[i] = x[i] + lambda*step[i]
+				if xNew[i] > n.xMax {
+					xNew[i] = 0.5 * (x[i] + n.xMax)
+				} else if xNew[i] < n.xMin {
+					xNew[i] = 0.5 * (x[i] + n.xMin)
+				}
+			}
+			fxNew = fx(xNew)
+			if !n.useHeuristics || euclideanNorm(fxNew) < residual || lambda < systemMinDamping {
+				break
+			}
+		}
+
+		x, xNew = xNew, x
+		fxVal, residual = fxNew, euclideanNorm(fxNew)
+
+		// Determine the step actually performed (i.e. after damping / limiting)
+		for i := range step {
+			step[i] = x[i] - xNew[i]
+		}
+
+		// If the current value is NaN, return it
+		if math.IsNaN(residual) {
+			return nanVector(len(x)), fmt.Errorf("%w: F(x) is NaN at %v", numerics.ErrDomain, x)
+		}
+
+		// If the minimum number of iterations has been performed and the convergence criteria
+		// are fulfilled or the maximum number of iterations has been performed, break
+		if nIter >= n.minIterations {
+			if n.converged(euclideanNorm(x), residual, euclideanNorm(step)) {
+				return x, nil
+			}
+			if nIter >= n.maxIterations {
+				return x, fmt.Errorf("%w: |F(x)| = %v after %d iterations", numerics.ErrNoConvergence, residual, nIter)
+			}
+		}
+	}
+}
+
+// systemMinDamping denotes the minimum damping factor of a Newton step (if heuristics are
+// enabled) before accepting a step that does not reduce the norm of F(x)
+const systemMinDamping = 1e-10
+
+// numericalJacobian returns a numerical approximation of the Jacobian of fx via central
+// differences (see centralDifference)
+func numericalJacobian(fx func(x []float64) []float64) func(x []float64) *linalg.Dense {
+	return func(x []float64) *linalg.Dense {
+
+		shifted := slices.Clone(x)
+		var jac *linalg.Dense
+		for j := range x {
+			h := centralDifferenceStep * math.Max(math.Abs(x[j]), 1.)
+
+			shifted[j] = x[j] + h
+			up := fx(shifted)
+			shifted[j] = x[j] - h
+			down := fx(shifted)
+			width := (x[j] + h) - (x[j] - h)
+			shifted[j] = x[j]
+
+			if jac == nil {
+				jac = linalg.NewDense(len(up), len(x), nil)
+			}
+			for i := range up {
+				jac.Set(i, j, (up[i]-down[i])/width)
+			}
+		}
+
+		return jac
+	}
+}
+
+// euclideanNorm returns the Euclidean norm of a vector
+func euclideanNorm(v []float64) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += x * x
+	}
+	return math.Sqrt(sum)
+}
+
+// nanVector returns a vector of NaN values
+func nanVector(n int) []float64 {
+	res := make([]float64, n)
+	for i := range res {
+		res[i] = math.NaN()
+	}
+	return res
+}
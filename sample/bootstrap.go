@@ -0,0 +1,37 @@
+package sample
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/fako1024/numerics"
+)
+
+// Bootstrap estimates the sampling distribution of a statistic by evaluating it on n
+// resamples (drawn with replacement) of the provided data, returning the n values of the
+// statistic. The resamples are evaluated concurrently (using GOMAXPROCS workers), hence
+// the statistic must be safe for concurrent use. Each resample is drawn from a generator
+// seeded by the random number generator of the sampler, such that the result remains
+// reproducible (see WithSeed) regardless of the scheduling of the workers
+func Bootstrap[T any](data []T, stat func([]T) float64, n int, options ...Option) ([]float64, error) {
+
+	if len(data) == 0 || n < 1 {
+		return nil, fmt.Errorf("%w: invalid number of data points (%d) or resamples (%d)", numerics.ErrDomain, len(data), n)
+	}
+
+	rnd := newRand(options)
+	seeds := make([]uint64, n)
+	for i := range seeds {
+		seeds[i] = rnd.Uint64()
+	}
+
+	return numerics.ParallelMap(context.Background(), func(seed uint64) float64 {
+		rnd := rand.New(rand.NewPCG(seed, seed))
+		resample := make([]T, len(data))
+		for i := range resample {
+			resample[i] = data[rnd.IntN(len(data))]
+		}
+		return stat(resample)
+	}, seeds, 0)
+}
@@ -0,0 +1,127 @@
+package sample
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/fako1024/numerics"
+	"github.com/fako1024/numerics/root"
+)
+
+// defaultGridSize denotes the default number of intervals of the cached inverse CDF
+const defaultGridSize = 1024
+
+// CDFSampler generates random variates of an arbitrary distribution by numerically
+// inverting its cumulative distribution function (CDF)
+type CDFSampler struct {
+	cdf        func(float64) float64
+	xMin, xMax float64
+
+	// Cached inverse CDF, i.e. nodes xs[i] with cdf(xs[i]) = us[i]
+	us, xs []float64
+
+	rnd   *rand.Rand
+	exact bool
+	cfg   numerics.Config
+}
+
+// FromCDF instantiates a sampler for the distribution defined by a (non-decreasing) CDF
+// on the domain [xMin, xMax]. The inverse CDF is computed via bisection on a grid and
+// cached, variates being obtained by linear interpolation between its nodes (unless
+// WithExactInversion is used)
+func FromCDF(cdf func(float64) float64, xMin, xMax float64, options ...Option) (*CDFSampler, error) {
+
+	if !(xMin < xMax) || math.IsInf(xMin, 0) || math.IsInf(xMax, 0) {
+		return nil, fmt.Errorf("%w: invalid domain [%v, %v]", numerics.ErrDomain, xMin, xMax)
+	}
+	uMin, uMax := cdf(xMin), cdf(xMax)
+	if !(uMin < uMax) {
+		return nil, fmt.Errorf("%w: CDF not increasing on [%v, %v]", numerics.ErrDomain, xMin, xMax)
+	}
+
+	opts := newSettings(options)
+	if opts.gridSize < 1 {
+		return nil, fmt.Errorf("%w: invalid grid size %d", numerics.ErrDomain, opts.gridSize)
+	}
+
+	s := &CDFSampler{
+		cdf:   cdf,
+		xMin:  xMin,
+		xMax:  xMax,
+		us:    make([]float64, opts.gridSize+1),
+		xs:    make([]float64, opts.gridSize+1),
+		rnd:   opts.rnd,
+		exact: opts.exact,
+		cfg: numerics.Config{
+			Epsilon:       1e-12 * (xMax - xMin) / (1. + math.Max(math.Abs(xMin), math.Abs(xMax))),
+			MaxIterations: 200,
+			NaNPolicy:     numerics.ReturnError,
+		},
+	}
+
+	// Invert the CDF on an equidistant grid in probability space, exploiting that
+	// the nodes are monotonic to narrow down the bracketing interval
+	s.us[0], s.xs[0] = uMin, xMin
+	s.us[opts.gridSize], s.xs[opts.gridSize] = uMax, xMax
+	for i := 1; i < opts.gridSize; i++ {
+		s.us[i] = uMin + (uMax-uMin)*float64(i)/float64(opts.gridSize)
+
+		x, err := s.invert(s.us[i], s.xs[i-1], xMax)
+		if err != nil {
+			return nil, err
+		}
+		s.xs[i] = x
+	}
+
+	return s, nil
+}
+
+// Quantile returns the value x at which the (normalized) CDF reaches the probability p
+func (s *CDFSampler) Quantile(p float64) float64 {
+	if !(p >= 0. && p <= 1.) {
+		return math.NaN()
+	}
+
+	u := s.us[0] + p*(s.us[len(s.us)-1]-s.us[0])
+	i := sort.SearchFloat64s(s.us, u)
+	if i == 0 {
+		return s.xs[0]
+	}
+	if i == len(s.us) {
+		return s.xs[len(s.xs)-1]
+	}
+
+	if s.exact {
+		if x, err := s.invert(u, s.xs[i-1], s.xs[i]); err == nil {
+			return x
+		}
+	}
+
+	frac := (u - s.us[i-1]) / (s.us[i] - s.us[i-1])
+	return s.xs[i-1] + frac*(s.xs[i]-s.xs[i-1])
+}
+
+// Rand returns a single random variate
+func (s *CDFSampler) Rand() float64 {
+	return s.Quantile(s.rnd.Float64())
+}
+
+// Sample returns n random variates
+func (s *CDFSampler) Sample(n int) []float64 {
+	res := make([]float64, n)
+	for i := range res {
+		res[i] = s.Rand()
+	}
+
+	return res
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (s *CDFSampler) invert(u, a, b float64) (float64, error) {
+	return root.BisectWithConfig(func(x float64) float64 {
+		return s.cdf(x) - u
+	}, a, b, s.cfg)
+}
@@ -0,0 +1,6 @@
+// Package sample provides methods for random sampling, such as bounded (weighted)
+// reservoir samples of data streams, e.g. allowing to compute exact quantiles or to
+// refill a better-binned histogram from a representative sample later on, random
+// variates of arbitrary distributions defined by their CDF, as well as (concurrently
+// evaluated) bootstrap resampling and Monte Carlo integration
+package sample
@@ -0,0 +1,76 @@
+package sample
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"github.com/fako1024/numerics"
+)
+
+// monteCarloBatchSize denotes the number of points evaluated per concurrent batch
+const monteCarloBatchSize = 4096
+
+// MonteCarlo estimates the integral of f over the hyperrectangle [lo, hi] by averaging f at
+// n uniformly distributed random points, returning the estimate and its statistical
+// (standard) error. Batches of points are evaluated concurrently (using GOMAXPROCS workers),
+// hence f must be safe for concurrent use. Each batch is drawn from a generator seeded by
+// the random number generator of the sampler, such that the result remains reproducible
+// (see WithSeed) regardless of the scheduling of the workers
+func MonteCarlo(f func(x []float64) float64, lo, hi []float64, n int, options ...Option) (float64, float64, error) {
+
+	if len(lo) == 0 || len(lo) != len(hi) {
+		return math.NaN(), math.NaN(), fmt.Errorf("%w: invalid dimensions of limits (%d / %d)", numerics.ErrDomain, len(lo), len(hi))
+	}
+	volume := 1.
+	for i := range lo {
+		if !(lo[i] < hi[i]) || math.IsInf(lo[i], 0) || math.IsInf(hi[i], 0) {
+			return math.NaN(), math.NaN(), fmt.Errorf("%w: invalid limits [%v, %v] in dimension %d", numerics.ErrDomain, lo[i], hi[i], i)
+		}
+		volume *= hi[i] - lo[i]
+	}
+	if n < 2 {
+		return math.NaN(), math.NaN(), fmt.Errorf("%w: invalid number of points %d", numerics.ErrDomain, n)
+	}
+
+	// Split the points into batches, each with its own generator
+	type batch struct {
+		seed uint64
+		size int
+	}
+	rnd := newRand(options)
+	batches := make([]batch, 0, (n+monteCarloBatchSize-1)/monteCarloBatchSize)
+	for remaining := n; remaining > 0; remaining -= monteCarloBatchSize {
+		batches = append(batches, batch{seed: rnd.Uint64(), size: min(remaining, monteCarloBatchSize)})
+	}
+
+	sums, err := numerics.ParallelMap(context.Background(), func(b batch) [2]float64 {
+		rnd := rand.New(rand.NewPCG(b.seed, b.seed))
+		x := make([]float64, len(lo))
+
+		var sum, sumSq float64
+		for i := 0; i < b.size; i++ {
+			for j := range x {
+				x[j] = lo[j] + rnd.Float64()*(hi[j]-lo[j])
+			}
+			fx := f(x)
+			sum += fx
+			sumSq += fx * fx
+		}
+		return [2]float64{sum, sumSq}
+	}, batches, 0)
+	if err != nil {
+		return math.NaN(), math.NaN(), err
+	}
+
+	var sum, sumSq float64
+	for _, s := range sums {
+		sum += s[0]
+		sumSq += s[1]
+	}
+	mean := sum / float64(n)
+	variance := math.Max(sumSq/float64(n)-mean*mean, 0.) * float64(n) / float64(n-1)
+
+	return volume * mean, volume * math.Sqrt(variance/float64(n)), nil
+}
@@ -0,0 +1,46 @@
+package sample
+
+import (
+	"math/rand/v2"
+)
+
+// Option denotes a functional option shared by all samplers of this package
+type Option func(*settings)
+
+type settings struct {
+	rnd *rand.Rand
+
+	gridSize int
+	exact    bool
+}
+
+// WithSeed seeds the random number generator used by a sampler, yielding reproducible
+// samples
+func WithSeed(seed uint64) Option {
+	return func(s *settings) {
+		s.rnd = rand.New(rand.NewPCG(seed, seed))
+	}
+}
+
+// WithRand sets the random number generator used by a sampler
+func WithRand(rnd *rand.Rand) Option {
+	return func(s *settings) {
+		s.rnd = rnd
+	}
+}
+
+// WithGridSize sets the number of intervals of the cached, interpolated inverse CDF used
+// by FromCDF (ignored by all other samplers)
+func WithGridSize(n int) Option {
+	return func(s *settings) {
+		s.gridSize = n
+	}
+}
+
+// WithExactInversion enables refining each variate generated by FromCDF via bisection
+// within the bracketing interval of the cached inverse CDF (ignored by all other samplers)
+func WithExactInversion() Option {
+	return func(s *settings) {
+		s.exact = true
+	}
+}
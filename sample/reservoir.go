@@ -0,0 +1,256 @@
+package sample
+
+import (
+	"container/heap"
+	"math"
+	"math/rand/v2"
+)
+
+// Reservoir denotes a bounded uniform random sample of a stream of items of unknown
+// length, maintained via Vitter's / Li's Algorithm L (skipping over items instead of
+// drawing a random number for each one). Each item is associated with a random key and
+// the items with the smallest keys are retained, allowing for exact merging of reservoirs
+type Reservoir[T any] struct {
+	k    int
+	seen int64
+	skip int64
+
+	items keyedItems[T]
+	rnd   *rand.Rand
+}
+
+// NewReservoir instantiates a new reservoir retaining (at most) k items
+func NewReservoir[T any](k int, options ...Option) *Reservoir[T] {
+
+	if k < 1 {
+		panic("reservoir size must be positive")
+	}
+
+	return &Reservoir[T]{
+		k:     k,
+		items: make(keyedItems[T], 0, k),
+		rnd:   newRand(options),
+	}
+}
+
+// Add adds an item from the stream to the reservoir
+func (r *Reservoir[T]) Add(item T) {
+
+	r.seen++
+
+	// Fill the reservoir until it reaches its capacity
+	if len(r.items) < r.k {
+		heap.Push(&r.items, keyedItem[T]{key: r.rnd.Float64(), item: item})
+		if len(r.items) == r.k {
+			r.updateSkip()
+		}
+		return
+	}
+
+	if r.skip > 0 {
+		r.skip--
+		return
+	}
+
+	// Replace the item with the largest key by the current item, whose key is uniformly
+	// distributed below the current largest key
+	r.items[0] = keyedItem[T]{key: r.rnd.Float64() * r.items[0].key, item: item}
+	heap.Fix(&r.items, 0)
+	r.updateSkip()
+}
+
+// Merge merges another reservoir into this one, such that the result is a uniform random
+// sample of the union of both streams
+func (r *Reservoir[T]) Merge(other *Reservoir[T]) {
+	r.seen += other.seen
+	r.items = mergeItems(r.items, other.items, r.k)
+	if len(r.items) == r.k {
+		r.updateSkip()
+	}
+}
+
+// Snapshot returns a copy of the items currently retained in the reservoir (in random order)
+func (r *Reservoir[T]) Snapshot() []T {
+	return r.items.snapshot()
+}
+
+// Len returns the number of items currently retained in the reservoir
+func (r *Reservoir[T]) Len() int {
+	return len(r.items)
+}
+
+// Cap returns the maximum number of items retained in the reservoir
+func (r *Reservoir[T]) Cap() int {
+	return r.k
+}
+
+// Seen returns the number of items added to the reservoir (including merged reservoirs)
+func (r *Reservoir[T]) Seen() int64 {
+	return r.seen
+}
+
+// updateSkip draws the number of items to skip before the next item is accepted, which
+// follows a geometric distribution with the largest retained key as success probability
+func (r *Reservoir[T]) updateSkip() {
+	w := r.items[0].key
+	if w <= 0 {
+		r.skip = math.MaxInt64
+		return
+	}
+	if w >= 1 {
+		r.skip = 0
+		return
+	}
+
+	r.skip = int64(math.Floor(math.Log(1.-r.rnd.Float64()) / math.Log1p(-w)))
+}
+
+// WeightedReservoir denotes a bounded weighted random sample of a stream of items of
+// unknown length, maintained via the A-Res algorithm by Efraimidis and Spirakis (in its
+// numerically stable exponential key form). The probability of an item being retained
+// is proportional to its weight
+type WeightedReservoir[T any] struct {
+	k           int
+	seen        int64
+	totalWeight float64
+
+	items keyedItems[T]
+	rnd   *rand.Rand
+}
+
+// NewWeightedReservoir instantiates a new weighted reservoir retaining (at most) k items
+func NewWeightedReservoir[T any](k int, options ...Option) *WeightedReservoir[T] {
+
+	if k < 1 {
+		panic("reservoir size must be positive")
+	}
+
+	return &WeightedReservoir[T]{
+		k:     k,
+		items: make(keyedItems[T], 0, k),
+		rnd:   newRand(options),
+	}
+}
+
+// Add adds an item with a given (positive) weight from the stream to the reservoir.
+// Items with non-positive weight are counted, but never retained
+func (r *WeightedReservoir[T]) Add(item T, weight float64) {
+
+	r.seen++
+	if weight <= 0 || math.IsNaN(weight) {
+		return
+	}
+	r.totalWeight += weight
+
+	// An exponentially distributed key with rate equal to the weight, retaining the items
+	// with the smallest keys
+	key := r.rnd.ExpFloat64() / weight
+	if len(r.items) < r.k {
+		heap.Push(&r.items, keyedItem[T]{key: key, item: item})
+		return
+	}
+	if key < r.items[0].key {
+		r.items[0] = keyedItem[T]{key: key, item: item}
+		heap.Fix(&r.items, 0)
+	}
+}
+
+// Merge merges another weighted reservoir into this one, such that the result is a
+// weighted random sample of the union of both streams
+func (r *WeightedReservoir[T]) Merge(other *WeightedReservoir[T]) {
+	r.seen += other.seen
+	r.totalWeight += other.totalWeight
+	r.items = mergeItems(r.items, other.items, r.k)
+}
+
+// Snapshot returns a copy of the items currently retained in the reservoir (in random order)
+func (r *WeightedReservoir[T]) Snapshot() []T {
+	return r.items.snapshot()
+}
+
+// Len returns the number of items currently retained in the reservoir
+func (r *WeightedReservoir[T]) Len() int {
+	return len(r.items)
+}
+
+// Cap returns the maximum number of items retained in the reservoir
+func (r *WeightedReservoir[T]) Cap() int {
+	return r.k
+}
+
+// Seen returns the number of items added to the reservoir (including merged reservoirs)
+func (r *WeightedReservoir[T]) Seen() int64 {
+	return r.seen
+}
+
+// TotalWeight returns the sum of weights of all items added to the reservoir (including
+// merged reservoirs)
+func (r *WeightedReservoir[T]) TotalWeight() float64 {
+	return r.totalWeight
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newRand(options []Option) *rand.Rand {
+	return newSettings(options).rnd
+}
+
+func newSettings(options []Option) settings {
+	opts := settings{
+		rnd:      rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+		gridSize: defaultGridSize,
+	}
+
+	// Execute functional options (if any), see options.go for implementation
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return opts
+}
+
+// keyedItem denotes an item associated with its random key
+type keyedItem[T any] struct {
+	key  float64
+	item T
+}
+
+// keyedItems implements a max-heap of keyed items (with the largest key on top)
+type keyedItems[T any] []keyedItem[T]
+
+func (k keyedItems[T]) Len() int           { return len(k) }
+func (k keyedItems[T]) Less(i, j int) bool { return k[i].key > k[j].key }
+func (k keyedItems[T]) Swap(i, j int)      { k[i], k[j] = k[j], k[i] }
+
+func (k *keyedItems[T]) Push(x any) {
+	*k = append(*k, x.(keyedItem[T]))
+}
+
+func (k *keyedItems[T]) Pop() any {
+	old := *k
+	n := len(old)
+	x := old[n-1]
+	*k = old[:n-1]
+	return x
+}
+
+func (k keyedItems[T]) snapshot() []T {
+	res := make([]T, len(k))
+	for i := range k {
+		res[i] = k[i].item
+	}
+	return res
+}
+
+// mergeItems retains the (at most) n items with the smallest keys from both heaps
+func mergeItems[T any](a, b keyedItems[T], n int) keyedItems[T] {
+	for _, item := range b {
+		if len(a) < n {
+			heap.Push(&a, item)
+		} else if item.key < a[0].key {
+			a[0] = item
+			heap.Fix(&a, 0)
+		}
+	}
+	return a
+}
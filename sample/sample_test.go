@@ -0,0 +1,200 @@
+package sample
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/fako1024/numerics"
+)
+
+func TestReservoirUniform(t *testing.T) {
+
+	const (
+		n, k, nTrials = 100, 10, 5000
+	)
+
+	counts := make([]int, n)
+	for trial := 0; trial < nTrials; trial++ {
+		r := NewReservoir[int](k, WithSeed(uint64(trial)))
+		for i := 0; i < n; i++ {
+			r.Add(i)
+		}
+		if r.Len() != k || r.Seen() != n {
+			t.Fatalf("Unexpected reservoir state: len %d, seen %d", r.Len(), r.Seen())
+		}
+		for _, item := range r.Snapshot() {
+			counts[item]++
+		}
+	}
+
+	// Each item should be retained with probability k/n
+	expected := float64(nTrials * k / n)
+	for i, c := range counts {
+		if math.Abs(float64(c)-expected) > 5.*math.Sqrt(expected) {
+			t.Fatalf("Unexpected selection frequency of item %d: want ~%.0f, have %d", i, expected, c)
+		}
+	}
+}
+
+func TestReservoirMerge(t *testing.T) {
+
+	const nTrials = 2000
+
+	fromFirst := 0
+	for trial := 0; trial < nTrials; trial++ {
+		a, b := NewReservoir[int](10, WithSeed(uint64(2*trial))), NewReservoir[int](10, WithSeed(uint64(2*trial+1)))
+		for i := 0; i < 300; i++ {
+			a.Add(0)
+		}
+		for i := 0; i < 100; i++ {
+			b.Add(1)
+		}
+
+		a.Merge(b)
+		if a.Len() != 10 || a.Seen() != 400 {
+			t.Fatalf("Unexpected merged reservoir state: len %d, seen %d", a.Len(), a.Seen())
+		}
+		for _, item := range a.Snapshot() {
+			if item == 0 {
+				fromFirst++
+			}
+		}
+	}
+
+	// Items should originate from the first stream with probability 3/4
+	if frac := float64(fromFirst) / float64(10*nTrials); math.Abs(frac-0.75) > 0.02 {
+		t.Fatalf("Unexpected fraction of items from first stream: want 0.75, have %.3f", frac)
+	}
+}
+
+func TestWeightedReservoir(t *testing.T) {
+
+	const nTrials = 5000
+
+	heavy := 0
+	for trial := 0; trial < nTrials; trial++ {
+		r := NewWeightedReservoir[int](1, WithSeed(uint64(trial)))
+		r.Add(0, 1.)
+		r.Add(1, 3.)
+		r.Add(2, 0.)
+		if r.Snapshot()[0] == 1 {
+			heavy++
+		}
+	}
+
+	// The heavy item should be selected with probability 3/4
+	if frac := float64(heavy) / nTrials; math.Abs(frac-0.75) > 0.03 {
+		t.Fatalf("Unexpected selection frequency of heavy item: want 0.75, have %.3f", frac)
+	}
+}
+
+func TestFromCDF(t *testing.T) {
+
+	expCDF := func(x float64) float64 {
+		return 1. - math.Exp(-x)
+	}
+
+	for _, exact := range []bool{false, true} {
+		options, tol := []Option{WithSeed(42), WithGridSize(2048)}, 1e-2
+		if exact {
+			options, tol = append(options, WithExactInversion()), 1e-9
+		}
+
+		s, err := FromCDF(expCDF, 0., 50., options...)
+		if err != nil {
+			t.Fatalf("Failed to instantiate sampler: %s", err)
+		}
+
+		for _, p := range []float64{0.01, 0.1, 0.5, 0.9, 0.99} {
+			if q := s.Quantile(p); math.Abs(q+math.Log(1.-p)) > tol {
+				t.Fatalf("Unexpected quantile for p=%v (exact: %v): want %v, have %v", p, exact, -math.Log(1.-p), q)
+			}
+		}
+
+		var sum float64
+		samples := s.Sample(100000)
+		for _, x := range samples {
+			if x < 0. || x > 50. {
+				t.Fatalf("Variate outside of domain: %v", x)
+			}
+			sum += x
+		}
+		if mean := sum / float64(len(samples)); math.Abs(mean-1.) > 0.02 {
+			t.Fatalf("Unexpected mean of exponential variates (exact: %v): %v", exact, mean)
+		}
+	}
+
+	if _, err := FromCDF(expCDF, 1., 0.); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected error for invalid domain: %v", err)
+	}
+	if _, err := FromCDF(func(float64) float64 { return 0.5 }, 0., 1.); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected error for constant CDF: %v", err)
+	}
+}
+
+func TestBootstrap(t *testing.T) {
+
+	data := make([]float64, 100)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	mean := func(xs []float64) float64 {
+		var sum float64
+		for _, x := range xs {
+			sum += x
+		}
+		return sum / float64(len(xs))
+	}
+
+	means, err := Bootstrap(data, mean, 2000, WithSeed(42))
+	if err != nil || len(means) != 2000 {
+		t.Fatalf("Failed to bootstrap: %v", err)
+	}
+
+	// The spread of the bootstrapped means approximates the standard error of the mean
+	var sum, sumSq float64
+	for _, m := range means {
+		sum += m
+		sumSq += m * m
+	}
+	avg := sum / float64(len(means))
+	stdErr := math.Sqrt(sumSq/float64(len(means)) - avg*avg)
+	if expected := math.Sqrt((100.*100. - 1.) / 12. / 100.); math.Abs(avg-49.5) > 0.5 || math.Abs(stdErr-expected) > 0.1*expected {
+		t.Fatalf("Unexpected bootstrap distribution: mean %v, standard error %v (want %v)", avg, stdErr, expected)
+	}
+
+	// Results are reproducible regardless of the concurrent evaluation
+	again, _ := Bootstrap(data, mean, 2000, WithSeed(42))
+	for i := range means {
+		if means[i] != again[i] {
+			t.Fatalf("Unexpected non-reproducible result at index %d: %v vs. %v", i, means[i], again[i])
+		}
+	}
+
+	if _, err := Bootstrap(nil, mean, 10); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected error for empty data: %v", err)
+	}
+}
+
+func TestMonteCarlo(t *testing.T) {
+
+	// ∫∫ x·y dx dy over [0, 1] x [0, 2] = 1
+	f := func(x []float64) float64 {
+		return x[0] * x[1]
+	}
+	res, stdErr, err := MonteCarlo(f, []float64{0., 0.}, []float64{1., 2.}, 100000, WithSeed(42))
+	if err != nil || math.Abs(res-1.) > 4.*stdErr || stdErr > 0.01 {
+		t.Fatalf("Unexpected result: %v +- %v / %v", res, stdErr, err)
+	}
+	if again, _, _ := MonteCarlo(f, []float64{0., 0.}, []float64{1., 2.}, 100000, WithSeed(42)); again != res {
+		t.Fatalf("Unexpected non-reproducible result: %v vs. %v", res, again)
+	}
+
+	if _, _, err := MonteCarlo(f, []float64{0.}, []float64{1., 2.}, 100); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected error for mismatching dimensions: %v", err)
+	}
+	if _, _, err := MonteCarlo(f, []float64{0., 1.}, []float64{1., 1.}, 100); !errors.Is(err, numerics.ErrDomain) {
+		t.Fatalf("Unexpected error for empty domain: %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// CountMin denotes a Count-Min sketch, estimating the frequency of items in a stream
+// using sub-linear memory. Estimates never underestimate the true frequency and exceed
+// it by at most epsilon*N (with N the total count) with probability 1-delta
+type CountMin struct {
+	width, depth int
+	total        uint64
+	counts       []uint64
+}
+
+// NewCountMin instantiates a new Count-Min sketch with the given number of counters per
+// row (width) and number of rows (depth)
+func NewCountMin(width, depth int) *CountMin {
+
+	if width < 1 || depth < 1 {
+		panic("width and depth must be positive")
+	}
+
+	return &CountMin{
+		width:  width,
+		depth:  depth,
+		counts: make([]uint64, width*depth),
+	}
+}
+
+// NewCountMinWithEstimates instantiates a new Count-Min sketch whose estimates exceed the
+// true frequency by at most epsilon*N with probability 1-delta
+func NewCountMinWithEstimates(epsilon, delta float64) *CountMin {
+	return NewCountMin(int(math.Ceil(math.E/epsilon)), int(math.Ceil(math.Log(1./delta))))
+}
+
+// Add adds an item with a given count to the sketch
+func (c *CountMin) Add(item []byte, count uint64) {
+	c.total += count
+
+	h1, h2 := split(hash64(item))
+	for i := 0; i < c.depth; i++ {
+		c.counts[i*c.width+c.index(h1, h2, i)] += count
+	}
+}
+
+// AddString adds a string item with a given count to the sketch
+func (c *CountMin) AddString(item string, count uint64) {
+	c.Add([]byte(item), count)
+}
+
+// Estimate returns the estimated frequency of an item
+func (c *CountMin) Estimate(item []byte) uint64 {
+
+	h1, h2 := split(hash64(item))
+	res := uint64(math.MaxUint64)
+	for i := 0; i < c.depth; i++ {
+		res = min(res, c.counts[i*c.width+c.index(h1, h2, i)])
+	}
+
+	return res
+}
+
+// EstimateString returns the estimated frequency of a string item
+func (c *CountMin) EstimateString(item string) uint64 {
+	return c.Estimate([]byte(item))
+}
+
+// Total returns the sum of all counts added to the sketch
+func (c *CountMin) Total() uint64 {
+	return c.total
+}
+
+// Dims returns the width and depth of the sketch
+func (c *CountMin) Dims() (int, int) {
+	return c.width, c.depth
+}
+
+// Merge merges another sketch (with identical width and depth) into this one
+func (c *CountMin) Merge(other *CountMin) error {
+
+	if c.width != other.width || c.depth != other.depth {
+		return ErrIncompatible
+	}
+
+	c.total += other.total
+	for i := range c.counts {
+		c.counts[i] += other.counts[i]
+	}
+
+	return nil
+}
+
+// Reset clears all counts of the sketch
+func (c *CountMin) Reset() {
+	c.total = 0
+	clear(c.counts)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (c *CountMin) MarshalBinary() ([]byte, error) {
+
+	data := make([]byte, 0, 1+3*binary.MaxVarintLen64+8*len(c.counts))
+	data = append(data, encodingVersion)
+	data = binary.AppendUvarint(data, uint64(c.width))
+	data = binary.AppendUvarint(data, uint64(c.depth))
+	data = binary.AppendUvarint(data, c.total)
+	for _, v := range c.counts {
+		data = binary.LittleEndian.AppendUint64(data, v)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (c *CountMin) UnmarshalBinary(data []byte) error {
+
+	if len(data) < 1 || data[0] != encodingVersion {
+		return ErrInvalidData
+	}
+	data = data[1:]
+
+	var header [3]uint64
+	for i := range header {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return ErrInvalidData
+		}
+		header[i], data = v, data[n:]
+	}
+
+	// Bound the dimensions by the available data prior to multiplying them (to prevent
+	// overflows from corrupt input matching the data length)
+	if header[0] < 1 || header[1] < 1 || header[1] > uint64(len(data))/8/header[0] ||
+		uint64(len(data)) != 8*header[0]*header[1] {
+		return ErrInvalidData
+	}
+	width, depth := int(header[0]), int(header[1])
+
+	c.width, c.depth, c.total = width, depth, header[2]
+	c.counts = make([]uint64, width*depth)
+	for i := range c.counts {
+		c.counts[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// index determines the counter index of row i via double hashing
+func (c *CountMin) index(h1, h2 uint64, i int) int {
+	return int((h1 + uint64(i)*h2) % uint64(c.width))
+}
+
+func split(h uint64) (uint64, uint64) {
+	return h & 0xffffffff, (h >> 32) | 1
+}
@@ -0,0 +1,138 @@
+package sketch
+
+import (
+	"math"
+	"math/bits"
+)
+
+const (
+	minPrecision = 4
+	maxPrecision = 18
+)
+
+// HyperLogLog denotes a HyperLogLog sketch, estimating the number of distinct items in a
+// stream using 2^precision registers of one byte each. The relative standard error of the
+// estimate is approximately 1.04/sqrt(2^precision)
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+}
+
+// NewHyperLogLog instantiates a new HyperLogLog sketch with the given precision (between
+// 4 and 18)
+func NewHyperLogLog(precision uint8) *HyperLogLog {
+
+	if precision < minPrecision || precision > maxPrecision {
+		panic("precision must be between 4 and 18")
+	}
+
+	return &HyperLogLog{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}
+}
+
+// Add adds an item to the sketch
+func (h *HyperLogLog) Add(item []byte) {
+	x := hash64(item)
+
+	// The first bits determine the register, the position of the leftmost one bit among
+	// the remaining bits determines the value
+	idx := x >> (64 - h.precision)
+	rank := uint8(bits.LeadingZeros64(x<<h.precision|1<<(h.precision-1))) + 1
+
+	h.registers[idx] = max(h.registers[idx], rank)
+}
+
+// AddString adds a string item to the sketch
+func (h *HyperLogLog) AddString(item string) {
+	h.Add([]byte(item))
+}
+
+// Count returns the estimated number of distinct items added to the sketch
+func (h *HyperLogLog) Count() uint64 {
+
+	m := float64(len(h.registers))
+	sum, zeros := 0., 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1., -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := alpha(len(h.registers)) * m * m / sum
+
+	// Apply linear counting for small cardinalities
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(math.Round(estimate))
+}
+
+// Precision returns the precision of the sketch
+func (h *HyperLogLog) Precision() uint8 {
+	return h.precision
+}
+
+// Merge merges another sketch (with identical precision) into this one, such that the
+// result estimates the number of distinct items in the union of both streams
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+
+	if h.precision != other.precision {
+		return ErrIncompatible
+	}
+
+	for i := range h.registers {
+		h.registers[i] = max(h.registers[i], other.registers[i])
+	}
+
+	return nil
+}
+
+// Reset clears all registers of the sketch
+func (h *HyperLogLog) Reset() {
+	clear(h.registers)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+len(h.registers))
+	data = append(data, encodingVersion, h.precision)
+
+	return append(data, h.registers...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+
+	if len(data) < 2 || data[0] != encodingVersion {
+		return ErrInvalidData
+	}
+
+	precision := data[1]
+	if precision < minPrecision || precision > maxPrecision || len(data) != 2+1<<precision {
+		return ErrInvalidData
+	}
+
+	h.precision = precision
+	h.registers = make([]uint8, 1<<precision)
+	copy(h.registers, data[2:])
+
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// alpha returns the bias correction constant for m registers
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1. + 1.079/float64(m))
+}
@@ -0,0 +1,36 @@
+// Package sketch provides compact, mergeable streaming sketches answering approximate
+// frequency (Count-Min) and cardinality (HyperLogLog) questions over data streams, e.g.
+// complementing histograms of the same data
+package sketch
+
+import (
+	"errors"
+	"hash/fnv"
+)
+
+var (
+	// ErrIncompatible denotes that two sketches cannot be merged due to different parameters
+	ErrIncompatible = errors.New("incompatible sketch parameters")
+
+	// ErrInvalidData denotes that serialized data cannot be decoded
+	ErrInvalidData = errors.New("invalid serialized sketch data")
+)
+
+// encodingVersion denotes the version of the binary serialization format
+const encodingVersion = 1
+
+// hash64 computes a well-mixed 64 bit hash of the data (FNV-1a followed by the
+// finalizer of SplitMix64), which is stable across processes and platforms
+func hash64(data []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(data)
+
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
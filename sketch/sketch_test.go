@@ -0,0 +1,85 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestCountMin(t *testing.T) {
+
+	c := NewCountMinWithEstimates(0.001, 0.01)
+	for i := 0; i < 1000; i++ {
+		c.AddString(fmt.Sprintf("item-%d", i), uint64(i%10+1))
+	}
+	c.AddString("GET", 5000)
+
+	if est := c.EstimateString("GET"); est < 5000 || est > 5000+uint64(0.001*float64(c.Total())) {
+		t.Fatalf("Unexpected frequency estimate: have %d", est)
+	}
+	if est := c.EstimateString("item-7"); est < 8 {
+		t.Fatalf("Unexpected underestimate of frequency: have %d", est)
+	}
+
+	data, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error serializing sketch: %s", err)
+	}
+	restored := new(CountMin)
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected error deserializing sketch: %s", err)
+	}
+	if err := restored.Merge(c); err != nil {
+		t.Fatalf("Unexpected error merging sketches: %s", err)
+	}
+	if est := restored.EstimateString("GET"); est < 10000 || restored.Total() != 2*c.Total() {
+		t.Fatalf("Unexpected frequency estimate after merge: have %d", est)
+	}
+
+	if err := restored.Merge(NewCountMin(10, 2)); err != ErrIncompatible {
+		t.Fatalf("Unexpected error merging incompatible sketches: %v", err)
+	}
+
+	// Corrupt dimensions (whose product overflows) must be rejected
+	for _, dims := range [][2]uint64{{1 << 61, 8}, {8, 1 << 61}, {1 << 63, 2}, {0, 1}, {2, 2}} {
+		corrupt := []byte{data[0]}
+		corrupt = binary.AppendUvarint(corrupt, dims[0])
+		corrupt = binary.AppendUvarint(corrupt, dims[1])
+		corrupt = binary.AppendUvarint(corrupt, 0)
+		if err := new(CountMin).UnmarshalBinary(corrupt); err != ErrInvalidData {
+			t.Fatalf("Unexpected error for corrupt dimensions %v: %v", dims, err)
+		}
+	}
+}
+
+func TestHyperLogLog(t *testing.T) {
+
+	for _, n := range []int{10, 1000, 100000} {
+		a, b := NewHyperLogLog(14), NewHyperLogLog(14)
+		for i := 0; i < n; i++ {
+			a.AddString(fmt.Sprintf("a-%d", i))
+			a.AddString(fmt.Sprintf("a-%d", i))
+			b.AddString(fmt.Sprintf("b-%d", i))
+		}
+
+		if count := a.Count(); math.Abs(float64(count)-float64(n)) > 0.03*float64(n)+1 {
+			t.Fatalf("Unexpected cardinality estimate: want ~%d, have %d", n, count)
+		}
+
+		data, err := b.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Unexpected error serializing sketch: %s", err)
+		}
+		restored := new(HyperLogLog)
+		if err := restored.UnmarshalBinary(data); err != nil {
+			t.Fatalf("Unexpected error deserializing sketch: %s", err)
+		}
+		if err := a.Merge(restored); err != nil {
+			t.Fatalf("Unexpected error merging sketches: %s", err)
+		}
+		if count := a.Count(); math.Abs(float64(count)-float64(2*n)) > 0.03*float64(2*n)+1 {
+			t.Fatalf("Unexpected cardinality estimate after merge: want ~%d, have %d", 2*n, count)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+//go:build ignore
+
+// This program generates the embedded reference tables via math/big
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/fako1024/numerics/testutil"
+)
+
+var (
+	params = []int{1, 2, 3, 5, 8, 13, 20, 40}
+	xs     = []float64{0.01, 0.1, 0.23, 0.5, 0.77, 0.9, 0.99}
+)
+
+func main() {
+
+	var beta, betaReg, binomial testutil.Table
+	for _, a := range params {
+		for _, b := range params {
+			val, _ := testutil.BetaExact(a, b, testutil.DefaultPrecision).Float64()
+			beta.Cases = append(beta.Cases, testutil.Case{Args: []float64{float64(a), float64(b)}, Want: val})
+
+			for _, x := range xs {
+				val, _ := testutil.BetaIncompleteRegularExact(x, a, b, testutil.DefaultPrecision).Float64()
+				betaReg.Cases = append(betaReg.Cases, testutil.Case{Args: []float64{x, float64(a), float64(b)}, Want: val})
+
+				if a <= b {
+					val, _ := testutil.BinomialExact(x, a, b, testutil.DefaultPrecision).Float64()
+					binomial.Cases = append(binomial.Cases, testutil.Case{Args: []float64{x, float64(a), float64(b)}, Want: val})
+				}
+			}
+		}
+	}
+
+	write("beta", beta, "a, b, B(a, b)")
+	write("beta_incomplete_regular", betaReg, "x, a, b, Iₓ(a, b)")
+	write("binomial", binomial, "x, k, n, xᵏ (1-x)ⁿ⁻ᵏ")
+}
+
+func write(name string, t testutil.Table, header string) {
+	f, err := os.Create(filepath.Join("tables", name+".csv"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	if err := testutil.WriteTable(f, t, header); err != nil {
+		log.Fatal(err)
+	}
+}
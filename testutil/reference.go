@@ -0,0 +1,60 @@
+package testutil
+
+import (
+	"math/big"
+)
+
+// DefaultPrecision denotes the default mantissa precision (in bits) used for the computation
+// of reference values
+const DefaultPrecision = 512
+
+// BetaExact computes the complete beta function B(a, b) for positive integer a, b
+func BetaExact(a, b int, prec uint) *big.Float {
+
+	// B(a, b) = (a-1)!(b-1)! / (a+b-1)!
+	num := new(big.Int).Mul(factorial(a-1), factorial(b-1))
+	res := new(big.Float).SetPrec(prec).SetInt(num)
+
+	return res.Quo(res, new(big.Float).SetPrec(prec).SetInt(factorial(a+b-1)))
+}
+
+// BetaIncompleteRegularExact computes the regularized incomplete beta function Iₓ(a, b)
+// for positive integer a, b
+func BetaIncompleteRegularExact(x float64, a, b int, prec uint) *big.Float {
+
+	// Iₓ(a, b) = Σⱼ₌ₐ..ₐ₊ᵦ₋₁ C(a+b-1, j) xʲ (1-x)ᵃ⁺ᵇ⁻¹⁻ʲ
+	n := a + b - 1
+	bx := new(big.Float).SetPrec(prec).SetFloat64(x)
+	bxc := new(big.Float).SetPrec(prec).Sub(new(big.Float).SetPrec(prec).SetInt64(1), bx)
+
+	res := new(big.Float).SetPrec(prec)
+	for j := a; j <= n; j++ {
+		term := new(big.Float).SetPrec(prec).SetInt(new(big.Int).Binomial(int64(n), int64(j)))
+		term.Mul(term, pow(bx, j, prec))
+		term.Mul(term, pow(bxc, n-j, prec))
+		res.Add(res, term)
+	}
+
+	return res
+}
+
+// BinomialExact computes the binomial term xᵏ (1-x)ⁿ⁻ᵏ for non-negative integer k <= n
+func BinomialExact(x float64, k, n int, prec uint) *big.Float {
+	bx := new(big.Float).SetPrec(prec).SetFloat64(x)
+	bxc := new(big.Float).SetPrec(prec).Sub(new(big.Float).SetPrec(prec).SetInt64(1), bx)
+
+	return new(big.Float).SetPrec(prec).Mul(pow(bx, k, prec), pow(bxc, n-k, prec))
+}
+
+func factorial(n int) *big.Int {
+	return new(big.Int).MulRange(1, int64(n))
+}
+
+func pow(x *big.Float, n int, prec uint) *big.Float {
+	res := new(big.Float).SetPrec(prec).SetInt64(1)
+	for i := 0; i < n; i++ {
+		res.Mul(res, x)
+	}
+
+	return res
+}
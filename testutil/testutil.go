@@ -0,0 +1,178 @@
+// Package testutil provides a framework to verify the accuracy of numerical implementations
+// against embedded high-precision reference tables (generated via math/big), reporting the
+// maximum deviation in units in the last place (ULP) on the current platform
+package testutil
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:generate go run gen.go
+
+//go:embed tables/*.csv
+var tables embed.FS
+
+// ErrInvalidTable denotes that a reference table cannot be parsed
+var ErrInvalidTable = errors.New("invalid reference table")
+
+// Case denotes a single reference case, i.e. the function arguments and the expected result
+type Case struct {
+	Args []float64
+	Want float64
+}
+
+// Table denotes a named reference table for a function
+type Table struct {
+	Name  string
+	Cases []Case
+}
+
+// Result denotes the outcome of a verification of a function against a reference table
+type Result struct {
+	Table    string
+	Platform string
+	N        int
+
+	MaxULP uint64
+	Worst  Case
+	Got    float64
+}
+
+// String returns a human-readable summary of the result
+func (r Result) String() string {
+	return fmt.Sprintf("%s [%s]: %d cases, max. error %d ULP (args %v: want %.17g, have %.17g)",
+		r.Table, r.Platform, r.N, r.MaxULP, r.Worst.Args, r.Worst.Want, r.Got)
+}
+
+// Tables returns the names of all embedded reference tables
+func Tables() []string {
+	entries, err := tables.ReadDir("tables")
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".csv"))
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// LoadTable loads an embedded reference table by name
+func LoadTable(name string) (Table, error) {
+	f, err := tables.Open(path.Join("tables", name+".csv"))
+	if err != nil {
+		return Table{}, err
+	}
+	defer f.Close()
+
+	return ParseTable(name, f)
+}
+
+// ParseTable parses a reference table (one case per line, comma-separated arguments followed
+// by the expected result, lines starting with '#' being ignored)
+func ParseTable(name string, r io.Reader) (Table, error) {
+
+	t := Table{Name: name}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, ",")
+		if len(fields) < 2 {
+			return Table{}, fmt.Errorf("%w: %s, line %d: too few fields", ErrInvalidTable, name, line)
+		}
+		vals := make([]float64, len(fields))
+		for i, field := range fields {
+			val, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil {
+				return Table{}, fmt.Errorf("%w: %s, line %d: %s", ErrInvalidTable, name, line, err)
+			}
+			vals[i] = val
+		}
+		t.Cases = append(t.Cases, Case{Args: vals[:len(vals)-1], Want: vals[len(vals)-1]})
+	}
+
+	return t, scanner.Err()
+}
+
+// WriteTable writes a reference table in the format understood by ParseTable
+func WriteTable(w io.Writer, t Table, header string) error {
+	if header != "" {
+		if _, err := fmt.Fprintf(w, "# %s\n", header); err != nil {
+			return err
+		}
+	}
+	for _, c := range t.Cases {
+		fields := make([]string, 0, len(c.Args)+1)
+		for _, arg := range c.Args {
+			fields = append(fields, strconv.FormatFloat(arg, 'g', -1, 64))
+		}
+		fields = append(fields, strconv.FormatFloat(c.Want, 'g', -1, 64))
+		if _, err := fmt.Fprintln(w, strings.Join(fields, ",")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Verify evaluates a function for all cases of a reference table and determines the maximum
+// error in ULP
+func Verify(t Table, f func(args ...float64) float64) Result {
+
+	res := Result{
+		Table:    t.Name,
+		Platform: runtime.GOOS + "/" + runtime.GOARCH,
+		N:        len(t.Cases),
+	}
+	for i, c := range t.Cases {
+		got := f(c.Args...)
+		if dist := ULP(got, c.Want); dist > res.MaxULP || i == 0 {
+			res.MaxULP, res.Worst, res.Got = dist, c, got
+		}
+	}
+
+	return res
+}
+
+// ULP returns the distance between two values in units in the last place, i.e. the number
+// of representable float64 values between them (NaN being considered equal to NaN only)
+func ULP(a, b float64) uint64 {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		if math.IsNaN(a) && math.IsNaN(b) {
+			return 0
+		}
+		return math.MaxUint64
+	}
+
+	oa, ob := ordered(a), ordered(b)
+	if oa > ob {
+		return uint64(oa) - uint64(ob)
+	}
+	return uint64(ob) - uint64(oa)
+}
+
+// ordered maps a float64 onto an integer that is monotonic in the value (-0 and +0 coinciding)
+func ordered(x float64) int64 {
+	bits := int64(math.Float64bits(x))
+	if bits < 0 {
+		return math.MinInt64 - bits
+	}
+	return bits
+}
@@ -0,0 +1,90 @@
+package testutil
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestULP(t *testing.T) {
+
+	type testCaseULP struct {
+		a, b     float64
+		expected uint64
+	}
+
+	var testTableULP = []testCaseULP{
+		{1., 1., 0},
+		{1., math.Nextafter(1., 2.), 1},
+		{math.Nextafter(1., 0.), math.Nextafter(1., 2.), 2},
+		{0., math.Copysign(0., -1.), 0},
+		{-math.SmallestNonzeroFloat64, math.SmallestNonzeroFloat64, 2},
+		{math.Inf(1), math.Inf(1), 0},
+		{math.NaN(), math.NaN(), 0},
+		{math.NaN(), 1., math.MaxUint64},
+	}
+
+	for _, cs := range testTableULP {
+		if dist := ULP(cs.a, cs.b); dist != cs.expected {
+			t.Fatalf("Unexpected ULP distance between %v and %v: want %d, have %d", cs.a, cs.b, cs.expected, dist)
+		}
+		if dist := ULP(cs.b, cs.a); dist != cs.expected {
+			t.Fatalf("Unexpected (reversed) ULP distance between %v and %v: want %d, have %d", cs.b, cs.a, cs.expected, dist)
+		}
+	}
+}
+
+func TestTables(t *testing.T) {
+
+	names := Tables()
+	if len(names) != 3 {
+		t.Fatalf("Unexpected embedded reference tables: %v", names)
+	}
+
+	for _, name := range names {
+		table, err := LoadTable(name)
+		if err != nil {
+			t.Fatalf("Failed to load reference table %s: %s", name, err)
+		}
+
+		buf := bytes.NewBuffer(nil)
+		if err := WriteTable(buf, table, "header"); err != nil {
+			t.Fatalf("Failed to write reference table %s: %s", name, err)
+		}
+		parsed, err := ParseTable(name, buf)
+		if err != nil {
+			t.Fatalf("Failed to parse reference table %s: %s", name, err)
+		}
+		if len(parsed.Cases) != len(table.Cases) || len(table.Cases) == 0 {
+			t.Fatalf("Unexpected number of cases in reference table %s: %d", name, len(parsed.Cases))
+		}
+	}
+
+	if _, err := ParseTable("invalid", strings.NewReader("1,a\n")); !errors.Is(err, ErrInvalidTable) {
+		t.Fatalf("Unexpected error for invalid table: %v", err)
+	}
+}
+
+func TestExact(t *testing.T) {
+
+	// I₀.₅(a, a) = 0.5 by symmetry, B(1, b) = 1/b
+	if val, _ := BetaIncompleteRegularExact(0.5, 7, 7, DefaultPrecision).Float64(); val != 0.5 {
+		t.Fatalf("Unexpected exact regularized incomplete beta function: %v", val)
+	}
+	if val, _ := BetaExact(1, 8, DefaultPrecision).Float64(); val != 0.125 {
+		t.Fatalf("Unexpected exact beta function: %v", val)
+	}
+	if val, _ := BinomialExact(0.5, 2, 3, DefaultPrecision).Float64(); val != 0.125 {
+		t.Fatalf("Unexpected exact binomial term: %v", val)
+	}
+
+	table, _ := LoadTable("beta")
+	if res := Verify(table, func(args ...float64) float64 {
+		val, _ := BetaExact(int(args[0]), int(args[1]), DefaultPrecision).Float64()
+		return val
+	}); res.MaxULP != 0 {
+		t.Fatalf("Unexpected deviation of reference table from exact computation: %s", res)
+	}
+}